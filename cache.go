@@ -1,19 +1,84 @@
 package validator
 
-import "sync"
+import (
+	"reflect"
+	"sync"
+)
 
+// cacheKey identifies parsed struct information.
+//
+// reflect.Type values are comparable and unique per type, which means anonymous structs and function local types
+// sharing the same name never collide. Since tag names, including the rules tag name, their case sensitivity, strict
+// parsing, tag limits and the function separator determine how fields are parsed, they are part of the key as well,
+// allowing per call options to use different tag names.
+type cacheKey struct {
+	structType       reflect.Type
+	filterTagName    string
+	triggerTagName   string
+	validatorTagName string
+	messageTagName   string
+	labelTagName     string
+	flagTagName      string
+	rulesTagName     string
+	separator        string
+	caseInsensitive  bool
+	strict           bool
+	strictTags       bool
+	limits           TagComplexityLimits
+}
+
+func newCacheKey(t reflect.Type, opts *ValidationOptions) cacheKey {
+	return cacheKey{
+		structType:       t,
+		filterTagName:    opts.FilterTagName,
+		triggerTagName:   opts.TriggerTagName,
+		validatorTagName: opts.ValidatorTagName,
+		messageTagName:   opts.MessageTagName,
+		labelTagName:     opts.LabelTagName,
+		flagTagName:      opts.FlagTagName,
+		rulesTagName:     opts.RulesTagName,
+		separator:        opts.FunctionSeparator,
+		caseInsensitive:  opts.CaseInsensitiveTagNames,
+		strict:           opts.StrictTagParsing,
+		strictTags:       opts.StrictTags,
+		limits:           opts.TagLimits,
+	}
+}
+
+// cacheEntry holds parsed field contexts along with the generation of the functions they refer to
+type cacheEntry struct {
+	contexts   []*fieldContext
+	generation uint64
+}
+
+// fieldCache stores parsed field contexts keyed by struct type and tag names.
+//
+// Entries are stored with the generation of the validator and filter functions of the instance at the time parsing
+// started. Entries of another generation are stale: they may refer to replaced functions or lack newly added ones,
+// and are reported as missing so that the struct is parsed again.
 type fieldCache struct {
 	backend sync.Map
 }
 
-func (c *fieldCache) Get(path string) (fc []*fieldContext, has bool) {
-	val, has := c.backend.Load(path)
+func (c *fieldCache) Get(t reflect.Type, opts *ValidationOptions, generation uint64) (fc []*fieldContext, has bool) {
+	val, has := c.backend.Load(newCacheKey(t, opts))
 	if has {
-		return val.([]*fieldContext), true
+		entry := val.(cacheEntry)
+		if entry.generation == generation {
+			return entry.contexts, true
+		}
 	}
 	return nil, false
 }
 
-func (c *fieldCache) Store(path string, fc []*fieldContext) {
-	c.backend.Store(path, fc)
+func (c *fieldCache) Store(t reflect.Type, opts *ValidationOptions, generation uint64, fc []*fieldContext) {
+	c.backend.Store(newCacheKey(t, opts), cacheEntry{contexts: fc, generation: generation})
+}
+
+// Clear removes all cached entries
+func (c *fieldCache) Clear() {
+	c.backend.Range(func(key, _ any) bool {
+		c.backend.Delete(key)
+		return true
+	})
 }
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"go/types"
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+var (
+	emptyStructType    = reflect.TypeOf(struct{}{})
+	emptyInterfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+	timeType           = reflect.TypeOf(time.Time{})
+)
+
+// basicTypes maps the kinds of basic types to the corresponding reflect types
+var basicTypes = map[types.BasicKind]reflect.Type{
+	types.Bool:          reflect.TypeOf(false),
+	types.Int:           reflect.TypeOf(int(0)),
+	types.Int8:          reflect.TypeOf(int8(0)),
+	types.Int16:         reflect.TypeOf(int16(0)),
+	types.Int32:         reflect.TypeOf(int32(0)),
+	types.Int64:         reflect.TypeOf(int64(0)),
+	types.Uint:          reflect.TypeOf(uint(0)),
+	types.Uint8:         reflect.TypeOf(uint8(0)),
+	types.Uint16:        reflect.TypeOf(uint16(0)),
+	types.Uint32:        reflect.TypeOf(uint32(0)),
+	types.Uint64:        reflect.TypeOf(uint64(0)),
+	types.Uintptr:       reflect.TypeOf(uintptr(0)),
+	types.Float32:       reflect.TypeOf(float32(0)),
+	types.Float64:       reflect.TypeOf(float64(0)),
+	types.Complex64:     reflect.TypeOf(complex64(0)),
+	types.Complex128:    reflect.TypeOf(complex128(0)),
+	types.String:        reflect.TypeOf(""),
+	types.UnsafePointer: reflect.TypeOf(unsafe.Pointer(nil)),
+}
+
+// reflectStruct builds a struct type with the exported fields of the given struct type, whose values have the same
+// kinds, so that its tags can be analyzed without the type being compiled in. Tags are copied if requested.
+//
+// Named struct types other than time.Time are replaced with empty structs, as they are checked on their own, and the
+// fields promoted from embedded structs are added without tags, so that fields can reference them.
+func reflectStruct(structType *types.Struct, tags bool) reflect.Type {
+	var fields []reflect.StructField
+	names := make(map[string]bool)
+	add := func(field *types.Var, tag string) {
+		if !field.Exported() || names[field.Name()] {
+			return
+		}
+		names[field.Name()] = true
+		fields = append(fields, reflect.StructField{Name: field.Name(), Type: reflectType(field.Type()), Tag: reflect.StructTag(tag)})
+	}
+
+	var embedded []*types.Struct
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		tag := ""
+		if tags {
+			tag = structType.Tag(i)
+		}
+		add(field, tag)
+		if inner, ok := embeddedStruct(field); ok {
+			embedded = append(embedded, inner)
+		}
+	}
+
+	// promoted fields, shallower ones first
+	visited := map[*types.Struct]bool{structType: true}
+	for len(embedded) > 0 {
+		inner := embedded[0]
+		embedded = embedded[1:]
+		if visited[inner] {
+			continue
+		}
+		visited[inner] = true
+		for i := 0; i < inner.NumFields(); i++ {
+			field := inner.Field(i)
+			add(field, "")
+			if next, ok := embeddedStruct(field); ok {
+				embedded = append(embedded, next)
+			}
+		}
+	}
+
+	return reflect.StructOf(fields)
+}
+
+// embeddedStruct returns the struct type of the given field if it is an embedded struct or struct pointer
+func embeddedStruct(field *types.Var) (*types.Struct, bool) {
+	if !field.Embedded() {
+		return nil, false
+	}
+	t := field.Type()
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+	structType, ok := t.Underlying().(*types.Struct)
+	return structType, ok
+}
+
+// reflectType returns a reflect type of the same kind as the given type, see reflectStruct
+func reflectType(t types.Type) reflect.Type {
+	switch t := t.(type) {
+	case *types.Named:
+		if obj := t.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+			return timeType
+		}
+		if _, ok := t.Underlying().(*types.Struct); ok {
+			return emptyStructType
+		}
+		return reflectType(t.Underlying())
+	case *types.Basic:
+		if basic, ok := basicTypes[t.Kind()]; ok {
+			return basic
+		}
+	case *types.Pointer:
+		return reflect.PointerTo(reflectType(t.Elem()))
+	case *types.Slice:
+		return reflect.SliceOf(reflectType(t.Elem()))
+	case *types.Array:
+		return reflect.ArrayOf(int(t.Len()), reflectType(t.Elem()))
+	case *types.Map:
+		return reflect.MapOf(reflectType(t.Key()), reflectType(t.Elem()))
+	case *types.Chan:
+		dir := reflect.BothDir
+		switch t.Dir() {
+		case types.SendOnly:
+			dir = reflect.SendDir
+		case types.RecvOnly:
+			dir = reflect.RecvDir
+		}
+		return reflect.ChanOf(dir, reflectType(t.Elem()))
+	case *types.Signature:
+		return reflect.TypeOf(func() {})
+	case *types.Struct:
+		return reflectStruct(t, true)
+	case *types.Interface:
+		return emptyInterfaceType
+	default:
+		// aliases and type parameters
+		if underlying := t.Underlying(); underlying != t {
+			return reflectType(underlying)
+		}
+	}
+	return emptyInterfaceType
+}
@@ -0,0 +1,19 @@
+module github.com/SharkFourSix/go-struct-validator/cmd/vet
+
+go 1.22.0
+
+require (
+	github.com/SharkFourSix/go-struct-validator v0.0.0-20261016131943-159b31f0357c
+	github.com/stretchr/testify v1.8.2
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.3.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/exp v0.0.0-20230321023759-10a507213a29 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
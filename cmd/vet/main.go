@@ -0,0 +1,233 @@
+// Command vet statically checks the validator tags of the struct types declared in Go packages, reporting the
+// problems validation would report at runtime without executing any code of the packages:
+//
+//	go run github.com/SharkFourSix/go-struct-validator/cmd/vet@latest ./...
+//
+// Every named struct type with a field carrying a validator, filter, flags or trigger tag is checked like
+// validator.AnalyzeType does: unknown validators, filters and flags, argument counts and values, malformed entries,
+// references to unknown fields, unsupported kinds and duplicate validators. Problems are printed as
+// file:line:column diagnostics and the command exits with status 1 if any is found, or 2 if the packages cannot be
+// loaded.
+//
+// Custom validators, filters, rule sets, aliases and patterns are unknown to the command. List them in a registration
+// file passed with -functions, one per line:
+//
+//	# comments and blank lines are ignored
+//	validator is_sku
+//	filter slugify
+//	ruleset contact required|email
+//	alias username required|alphanum|length(3,30)
+//	pattern sku ^[A-Z]{3}-\d{6}$
+//
+// Tags of unexported fields are never read, and struct types nested within checked types are checked on their own.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	validator "github.com/SharkFourSix/go-struct-validator"
+	"golang.org/x/tools/go/packages"
+)
+
+func main() {
+	functions := flag.String("functions", "", "registration file listing custom validators, filters, rule sets, aliases and patterns")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: vet [-functions file] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	os.Exit(run(flag.Args(), *functions, os.Stdout, os.Stderr))
+}
+
+// run checks the given package patterns, writing diagnostics to stdout and errors to stderr, and returns the exit
+// status
+func run(patterns []string, functions string, stdout io.Writer, stderr io.Writer) int {
+	// registration files may replace packaged functions
+	v := validator.New(func(opts *validator.ValidationOptions) {
+		opts.NoPanicOnFunctionConflict = true
+	})
+	if functions != "" {
+		if err := register(v, functions); err != nil {
+			fmt.Fprintln(stderr, "vet:", err)
+			return 2
+		}
+	}
+
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		fmt.Fprintln(stderr, "vet:", err)
+		return 2
+	}
+	loadErrors := 0
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			fmt.Fprintln(stderr, err)
+			loadErrors++
+		}
+	})
+	if loadErrors > 0 {
+		return 2
+	}
+
+	diagnostics := 0
+	for _, pkg := range pkgs {
+		for _, d := range checkPackage(v, pkg.Fset, pkg.Types) {
+			fmt.Fprintln(stdout, d)
+			diagnostics++
+		}
+	}
+	if diagnostics > 0 {
+		return 1
+	}
+	return 0
+}
+
+// diagnostic a problem found in the tags of a struct type, located in the source code
+type diagnostic struct {
+	position token.Position
+	problem  validator.TagProblem
+}
+
+func (d diagnostic) String() string {
+	msg := d.problem.Struct
+	if d.problem.Field != "" {
+		msg += "." + d.problem.Field
+	}
+	if d.problem.Rule != "" {
+		msg += ": rule `" + d.problem.Rule + "`"
+	}
+	return d.position.String() + ": " + msg + ": " + d.problem.Message
+}
+
+// checkPackage checks the named struct types declared at the top level of the given package, returning the
+// problems found ordered by position
+func checkPackage(v *validator.Validator, fset *token.FileSet, pkg *types.Package) (diagnostics []diagnostic) {
+	tags := checkedTags(v)
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+		named, ok := typeName.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			continue
+		}
+		structType, ok := named.Underlying().(*types.Struct)
+		if !ok || !hasValidatorTags(structType, tags) {
+			continue
+		}
+
+		for _, problem := range v.AnalyzeType(reflectStruct(structType, true)) {
+			problem.Struct = pkg.Name() + "." + name
+			diagnostics = append(diagnostics, diagnostic{position: fset.Position(fieldPos(structType, problem.Field, typeName.Pos())), problem: problem})
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].position, diagnostics[j].position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return
+}
+
+// checkedTags lists the tags marking struct types to check, as named by the options of the given instance, including
+// fallback names such as `validator:"..."` in "validator,validate"
+func checkedTags(v *validator.Validator) (tags []string) {
+	var opts validator.ValidationOptions
+	v.CopyOptions(&opts)
+	for _, option := range []string{opts.ValidatorTagName, opts.FilterTagName, opts.RulesTagName, opts.FlagTagName, opts.TriggerTagName} {
+		for _, name := range strings.Split(option, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				tags = append(tags, name)
+			}
+		}
+	}
+	return
+}
+
+// hasValidatorTags tests whether any field of the given struct type carries one of the given tags
+func hasValidatorTags(structType *types.Struct, tags []string) bool {
+	for i := 0; i < structType.NumFields(); i++ {
+		tag := structType.Tag(i)
+		for _, name := range tags {
+			if strings.Contains(tag, name+":") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fieldPos returns the position of the field with the given name, or the given position if there is none, e.g. for
+// problems reported for the struct as a whole
+func fieldPos(structType *types.Struct, name string, fallback token.Pos) token.Pos {
+	for i := 0; i < structType.NumFields(); i++ {
+		if field := structType.Field(i); field.Name() == name {
+			return field.Pos()
+		}
+	}
+	return fallback
+}
+
+// register registers the custom validators, filters, rule sets, aliases and patterns listed in the given registration
+// file with the given instance. Validators and filters are registered as functions that always pass, as only their names matter.
+func register(v *validator.Validator, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		kind, rest, _ := strings.Cut(text, " ")
+		name, rules, _ := strings.Cut(strings.TrimSpace(rest), " ")
+		if name == "" {
+			return fmt.Errorf("%s:%d: missing name", path, line)
+		}
+
+		switch kind {
+		case "validator":
+			v.AddValidator(name, func(ctx *validator.ValidationContext) bool { return true })
+		case "filter":
+			v.AddFilter(name, func(ctx *validator.ValidationContext) reflect.Value { return ctx.GetValue() })
+		case "ruleset":
+			if err := v.RegisterRuleSet(name, strings.TrimSpace(rules)); err != nil {
+				return fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+		case "alias":
+			if err := v.RegisterAlias(name, strings.TrimSpace(rules)); err != nil {
+				return fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+		case "pattern":
+			if err := v.RegisterPattern(name, strings.TrimSpace(rules)); err != nil {
+				return fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+		default:
+			return fmt.Errorf("%s:%d: unknown kind `%s`, expected validator, filter, ruleset, alias or pattern", path, line, kind)
+		}
+	}
+	return scanner.Err()
+}
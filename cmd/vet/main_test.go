@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"./testdata/example"}, "testdata/functions.txt", &stdout, &stderr)
+	assert.Equal(t, 1, status, stderr.String())
+
+	file := filepath.Join("testdata", "example", "example.go")
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		_, diagnostic, _ := strings.Cut(line, file+":")
+		lines = append(lines, diagnostic)
+	}
+	assert.Equal(t, []string{
+		"6:2: example.Signup.Email: rule `emial`: validator `emial` not found",
+		"7:2: example.Signup.Name: rule `length(1,3`: invalid validator tag: unbalanced parentheses in `length(1,3`",
+		"8:2: example.Signup.Age: rule `min(21)`: duplicate validator `min`",
+		"9:2: example.Signup.Nickname: rule `allowzero`: unknown flag `allowzero`",
+		"10:2: example.Signup.Confirm: rule `eqfield(Pasword)`: validator `eqfield` references unknown field Pasword",
+		"19:2: example.Address.Zip: rule `email`: validator `email` does not support int values",
+		"39:2: example.Coupon.Code: rule `emial`: validator `emial` not found",
+	}, lines)
+
+	// custom functions are unknown without a registration file
+	stdout.Reset()
+	assert.Equal(t, 1, run([]string{"./testdata/example"}, "", &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "example.Signup.Sku: rule `is_sku|$contact`: rule set `$contact` not found")
+	assert.Contains(t, stdout.String(), "example.Address.City: rule `slugify`: filter `slugify` not found")
+}
+
+func TestRunErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, run([]string{"./testdata/example"}, "testdata/missing.txt", &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "missing.txt")
+
+	stderr.Reset()
+	assert.Equal(t, 2, run([]string{"./testdata/example"}, "testdata/invalid.txt", &stdout, &stderr))
+	assert.Equal(t, "vet: testdata/invalid.txt:2: unknown kind `validators`, expected validator, filter, ruleset, alias or pattern\n", stderr.String())
+
+	assert.Equal(t, 2, run([]string{"./testdata/unknown"}, "", &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "unknown")
+}
@@ -0,0 +1,40 @@
+package example
+
+import "time"
+
+type Signup struct {
+	Email    string `validator:"required|emial"`
+	Name     string `validator:"length(1,3" filter:"trim"`
+	Age      int    `validator:"min(18)|min(21)"`
+	Nickname string `validator:"length(1,_)" flags:"allowzero"`
+	Confirm  string `validator:"eqfield(Pasword)"`
+	Sku      string `validator:"is_sku|$contact"`
+	Login    string `validator:"username"`
+	Address  *Address
+	Base
+}
+
+type Address struct {
+	City string `validator:"email" filter:"slugify"`
+	Zip  int    `validator:"email"`
+}
+
+type Base struct {
+	CreatedAt time.Time
+	Password  string
+}
+
+type Valid struct {
+	Password string `validator:"length(8,_)" trigger:"create"`
+	Confirm  string `validator:"eqfield(Password)"`
+	Start    time.Time
+	End      time.Time `validator:"gtfield(Start)"`
+	Base     `validator:"required"`
+	Next     *Valid
+	Tags     []string `validator:"length(1,5)"`
+	Code     string   `validator:"pattern(sku)"`
+}
+
+type Coupon struct {
+	Code string `rules:"trim|emial"`
+}
@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"golang.org/x/exp/slices"
+)
+
+// siblingRepresentation returns the string representation of the given field value used to match conditions and
+// to substitute argument placeholders: strings as is, integers and floats in decimal and booleans as true or false.
+//
+// Nil pointers have no representation, in which case present is false.
+func siblingRepresentation(value reflect.Value) (representation string, present bool, err error) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", false, nil
+		}
+		value = value.Elem()
+	}
+
+	switch {
+	case value.Kind() == reflect.String:
+		return value.String(), true, nil
+	case value.Kind() == reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true, nil
+	case value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64), true, nil
+	case slices.Contains(signedIntegerKinds, value.Kind()):
+		return strconv.FormatInt(value.Int(), 10), true, nil
+	case slices.Contains(unsignedIntegerKinds, value.Kind()):
+		return strconv.FormatUint(value.Uint(), 10), true, nil
+	}
+	return "", false, errors.New("unsupported field type " + value.Type().String())
+}
+
+// conditionalRequirement enforces the presence of the input value if the sibling field named by the first argument
+// matches (or, when negated, does not match) any of the remaining arguments. Otherwise the remaining validators of
+// the field are skipped.
+func conditionalRequirement(ctx *ValidationContext, negated bool) bool {
+	if ctx.ArgCount() < 2 {
+		panic(newValidationError("expected the name of the condition field followed by at least one value"))
+	}
+	name := ctx.Args[0]
+
+	sibling, ok := ctx.Sibling(name)
+	if !ok {
+		ctx.ErrorMessage = fmt.Sprintf(MsgFieldNotFound, name)
+		return false
+	}
+
+	representation, ok, err := siblingRepresentation(sibling)
+	if err != nil {
+		panic(newValidationError("condition field "+name, err))
+	}
+	matches := ok && slices.Contains(ctx.Args[1:], representation)
+	if matches == negated {
+		ctx.SkipRemaining = true
+		return true
+	}
+
+	if ctx.IsNull || ctx.GetValue().IsZero() {
+		ctx.ErrorMessage = MsgRequired
+		ctx.ErrorCode = absenceCode(ctx)
+		if ctx.ErrorCode == CodeEmpty {
+			ctx.ErrorMessage = MsgEmpty
+		}
+		return false
+	}
+	return true
+}
+
+// IsRequiredIf requires the input value to be set (neither a nil pointer nor a zero value) if the given field equals
+// any of the given values, e.g. required_if(Type,business). Otherwise the remaining validators of the field are skipped.
+//
+// The condition field may be a string, integer or boolean, or a pointer to one. A nil pointer matches no value.
+func IsRequiredIf(ctx *ValidationContext) bool {
+	return conditionalRequirement(ctx, false)
+}
+
+// IsRequiredUnless requires the input value to be set (neither a nil pointer nor a zero value) unless the given field
+// equals any of the given values, e.g. required_unless(Type,personal). Otherwise the remaining validators of the
+// field are skipped.
+//
+// The condition field may be a string, integer or boolean, or a pointer to one. A nil pointer matches no value.
+func IsRequiredUnless(ctx *ValidationContext) bool {
+	return conditionalRequirement(ctx, true)
+}
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"testing"
+)
+
+type accountType string
+
+type customerLevel int
+
+func TestRequiredIf(t *testing.T) {
+	type Customer struct {
+		Type   accountType
+		TaxID  string `validator:"required_if(Type,business,government)|length(9,9)"`
+		Level  *customerLevel
+		Mentor *string `validator:"required_if(Level,1,2)"`
+		Minor  bool
+		Parent string `validator:"required_if(Minor,true)"`
+	}
+
+	assertTrue(t, Validate(&Customer{Type: "personal"}).IsValid(), "expected personal customers to skip the tax id")
+
+	res := Validate(&Customer{Type: "business"})
+	assertFalse(t, res.IsValid(), "expected business customers to require a tax id")
+	assertEqual(t, []FieldError{
+		{Field: "TaxID", Message: MsgEmpty, Validator: "required_if", Code: CodeEmpty},
+		{Field: "TaxID", Message: "length (0) must be at least 9", Validator: "length"},
+	}, res.FieldErrors)
+
+	res = Validate(&Customer{Type: "government", TaxID: "1234"})
+	assertEqual(t, []FieldError{{Field: "TaxID", Message: "length (4) must be at least 9", Validator: "length"}}, res.FieldErrors)
+
+	// pointer condition fields: nil matches no value
+	level := customerLevel(2)
+	res = Validate(&Customer{Level: &level, Minor: true})
+	assertEqual(t, []FieldError{
+		{Field: "Mentor", Message: MsgRequired, Validator: "required_if", Code: CodeMissing},
+		{Field: "Parent", Message: MsgEmpty, Validator: "required_if", Code: CodeEmpty},
+	}, res.FieldErrors)
+
+	mentor := "jane"
+	assertTrue(t, Validate(&Customer{Level: &level, Mentor: &mentor}).IsValid(), "expected a mentor to satisfy the requirement")
+	level = 3
+	assertTrue(t, Validate(&Customer{Level: &level}).IsValid(), "expected other levels to skip the mentor")
+}
+
+func TestRequiredUnless(t *testing.T) {
+	type Shipment struct {
+		Method  *accountType
+		Address string `validator:"required_unless(Method,pickup)|length(5,_)"`
+	}
+
+	res := Validate(&Shipment{})
+	assertEqual(t, []FieldError{
+		{Field: "Address", Message: MsgEmpty, Validator: "required_unless", Code: CodeEmpty},
+		{Field: "Address", Message: "length (0) must be at least 5", Validator: "length"},
+	}, res.FieldErrors)
+
+	pickup := accountType("pickup")
+	assertTrue(t, Validate(&Shipment{Method: &pickup}).IsValid(), "expected pickups to skip the address")
+
+	delivery := accountType("delivery")
+	res = Validate(&Shipment{Method: &delivery, Address: "123"})
+	assertEqual(t, []FieldError{{Field: "Address", Message: "length (3) must be at least 5", Validator: "length"}}, res.FieldErrors)
+}
+
+func TestRequiredIfUnknownField(t *testing.T) {
+	type Form struct {
+		Kind  string
+		TaxID string `validator:"required_if(Knd,business)"`
+	}
+
+	res := Validate(&Form{})
+	assertEqual(t, []FieldError{{Field: "TaxID", Message: "field Knd not found", Validator: "required_if"}}, res.FieldErrors)
+
+	problems := CheckStruct(&Form{})
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "validator `required_if` references unknown field Knd", problems[0].Message)
+
+	type Invalid struct {
+		TaxID string `validator:"required_if(Kind)"`
+	}
+	assertEqual(t, 1, len(CheckStruct(&Invalid{})))
+}
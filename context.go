@@ -1,8 +1,11 @@
 package validator
 
 import (
+	"context"
 	"reflect"
 	"strconv"
+
+	"golang.org/x/exp/slices"
 )
 
 type ValidationContext struct {
@@ -12,6 +15,24 @@ type ValidationContext struct {
 	// The resolved kind of the input value
 	valueKind reflect.Kind
 
+	// The kind of the elements of array, slice and map values
+	elemKind reflect.Kind
+
+	// The struct declaring the input value
+	parent reflect.Value
+
+	// The struct passed to the validation call
+	root reflect.Value
+
+	// The path of the input value relative to the root struct, see FieldPath
+	fieldPath string
+
+	// The context of the validation call, see Validator.ValidateContext
+	goContext context.Context
+
+	// The instance performing the validation, e.g. to look up patterns, see IsPattern
+	engine *engine
+
 	// The resolved type of the input value
 	ValueType reflect.Type
 
@@ -27,20 +48,140 @@ type ValidationContext struct {
 	// Arguments passed to the validation or filter function
 	Args []string
 
-	// Containst the validation error message
+	// If the value must not appear in error messages, see the sensitive flag. Validators interpolating the value into
+	// error messages must mask it, see Redact
+	Redacted bool
+
+	// Containst the validation error message. Filters set it to report a failure, see FilterFunction
 	ErrorMessage string
 
-	// An error that may have occurred during validation
+	// Set by validators to identify the kind of failure, reported as FieldError.Code, e.g. CodeMissing
+	ErrorCode string
+
+	// An error that may have occurred during validation. Filters may set it to report a failure, see FilterFunction
 	AdditionalError error
+
+	// Set by validators to skip the remaining validators of the field, e.g. when a conditional requirement
+	// does not apply. Filters still run.
+	SkipRemaining bool
 }
 
-// GetValue GetValue Returns the underlying value, resolving pointers if necessary
+// GetValue GetValue Returns the underlying value, resolving pointers and interfaces if necessary
 func (vc ValidationContext) GetValue() reflect.Value {
+	value := vc.value
+	if value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
 	if vc.IsPointer {
-		return vc.value.Elem()
+		return value.Elem()
 	} else {
-		return vc.value
+		return value
+	}
+}
+
+// isCurrentValue reports whether the given value is the value returned by GetValue, e.g. a value returned by a filter
+// after storing it with SetReflectValue
+func (vc ValidationContext) isCurrentValue(value reflect.Value) bool {
+	current := vc.GetValue()
+	return current.IsValid() && current.CanAddr() && value.CanAddr() && value.Type() == current.Type() &&
+		value.Addr().Pointer() == current.Addr().Pointer()
+}
+
+// ElementKind ElementKind returns the kind of the elements of array, slice and map values, e.g. reflect.Uint8
+// for [16]byte, or reflect.Invalid for other values.
+func (vc ValidationContext) ElementKind() reflect.Kind {
+	return vc.elemKind
+}
+
+// Sibling Sibling returns the value of the field with the given name declared in the same struct as the input value,
+// allowing validators to compare fields. Pointers are not resolved.
+//
+// The boolean result is false if the struct has no such field.
+func (vc ValidationContext) Sibling(name string) (reflect.Value, bool) {
+	if !vc.parent.IsValid() {
+		return reflect.Value{}, false
 	}
+	field := vc.parent.FieldByName(name)
+	return field, field.IsValid()
+}
+
+// SiblingValues SiblingValues returns the elements of the slice or array field with the given name declared in the
+// same struct as the input value, formatted as strings, e.g. the options a selection must be among. Pointers are
+// resolved and a nil field has no elements. Elements must be strings or integers, other fields cause a panic.
+//
+// The boolean result is false if the struct has no such field.
+func (vc ValidationContext) SiblingValues(name string) ([]string, bool) {
+	field, ok := vc.Sibling(name)
+	if !ok {
+		return nil, false
+	}
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return nil, true
+		}
+		field = field.Elem()
+	}
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		panic(newValidationError("field " + name + " is not a slice but " + field.Type().String()))
+	}
+
+	values := make([]string, field.Len())
+	for i := range values {
+		element := field.Index(i)
+		switch {
+		case element.Kind() == reflect.String:
+			values[i] = element.String()
+		case slices.Contains(signedIntegerKinds, element.Kind()):
+			values[i] = strconv.FormatInt(element.Int(), 10)
+		case slices.Contains(unsignedIntegerKinds, element.Kind()):
+			values[i] = strconv.FormatUint(element.Uint(), 10)
+		default:
+			panic(newValidationError("field " + name + " holds unsupported elements of type " + element.Type().String()))
+		}
+	}
+	return values, true
+}
+
+// FieldPath FieldPath returns the path of the input value relative to the struct passed to the validation call,
+// made of field names separated by dots and of slice, array and map indexes, e.g. Items[2].Quantity. Unlike
+// FieldError.Field, it does not use labels.
+func (vc ValidationContext) FieldPath() string {
+	return vc.fieldPath
+}
+
+// ParentValue ParentValue returns the struct declaring the input value, e.g. the element of Items holding Quantity.
+//
+// The value is live: it is the struct being validated, not a copy. Modifying it changes the validated struct, which
+// validators must not do, and whose fields may still be filtered after the validator returns.
+func (vc ValidationContext) ParentValue() reflect.Value {
+	return vc.parent
+}
+
+// RootValue RootValue returns the struct passed to the validation call, pointers resolved, allowing validators of
+// nested fields to compare their value against top level fields.
+//
+// Like ParentValue, the value is live and must not be modified. Fields validated later may not have been filtered
+// yet.
+func (vc ValidationContext) RootValue() reflect.Value {
+	return vc.root
+}
+
+// Context Context returns the context passed to ValidateContext, or context.Background() for other validation calls.
+// Validators performing I/O must honor its cancellation.
+func (vc ValidationContext) Context() context.Context {
+	if vc.goContext == nil {
+		return context.Background()
+	}
+	return vc.goContext
+}
+
+// Redact Redact returns RedactedValue in place of the given value, such as the input value or a value derived from
+// it, if the field is sensitive, and the value itself otherwise.
+func (vc ValidationContext) Redact(value interface{}) interface{} {
+	if vc.Redacted {
+		return RedactedValue
+	}
+	return value
 }
 
 func (vc ValidationContext) ArgCount() int {
@@ -94,3 +235,97 @@ func (vc *ValidationContext) MustGetUintArg(position int) uint64 {
 func (vc *ValidationContext) IsValueOfType(i interface{}) bool {
 	return vc.ValueType.AssignableTo(reflect.TypeOf(i))
 }
+
+// IsValueConvertibleTo IsValueConvertibleTo tests if the input value can be converted to the type of the given value,
+// e.g. a `type Date time.Time` value to time.Time or a `type Email string` value to string.
+//
+// Unlike reflect, integers are not considered convertible to strings, since such conversions yield runes rather than
+// the decimal representation.
+func (vc *ValidationContext) IsValueConvertibleTo(i interface{}) bool {
+	target := reflect.TypeOf(i)
+	if target.Kind() == reflect.String && (vc.IsValueOfKind(signedIntegerKinds...) || vc.IsValueOfKind(unsignedIntegerKinds...)) {
+		return false
+	}
+	return vc.ValueType.ConvertibleTo(target)
+}
+
+// ConvertValueTo ConvertValueTo converts the input value, resolving pointers, to the type of the given value.
+//
+// The boolean result is false if the value is not convertible (see IsValueConvertibleTo) or is a nil pointer.
+func (vc *ValidationContext) ConvertValueTo(i interface{}) (reflect.Value, bool) {
+	if vc.IsNull || !vc.IsValueConvertibleTo(i) {
+		return reflect.Value{}, false
+	}
+	return vc.GetValue().Convert(reflect.TypeOf(i)), true
+}
+
+// SetValue SetValue stores the given value into the input value, e.g. a string into a string or *string field, see
+// SetReflectValue.
+func (vc *ValidationContext) SetValue(value interface{}) {
+	vc.SetReflectValue(reflect.ValueOf(value))
+}
+
+// SetReflectValue SetReflectValue stores the given value into the input value, letting filters modify fields without
+// handling pointers themselves:
+//
+//	AddFilter("lower", func(ctx *ValidationContext) reflect.Value {
+//		if !ctx.IsNull {
+//			ctx.SetValue(strings.ToLower(ctx.GetValue().String()))
+//		}
+//		return reflect.Value{}
+//	})
+//
+// Values of the type pointed to are stored into a newly allocated pointer, as the value pointed to may be shared by
+// the caller and restored values must stay intact, see ValidationOptions.SkipFiltersOnError. Values of the same kind
+// are converted, e.g. a string into a `type Email string` field, and an invalid value sets pointers to nil. Other
+// values cause a panic.
+//
+// Filters calling it may return the invalid reflect.Value{} or ctx.GetValue(), keeping the value set, see
+// FilterFunction.
+func (vc *ValidationContext) SetReflectValue(value reflect.Value) {
+	target := vc.value
+	if !target.CanSet() {
+		panic(newValidationError("cannot set the value of " + vc.fieldPath))
+	}
+
+	// interface fields keep the type of their dynamic value
+	targetType := target.Type()
+	if target.Kind() == reflect.Interface && !target.IsNil() {
+		targetType = target.Elem().Type()
+	}
+
+	mismatch := func(t reflect.Type) *ValidationError {
+		found := "nil"
+		if value.IsValid() {
+			found = value.Type().String()
+		}
+		return newValidationError("cannot set a " + found + " value into the " + t.String() + " value of " + vc.fieldPath)
+	}
+	convert := func(t reflect.Type) reflect.Value {
+		if value.Type().AssignableTo(t) {
+			return value
+		}
+		if value.Kind() == t.Kind() && value.Type().ConvertibleTo(t) {
+			return value.Convert(t)
+		}
+		panic(mismatch(targetType))
+	}
+
+	switch {
+	case !value.IsValid():
+		if targetType.Kind() != reflect.Ptr && targetType.Kind() != reflect.Interface {
+			panic(mismatch(targetType))
+		}
+		target.Set(reflect.Zero(target.Type()))
+	case value.Type().AssignableTo(targetType) || targetType.Kind() != reflect.Ptr:
+		target.Set(convert(targetType))
+	default:
+		pointer := reflect.New(targetType.Elem())
+		pointer.Elem().Set(convert(targetType.Elem()))
+		target.Set(pointer)
+	}
+
+	if vc.IsPointer || target.Kind() == reflect.Interface {
+		vc.IsNull = target.IsNil() || (target.Kind() == reflect.Interface && target.Elem().Kind() == reflect.Ptr && target.Elem().IsNil())
+	}
+}
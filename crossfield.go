@@ -0,0 +1,182 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// fieldComparisonMessages holds the message formats reported for each comparator, taking the name of the referenced field
+var fieldComparisonMessages = map[Comparator]string{
+	EQUALS:                MsgEqualToField,
+	NOT_EQUAL:             MsgNotEqualToField,
+	GREATER_THAN:          MsgGreaterThanField,
+	GREATER_THAN_OR_EQUAL: MsgGreaterThanOrEqualToField,
+	LESS_THAN:             MsgLessThanField,
+	LESS_THAN_OR_EQUAL:    MsgLessThanOrEqualToField,
+}
+
+// fieldComparisonValidators lists the packaged validators comparing the input value with another field
+var fieldComparisonValidators = []string{"eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield"}
+
+// comparisonFamily returns the family of values of the given type compared by field comparisons: time for time.Time,
+// string, float, int or uint, or an empty string for types that cannot be compared
+func comparisonFamily(t reflect.Type) string {
+	switch kind := t.Kind(); {
+	case t == timeType:
+		return "time"
+	case kind == reflect.String:
+		return "string"
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return "float"
+	case slices.Contains(signedIntegerKinds, kind):
+		return "int"
+	case slices.Contains(unsignedIntegerKinds, kind):
+		return "uint"
+	}
+	return ""
+}
+
+// comparableTypes tests whether field comparisons can compare values of the given types, pointers being resolved.
+// Interface types are only known at runtime and are assumed comparable.
+func comparableTypes(a reflect.Type, b reflect.Type) bool {
+	if a.Kind() == reflect.Ptr {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Ptr {
+		b = b.Elem()
+	}
+	if a.Kind() == reflect.Interface || b.Kind() == reflect.Interface {
+		return true
+	}
+	family := comparisonFamily(a)
+	return family != "" && family == comparisonFamily(b)
+}
+
+// compareValues compares two non pointer values of the same family, see comparisonFamily, returning -1, 0 or 1. The
+// boolean result is false if the values are unsupported or of different families.
+func compareValues(a reflect.Value, b reflect.Value) (int, bool) {
+	family := comparisonFamily(a.Type())
+	if family == "" || family != comparisonFamily(b.Type()) {
+		return 0, false
+	}
+
+	switch family {
+	case "time":
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time)), true
+	case "string":
+		return compareOrdered(a.String(), b.String()), true
+	case "float":
+		return compareOrdered(a.Float(), b.Float()), true
+	case "int":
+		return compareOrdered(a.Int(), b.Int()), true
+	default:
+		return compareOrdered(a.Uint(), b.Uint()), true
+	}
+}
+
+// compareOrdered compares two ordered values, returning -1, 0 or 1
+func compareOrdered[T string | float64 | int64 | uint64](a T, b T) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// fieldComparison tests the input value against the sibling field named by the first argument using the given comparator.
+//
+// Nil input values pass. A nil sibling only equals a nil input value and is otherwise treated as an absent bound:
+// ordering comparisons pass. Values that cannot be compared with the sibling, such as a string with an integer, fail
+// with MsgFieldNotComparable.
+func fieldComparison(ctx *ValidationContext, comparator Comparator) bool {
+	if ctx.ArgCount() != 1 {
+		panic(newValidationError("expected the name of the field to compare with"))
+	}
+	name := ctx.Args[0]
+
+	sibling, ok := ctx.Sibling(name)
+	if !ok {
+		ctx.ErrorMessage = fmt.Sprintf(MsgFieldNotFound, name)
+		return false
+	}
+
+	if ctx.IsNull {
+		return true
+	}
+
+	if sibling.Kind() == reflect.Ptr {
+		if sibling.IsNil() {
+			switch comparator {
+			case EQUALS:
+				ctx.ErrorMessage = fmt.Sprintf(fieldComparisonMessages[comparator], name)
+				return false
+			default:
+				return true
+			}
+		}
+		sibling = sibling.Elem()
+	}
+
+	cmp, ok := compareValues(ctx.GetValue(), sibling)
+	if !ok {
+		ctx.ErrorMessage = fmt.Sprintf(MsgFieldNotComparable, name)
+		return false
+	}
+
+	var match bool
+	switch comparator {
+	case EQUALS:
+		match = cmp == 0
+	case NOT_EQUAL:
+		match = cmp != 0
+	case GREATER_THAN:
+		match = cmp > 0
+	case GREATER_THAN_OR_EQUAL:
+		match = cmp >= 0
+	case LESS_THAN:
+		match = cmp < 0
+	case LESS_THAN_OR_EQUAL:
+		match = cmp <= 0
+	}
+
+	if !match {
+		ctx.ErrorMessage = fmt.Sprintf(fieldComparisonMessages[comparator], name)
+	}
+	return match
+}
+
+// IsEqualToField tests if the input value equals the value of the given field, e.g. eqfield(Password)
+func IsEqualToField(ctx *ValidationContext) bool {
+	return fieldComparison(ctx, EQUALS)
+}
+
+// IsNotEqualToField tests if the input value differs from the value of the given field, e.g. nefield(OldPassword)
+func IsNotEqualToField(ctx *ValidationContext) bool {
+	return fieldComparison(ctx, NOT_EQUAL)
+}
+
+// IsGreaterThanField tests if the input value is greater than the value of the given field, e.g. gtfield(StartDate)
+func IsGreaterThanField(ctx *ValidationContext) bool {
+	return fieldComparison(ctx, GREATER_THAN)
+}
+
+// IsGreaterThanOrEqualToField tests if the input value is greater than or equal to the value of the given field,
+// e.g. gtefield(MinPrice)
+func IsGreaterThanOrEqualToField(ctx *ValidationContext) bool {
+	return fieldComparison(ctx, GREATER_THAN_OR_EQUAL)
+}
+
+// IsLessThanField tests if the input value is less than the value of the given field, e.g. ltfield(EndDate)
+func IsLessThanField(ctx *ValidationContext) bool {
+	return fieldComparison(ctx, LESS_THAN)
+}
+
+// IsLessThanOrEqualToField tests if the input value is less than or equal to the value of the given field,
+// e.g. ltefield(MaxPrice)
+func IsLessThanOrEqualToField(ctx *ValidationContext) bool {
+	return fieldComparison(ctx, LESS_THAN_OR_EQUAL)
+}
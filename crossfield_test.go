@@ -0,0 +1,172 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordConfirmation(t *testing.T) {
+	type SignupForm struct {
+		OldPassword     *string
+		Password        string `validator:"nefield(OldPassword)"`
+		ConfirmPassword string `validator:"eqfield(Password)"`
+	}
+
+	form := SignupForm{Password: "s3cret", ConfirmPassword: "s3cret"}
+	assertTrue(t, Validate(&form).IsValid(), "expected matching passwords to pass")
+
+	old := "s3cret"
+	form = SignupForm{OldPassword: &old, Password: "s3cret", ConfirmPassword: "secret"}
+	res := Validate(&form)
+	assertFalse(t, res.IsValid(), "expected validation to fail")
+	assertEqual(t, []FieldError{
+		{Field: "Password", Message: "must not be equal to OldPassword", Validator: "nefield"},
+		{Field: "ConfirmPassword", Message: "must be equal to Password", Validator: "eqfield"},
+	}, res.FieldErrors)
+}
+
+func TestTimeRange(t *testing.T) {
+	type Booking struct {
+		StartDate *time.Time
+		EndDate   *time.Time `validator:"gtfield(StartDate)"`
+		Nights    uint       `validator:"gtefield(MinNights)|ltefield(MaxNights)"`
+		MinNights uint
+		MaxNights uint
+	}
+
+	start := time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 2)
+
+	booking := Booking{StartDate: &start, EndDate: &end, Nights: 2, MinNights: 1, MaxNights: 2}
+	assertTrue(t, Validate(&booking).IsValid(), "expected a valid range to pass")
+
+	booking = Booking{StartDate: &end, EndDate: &start, Nights: 3, MinNights: 1, MaxNights: 2}
+	res := Validate(&booking)
+	assertEqual(t, []FieldError{
+		{Field: "EndDate", Message: "must be greater than StartDate", Validator: "gtfield"},
+		{Field: "Nights", Message: "must be less than or equal to MaxNights", Validator: "ltefield"},
+	}, res.FieldErrors)
+
+	// nil values are treated as absent
+	assertTrue(t, Validate(&Booking{EndDate: &end}).IsValid(), "expected a nil start date to pass")
+	assertTrue(t, Validate(&Booking{StartDate: &start}).IsValid(), "expected a nil end date to pass")
+}
+
+func TestNestedFieldComparison(t *testing.T) {
+	type Range struct {
+		Min float64
+		Max float64 `validator:"gtfield(Min)"`
+	}
+	type Product struct {
+		Price Range
+		Sizes []Range
+	}
+
+	res := Validate(&Product{Price: Range{Min: 2.5, Max: 1}, Sizes: []Range{{Min: 1, Max: 2}, {Min: 3, Max: 3}}})
+	assertEqual(t, []FieldError{
+		{Field: "Price.Max", Message: "must be greater than Min", Validator: "gtfield"},
+		{Field: "Sizes[1].Max", Message: "must be greater than Min", Validator: "gtfield"},
+	}, res.FieldErrors)
+}
+
+func TestFieldComparisonErrors(t *testing.T) {
+	type Form struct {
+		Password        string
+		ConfirmPassword string `validator:"eqfield(Pasword)"`
+	}
+
+	res := Validate(&Form{})
+	assertEqual(t, []FieldError{{Field: "ConfirmPassword", Message: "field Pasword not found", Validator: "eqfield"}}, res.FieldErrors)
+
+	problems := CheckStruct(Form{})
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "validator `eqfield` references unknown field Pasword", problems[0].Message)
+
+	type Mismatch struct {
+		Count int
+		Name  string `validator:"eqfield(Count)"`
+	}
+	res = Validate(&Mismatch{})
+	assertEqual(t, []FieldError{{Field: "Name", Message: "cannot be compared with Count", Validator: "eqfield"}}, res.FieldErrors)
+	assertNull(t, res.Error)
+	problems = CheckStruct(Mismatch{})
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "validator `eqfield` cannot compare string values with field Count of type int", problems[0].Message)
+	assertEqual(t, problems, New().AnalyzeType(reflect.TypeOf(Mismatch{})))
+
+	// pointers are resolved and interfaces only known at runtime
+	type Compatible struct {
+		Min   *int64
+		Max   int `validator:"gtfield(Min)"`
+		Start time.Time
+		End   *time.Time `validator:"gtfield(Start)"`
+		Any   any
+		Value int `validator:"eqfield(Any)"`
+	}
+	assertEqual(t, 0, len(CheckStruct(Compatible{})))
+}
+
+func TestEnumField(t *testing.T) {
+	type Poll struct {
+		Options   []string
+		Selection string `validator:"enum_field(Options)"`
+		Choice    *int   `validator:"enum_field(Numbers)"`
+		Numbers   *[]uint8
+	}
+	one, two := 1, 2
+	numbers := []uint8{1, 3}
+
+	v := New()
+	assertTrue(t, v.Validate(&Poll{Options: []string{"a", "b"}, Selection: "b", Choice: &one, Numbers: &numbers}).IsValid(), "expected selections among the options to pass")
+	assertTrue(t, v.Validate(&Poll{}).IsValid(), "expected empty selections without options to pass")
+
+	res := v.Validate(&Poll{Options: []string{"a", "b"}, Selection: "c", Choice: &two, Numbers: &numbers})
+	assertEqual(t, []FieldError{
+		{Field: "Selection", Message: MsgEnumField, Validator: "enum_field"},
+		{Field: "Choice", Message: MsgEnumField, Validator: "enum_field"},
+	}, res.FieldErrors)
+
+	// nil and empty options
+	res = v.Validate(&Poll{Options: []string{}, Selection: "a", Choice: &one})
+	assertEqual(t, []FieldError{
+		{Field: "Selection", Message: "no options provided in Options", Validator: "enum_field"},
+		{Field: "Choice", Message: "no options provided in Numbers", Validator: "enum_field"},
+	}, res.FieldErrors)
+
+	// the options are listed with ExposeEnumValues
+	exposing := New(func(opts *ValidationOptions) {
+		opts.ExposeEnumValues = true
+	})
+	res = exposing.Validate(&Poll{Options: []string{"a", "b"}, Selection: "c"})
+	assertEqual(t, "value not among provided options. expected any of a,b", res.FieldErrors[0].Message)
+
+	// integer values against string options
+	type Quantity struct {
+		Allowed []string
+		Count   int `validator:"enum_field(Allowed)"`
+	}
+	assertTrue(t, v.Validate(&Quantity{Allowed: []string{"6", "12"}, Count: 12}).IsValid(), "expected 12 among the options")
+	assertFalse(t, v.Validate(&Quantity{Allowed: []string{"6", "12"}, Count: 7}).IsValid(), "expected 7 not among the options")
+
+	type Invalid struct {
+		Options   string
+		Prices    []float64
+		Dynamic   any
+		Selection string `validator:"enum_field(Options)"`
+		Missing   string `validator:"enum_field(Choices)"`
+		Price     string `validator:"enum_field(Prices)"`
+		Other     string `validator:"enum_field(Dynamic)"`
+	}
+	problems := v.CheckStruct(&Invalid{})
+	assertEqual(t, 3, len(problems))
+	assertEqual(t, "validator `enum_field` references field Options, which is not a slice or array of strings or integers", problems[0].Message)
+	assertEqual(t, "validator `enum_field` references unknown field Choices", problems[1].Message)
+	assertEqual(t, "validator `enum_field` references field Prices, which is not a slice or array of strings or integers", problems[2].Message)
+	assertEqual(t, problems, v.AnalyzeType(reflect.TypeOf(Invalid{})))
+	assert.PanicsWithError(t, "field Options is not a slice but string", func() {
+		v.Validate(&Invalid{Options: "a", Selection: "a"})
+	})
+}
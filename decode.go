@@ -0,0 +1,451 @@
+package validator
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// ValidateInto ValidateInto decodes the given loosely typed map, such as a decoded JSON payload, into the struct dst
+// points to and validates it using this instance, e.g.
+//
+//	var req CreateUserRequest
+//	res := v.ValidateInto(payload, &req, "create")
+//
+// Keys are matched with the names of the json tags of the fields, or with their names if they have none, ignoring
+// case if ValidationOptions.DecodeCaseInsensitiveKeys is set. Fields tagged with `json:"-"` and unexported fields are
+// not decoded. Fields of embedded structs are promoted, as with encoding/json: fields of outer structs shadow those
+// of embedded ones, and names shared by several fields at the same depth are ignored, unless only one of them has a
+// json tag. Keys without a field are ignored, unless ValidationOptions.RejectUnknownKeys is set.
+//
+// Values are converted to the types of the fields: numbers, numeric strings and json.Number values to integers and
+// floats (whole numbers only for integers, within their range), booleans and "true" or "false" to booleans, strings
+// to strings and to types implementing encoding.TextUnmarshaler such as time.Time, arrays to slices and arrays,
+// and maps to structs and maps with string keys, recursively. Nil values leave fields at their zero value and
+// pointers are allocated as needed.
+//
+// Values that cannot be converted are reported as field errors with the code CodeInvalidType, unknown keys with the
+// code CodeUnknownField, in declaration order followed by unknown keys in key order. The struct is then filtered and
+// validated as by Validate, validation errors of fields that could not be decoded being left out. The fields of dst
+// that are not present in the map are left as is.
+func (v *Validator) ValidateInto(data map[string]interface{}, dst interface{}, trigger ...string) *ValidationResult {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		res := &ValidationResult{Error: newValidationError("cannot decode into " + fmt.Sprintf("%T", dst) + ": expected struct pointer")}
+		res.updateValidity()
+		return res
+	}
+
+	opts := v.currentOptions()
+	if err := opts.Check(); err != nil {
+		return &ValidationResult{Error: err.(*ValidationError)}
+	}
+	d := decoder{opts: &opts}
+	d.decodeStruct(data, target.Elem(), "")
+
+	res := v.validate(context.Background(), dst, &opts, trigger, nil)
+	fieldErrors := d.errors
+	for _, fe := range res.FieldErrors {
+		if !d.failed(fe.Field) {
+			fieldErrors = append(fieldErrors, fe)
+		}
+	}
+	res.FieldErrors = fieldErrors
+	res.updateValidity()
+	return res
+}
+
+// ValidateInto ValidateInto decodes the given loosely typed map into the struct dst points to and validates it using
+// the default instance.
+//
+// See Validator.ValidateInto for details.
+func ValidateInto(data map[string]interface{}, dst interface{}, trigger ...string) *ValidationResult {
+	return defaultValidator.ValidateInto(data, dst, trigger...)
+}
+
+// decoder decodes loosely typed maps into structs, see Validator.ValidateInto
+type decoder struct {
+	opts   *ValidationOptions
+	errors []FieldError
+	// failedPaths holds the paths of the values that could not be decoded, see failed
+	failedPaths []string
+}
+
+// decodedField is a field of a struct decoded from a map, possibly promoted from an embedded struct
+type decodedField struct {
+	key   string
+	index []int
+	name  string
+	label string
+	// tagged is set when the key is the name of a json tag, see dominantFields
+	tagged bool
+}
+
+// failed tests whether the value at the given path, or a value containing it, could not be decoded
+func (d *decoder) failed(path string) bool {
+	for _, failed := range d.failedPaths {
+		if path == failed || strings.HasPrefix(path, failed+".") || strings.HasPrefix(path, failed+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// fail reports a value that could not be decoded
+func (d *decoder) fail(path string, label string, message string, code string, cause error) {
+	d.errors = append(d.errors, FieldError{Field: label, Message: message, Code: code, Cause: cause})
+	d.failedPaths = append(d.failedPaths, path, label)
+}
+
+// decodeStruct decodes the given map into the given struct value found at the given path
+func (d *decoder) decodeStruct(data map[string]interface{}, target reflect.Value, path string) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	used := make(map[string]bool, len(data))
+	for _, field := range d.structFields(target.Type()) {
+		key, ok := field.key, false
+		if _, ok = data[key]; !ok && d.opts.DecodeCaseInsensitiveKeys {
+			for _, candidate := range keys {
+				if !used[candidate] && strings.EqualFold(candidate, field.key) {
+					key, ok = candidate, true
+					break
+				}
+			}
+		}
+		if !ok || used[key] {
+			continue
+		}
+		used[key] = true
+		d.decodeValue(data[key], fieldByIndexAlloc(target, field.index), path+field.name, path+field.label)
+	}
+
+	if d.opts.RejectUnknownKeys {
+		for _, key := range keys {
+			if !used[key] {
+				field := path + key
+				d.errors = append(d.errors, FieldError{Field: field, Message: MsgUnknownField, Code: CodeUnknownField})
+			}
+		}
+	}
+}
+
+// structFields returns the decoded fields of the given struct type in declaration order, promoting the fields of
+// embedded structs without json name as encoding/json does, see dominantFields
+func (d *decoder) structFields(t reflect.Type) []decodedField {
+	return dominantFields(d.embeddedFields(t, nil))
+}
+
+// dominantFields applies the rules of encoding/json to fields sharing the same key: the shallowest fields win, and
+// among them the only one with a json tag, if any. Keys that remain ambiguous are dropped.
+func dominantFields(fields []decodedField) []decodedField {
+	candidates := make(map[string][]decodedField, len(fields))
+	for _, field := range fields {
+		candidates[field.key] = append(candidates[field.key], field)
+	}
+
+	dominant := fields[:0:0]
+	for _, field := range fields {
+		var shallowest []decodedField
+		for _, candidate := range candidates[field.key] {
+			switch {
+			case len(shallowest) == 0 || len(candidate.index) < len(shallowest[0].index):
+				shallowest = []decodedField{candidate}
+			case len(candidate.index) == len(shallowest[0].index):
+				shallowest = append(shallowest, candidate)
+			}
+		}
+		if len(shallowest) > 1 {
+			var tagged []decodedField
+			for _, candidate := range shallowest {
+				if candidate.tagged {
+					tagged = append(tagged, candidate)
+				}
+			}
+			shallowest = tagged
+		}
+		if len(shallowest) == 1 && slices.Equal(shallowest[0].index, field.index) {
+			dominant = append(dominant, field)
+		}
+	}
+	return dominant
+}
+
+// embeddedFields returns the decoded fields of the given struct type and of the structs it embeds, recursively, in
+// declaration order
+func (d *decoder) embeddedFields(t reflect.Type, index []int) (fields []decodedField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		fieldIndex := append(append([]int{}, index...), i)
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			// like encoding/json, unexported structs embedded by pointer cannot be allocated
+			if !field.IsExported() && field.Type.Kind() == reflect.Ptr {
+				continue
+			}
+			fields = append(fields, d.embeddedFields(fieldType, fieldIndex)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		tagged := name != ""
+		if !tagged {
+			name = field.Name
+		}
+		label := field.Name
+		if value, ok, _ := lookupTag(field.Tag, d.opts.LabelTagName, d.opts.CaseInsensitiveTagNames); ok {
+			label = value
+		}
+		fields = append(fields, decodedField{key: name, index: fieldIndex, name: field.Name, label: label, tagged: tagged})
+	}
+	return fields
+}
+
+// fieldByIndexAlloc returns the field of the given struct value at the given index sequence, allocating nil pointers
+// to embedded structs along the way
+func fieldByIndexAlloc(value reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(x)
+	}
+	return value
+}
+
+// decodeValue converts the given value to the type of the given target and stores it. path is the path of the
+// target used by nested values, label the path reported in field errors, see fieldContext.fieldPath.
+func (d *decoder) decodeValue(value interface{}, target reflect.Value, path string, label string) {
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return
+	}
+
+	if target.Kind() == reflect.Ptr {
+		elem := reflect.New(target.Type().Elem())
+		errs := len(d.errors)
+		d.decodeValue(value, elem.Elem(), path, label)
+		if len(d.errors) == errs {
+			target.Set(elem)
+		}
+		return
+	}
+
+	invalidType := func() {
+		d.fail(path, label, fmt.Sprintf(MsgInvalidType, decodedTypeName(target.Type()), decodedValueName(value)), CodeInvalidType, nil)
+	}
+	invalidValue := func(err error) {
+		d.fail(path, label, fmt.Sprintf(MsgInvalidValue, decodedTypeName(target.Type())), CodeInvalidType, err)
+	}
+
+	if s, ok := value.(string); ok {
+		if unmarshaler, ok := target.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := unmarshaler.UnmarshalText([]byte(s)); err != nil {
+				invalidValue(err)
+			}
+			return
+		}
+	}
+
+	switch kind := target.Kind(); {
+	case kind == reflect.Interface:
+		v := reflect.ValueOf(value)
+		if !v.Type().AssignableTo(target.Type()) {
+			invalidType()
+			return
+		}
+		target.Set(v)
+	case kind == reflect.String:
+		switch s := value.(type) {
+		case string:
+			target.SetString(s)
+		case json.Number:
+			target.SetString(s.String())
+		default:
+			invalidType()
+		}
+	case kind == reflect.Bool:
+		switch b := value.(type) {
+		case bool:
+			target.SetBool(b)
+		case string:
+			parsed, err := strconv.ParseBool(b)
+			if err != nil || (b != "true" && b != "false") {
+				invalidValue(err)
+				return
+			}
+			target.SetBool(parsed)
+		default:
+			invalidType()
+		}
+	case slices.Contains(signedIntegerKinds, kind), slices.Contains(unsignedIntegerKinds, kind), kind == reflect.Float32, kind == reflect.Float64:
+		number, ok := decodedNumber(value)
+		if !ok {
+			invalidType()
+			return
+		}
+		if err := setNumber(target, number); err != nil {
+			invalidValue(err)
+		}
+	case kind == reflect.Slice || kind == reflect.Array:
+		elements := reflect.ValueOf(value)
+		if elements.Kind() != reflect.Slice && elements.Kind() != reflect.Array {
+			invalidType()
+			return
+		}
+		if kind == reflect.Array && elements.Len() != target.Len() {
+			invalidValue(fmt.Errorf("expected %d elements but found %d", target.Len(), elements.Len()))
+			return
+		}
+		if kind == reflect.Slice {
+			target.Set(reflect.MakeSlice(target.Type(), elements.Len(), elements.Len()))
+		}
+		for i := 0; i < elements.Len(); i++ {
+			index := "[" + strconv.Itoa(i) + "]"
+			d.decodeValue(elements.Index(i).Interface(), target.Index(i), path+index, label+index)
+		}
+	case kind == reflect.Map:
+		entries := reflect.ValueOf(value)
+		if entries.Kind() != reflect.Map || entries.Type().Key().Kind() != reflect.String || target.Type().Key().Kind() != reflect.String {
+			invalidType()
+			return
+		}
+		target.Set(reflect.MakeMapWithSize(target.Type(), entries.Len()))
+		iter := entries.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			element := reflect.New(target.Type().Elem()).Elem()
+			index := "[" + key + "]"
+			d.decodeValue(iter.Value().Interface(), element, path+index, label+index)
+			target.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), element)
+		}
+	case kind == reflect.Struct:
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			invalidType()
+			return
+		}
+		d.decodeStruct(data, target, path+".")
+	default:
+		invalidType()
+	}
+}
+
+// decodedNumber converts numbers, json.Number values and numeric strings into a json.Number
+func decodedNumber(value interface{}) (json.Number, bool) {
+	switch n := value.(type) {
+	case json.Number:
+		return n, true
+	case string:
+		if _, err := strconv.ParseFloat(n, 64); err != nil {
+			return "", false
+		}
+		return json.Number(n), true
+	case float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return json.Number(fmt.Sprint(n)), true
+	}
+	return "", false
+}
+
+// setNumber stores the given number into the given integer or float value, rejecting fractions for integers and
+// numbers out of range
+func setNumber(target reflect.Value, number json.Number) error {
+	switch kind := target.Kind(); {
+	case slices.Contains(signedIntegerKinds, kind):
+		i, err := strconv.ParseInt(string(number), 10, 64)
+		if err != nil {
+			f, ferr := strconv.ParseFloat(string(number), 64)
+			if ferr != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return fmt.Errorf("%s is not an integer within range", number)
+			}
+			i = int64(f)
+		}
+		if target.OverflowInt(i) {
+			return fmt.Errorf("%s is out of range", number)
+		}
+		target.SetInt(i)
+	case slices.Contains(unsignedIntegerKinds, kind):
+		u, err := strconv.ParseUint(string(number), 10, 64)
+		if err != nil {
+			f, ferr := strconv.ParseFloat(string(number), 64)
+			if ferr != nil || f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+				return fmt.Errorf("%s is not a non-negative integer within range", number)
+			}
+			u = uint64(f)
+		}
+		if target.OverflowUint(u) {
+			return fmt.Errorf("%s is out of range", number)
+		}
+		target.SetUint(u)
+	default:
+		f, err := strconv.ParseFloat(string(number), 64)
+		if err != nil || target.OverflowFloat(f) {
+			return fmt.Errorf("%s is out of range", number)
+		}
+		target.SetFloat(f)
+	}
+	return nil
+}
+
+// decodedTypeName describes the given type in field errors, e.g. integer or time.Time
+func decodedTypeName(t reflect.Type) string {
+	switch kind := t.Kind(); {
+	case t.PkgPath() != "" && t.Name() != "":
+		return t.String()
+	case slices.Contains(signedIntegerKinds, kind), slices.Contains(unsignedIntegerKinds, kind):
+		return "integer"
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return "number"
+	case kind == reflect.Bool:
+		return "boolean"
+	case kind == reflect.Slice || kind == reflect.Array:
+		return "array"
+	case kind == reflect.Map || kind == reflect.Struct:
+		return "object"
+	}
+	return t.String()
+}
+
+// decodedValueName describes the type of the given decoded value in field errors, using JSON type names
+func decodedValueName(value interface{}) string {
+	if _, ok := decodedNumber(value); ok {
+		if _, isString := value.(string); !isString {
+			return "number"
+		}
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
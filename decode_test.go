@@ -0,0 +1,178 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type decodedAddress struct {
+	Street string `json:"street" validator:"length(3,_)"`
+	Zip    string `json:"zip"`
+}
+
+type decodedAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type decodedOrder struct {
+	decodedAudit
+	Email     string            `json:"email" validator:"required|email" filter:"trim"`
+	Quantity  int               `json:"quantity" validator:"min(1)|max(10)"`
+	Price     float64           `json:"price"`
+	Gift      bool              `json:"gift"`
+	Note      *string           `json:"note"`
+	Address   decodedAddress    `json:"address"`
+	Items     []decodedAddress  `json:"items"`
+	Labels    map[string]string `json:"labels"`
+	Placed    *time.Time        `json:"placed"`
+	Reference string            `json:"-"`
+	Internal  string
+}
+
+func TestValidateInto(t *testing.T) {
+	v := New()
+
+	var payload map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"created_by": "api",
+		"email": "  Jane@Example.com ",
+		"quantity": 3,
+		"price": 9.5,
+		"gift": "true",
+		"note": "leave at the door",
+		"address": {"street": "Main street", "zip": "1000"},
+		"items": [{"street": "Elm street"}],
+		"labels": {"source": "web"},
+		"placed": "2024-01-02T03:04:05Z",
+		"Internal": "x",
+		"Reference": "ignored"
+	}`), &payload)
+	assertNull(t, err)
+
+	order := decodedOrder{Reference: "kept"}
+	res := v.ValidateInto(payload, &order)
+	assertTrue(t, res.IsValid(), "expected the decoded order to be valid")
+	assertEqual(t, "api", order.CreatedBy)
+	assertEqual(t, "Jane@Example.com", order.Email, "expected filters to apply")
+	assertEqual(t, 3, order.Quantity)
+	assertEqual(t, 9.5, order.Price)
+	assertTrue(t, order.Gift)
+	assertEqual(t, "leave at the door", *order.Note)
+	assertEqual(t, decodedAddress{Street: "Main street", Zip: "1000"}, order.Address)
+	assertEqual(t, []decodedAddress{{Street: "Elm street"}}, order.Items)
+	assertEqual(t, map[string]string{"source": "web"}, order.Labels)
+	assertEqual(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), *order.Placed)
+	assertEqual(t, "x", order.Internal)
+	assertEqual(t, "kept", order.Reference, "expected fields tagged json:\"-\" to be left as is")
+
+	// conversion errors come first and hide the validation errors of the same fields
+	res = v.ValidateInto(map[string]interface{}{
+		"email":    "jane",
+		"quantity": 1.5,
+		"price":    "cheap",
+		"address":  map[string]interface{}{"street": "x", "zip": 1000},
+		"items":    []interface{}{map[string]interface{}{"street": "Elm street"}, "Oak street"},
+		"placed":   "yesterday",
+	}, &decodedOrder{})
+	assertFalse(t, res.IsValid())
+	assertEqual(t, 7, len(res.FieldErrors))
+	assertEqual(t, FieldError{Field: "Quantity", Message: "invalid integer value", Code: CodeInvalidType, Cause: res.FieldErrors[0].Cause}, res.FieldErrors[0])
+	assertEqual(t, FieldError{Field: "Price", Message: "expected number but found string", Code: CodeInvalidType}, res.FieldErrors[1])
+	assertEqual(t, FieldError{Field: "Address.Zip", Message: "expected string but found number", Code: CodeInvalidType}, res.FieldErrors[2])
+	assertEqual(t, FieldError{Field: "Items[1]", Message: "expected validator.decodedAddress but found string", Code: CodeInvalidType}, res.FieldErrors[3])
+	assertEqual(t, "Placed", res.FieldErrors[4].Field)
+	assertEqual(t, "invalid time.Time value", res.FieldErrors[4].Message)
+	assertEqual(t, "Email", res.FieldErrors[5].Field)
+	assertEqual(t, "email", res.FieldErrors[5].Validator)
+	assertEqual(t, "Address.Street", res.FieldErrors[6].Field)
+
+	// integers are checked against the range of their type
+	type Small struct {
+		Level int8  `json:"level"`
+		Count uint8 `json:"count"`
+	}
+	res = v.ValidateInto(map[string]interface{}{"level": json.Number("128"), "count": -1}, &Small{})
+	assertEqual(t, 2, len(res.FieldErrors))
+	assertEqual(t, "invalid integer value", res.FieldErrors[0].Message)
+	assertEqual(t, "invalid integer value", res.FieldErrors[1].Message)
+	var small Small
+	assertTrue(t, v.ValidateInto(map[string]interface{}{"level": "-128", "count": 255.0}, &small).IsValid())
+	assertEqual(t, Small{Level: -128, Count: 255}, small)
+
+	// keys are matched exactly unless case insensitive keys are enabled, unknown keys are reported if rejected
+	data := map[string]interface{}{
+		"EMAIL":    "jane@example.com",
+		"quantity": 2,
+		"address":  map[string]interface{}{"street": "Main street", "city": "Springfield"},
+		"coupon":   "SAVE10",
+	}
+	res = v.ValidateInto(data, &decodedOrder{})
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "Email", res.FieldErrors[0].Field)
+	assertEqual(t, "email", res.FieldErrors[0].Validator)
+
+	var opts ValidationOptions
+	v.CopyOptions(&opts)
+	opts.DecodeCaseInsensitiveKeys = true
+	opts.RejectUnknownKeys = true
+	v.SetupOptions(func(o *ValidationOptions) { *o = opts })
+	order = decodedOrder{}
+	res = v.ValidateInto(data, &order)
+	assertEqual(t, "jane@example.com", order.Email)
+	assertEqual(t, []FieldError{
+		{Field: "Address.city", Message: MsgUnknownField, Code: CodeUnknownField},
+		{Field: "coupon", Message: MsgUnknownField, Code: CodeUnknownField},
+	}, res.FieldErrors)
+
+	// invalid destinations
+	res = v.ValidateInto(data, decodedOrder{})
+	assertFalse(t, res.IsValid())
+	assertEqual(t, "cannot decode into validator.decodedOrder: expected struct pointer", res.Error.Error())
+
+	// invalid options are reported before anything is decoded
+	invalid := New(func(opts *ValidationOptions) {
+		opts.SliceSample = -2
+	})
+	order = decodedOrder{}
+	res = invalid.ValidateInto(data, &order)
+	assertFalse(t, res.IsValid())
+	assertEqual(t, "invalid options: SliceSample must not be negative", res.Error.Error())
+	assertEqual(t, decodedOrder{}, order, "expected the destination to be left as is")
+}
+
+func TestValidateIntoShadowedFields(t *testing.T) {
+	type Base struct {
+		ID   string
+		Name string
+	}
+	type Named struct {
+		Title string `json:"Name"`
+	}
+	type Other struct {
+		ID string
+	}
+	type Entity struct {
+		Base
+		Other
+		Name string
+	}
+	type Tagged struct {
+		Base
+		Named
+	}
+
+	var entity Entity
+	res := New().ValidateInto(map[string]interface{}{"Name": "x", "ID": "1"}, &entity)
+	assertTrue(t, res.IsValid(), "expected the shadowing payload to be decoded")
+	assertEqual(t, Entity{Name: "x"}, entity, "expected the outer field to win and ambiguous fields to be ignored")
+
+	var tagged Tagged
+	assertTrue(t, New().ValidateInto(map[string]interface{}{"Name": "x"}, &tagged).IsValid())
+	assertEqual(t, Tagged{Named: Named{Title: "x"}}, tagged, "expected the tagged field to win at the same depth")
+
+	// decoded like encoding/json
+	var decoded Entity
+	assertNull(t, json.Unmarshal([]byte(`{"Name":"x","ID":"1"}`), &decoded))
+	assertEqual(t, decoded, entity)
+}
@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+)
+
+func ExampleValidator_Validate() {
+	type Account struct {
+		Id       int     `validator:"min(1000)" trigger:"update"`
+		Username string  `validator:"alphanum|length(3,20)" filter:"trim|lower"`
+		Referrer *string `validator:"alphanum" flags:"allow_zero"`
+	}
+
+	v := New()
+	v.AddFilter("lower", func(ctx *ValidationContext) reflect.Value {
+		if !ctx.IsNull {
+			ctx.GetValue().SetString(strings.ToLower(ctx.GetValue().String()))
+		}
+		return ctx.GetValue()
+	})
+
+	report := func(res *ValidationResult) {
+		fmt.Println(res.IsValid())
+		for _, fe := range res.FieldErrors {
+			fmt.Printf("%s (%s): %s\n", fe.Field, fe.Validator, fe.Message)
+		}
+	}
+
+	// Id is only validated upon update and Referrer may be nil. Filters run before validators: the username is
+	// normalized, then validated.
+	account := Account{Username: " Jane"}
+	report(v.Validate(&account, "create"))
+	fmt.Printf("%q\n", account.Username)
+
+	report(v.Validate(&account, "update"))
+	// Output:
+	// true
+	// "jane"
+	// false
+	// Id (min): value (0) must be at least 1000
+}
+
+func ExampleValidator_MustValidate() {
+	type Config struct {
+		Host string `validator:"length(1,_)"`
+		Port int    `validator:"min(1)|max(65535)"`
+	}
+
+	defer func() {
+		fmt.Println(recover())
+	}()
+
+	New().MustValidate(&Config{Port: 70000})
+	// Output:
+	// validation of validator.Config failed:
+	//   Host: length (0) must be at least 1
+	//   Port: value (70000) must not exceed 65535
+}
+
+func ExampleValidator_ValidateAndLog() {
+	type Signup struct {
+		Email string `validator:"email"`
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	New().ValidateAndLog(logger, &Signup{Email: "jane"})
+	// Output:
+	// level=WARN msg="validation failed" struct=validator.Signup field=Email validator=email message="Email: field validation failed"
+}
@@ -1,8 +1,12 @@
 package validator
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/exp/slices"
 )
@@ -11,124 +15,776 @@ type fieldContext struct {
 	filters              []*fieldValueFilter
 	validators           []*fieldValueValidator
 	fieldName            string
+	structType           reflect.Type
+	fieldIndex           []int
+	pathPrefix           string
 	fieldKind            reflect.Kind
+	elemKind             reflect.Kind
+	fieldType            reflect.Type
 	fieldLabel           string
 	fieldMessageTemplate string
 	hasLabel             bool
 	hasMessagTemplate    bool
-	triggers             []string
+	triggers             triggerSet
+	negatedTriggers      triggerSet
 	flags                []ValidationFlag
-	zeroValue            reflect.Value
+	timeout              time.Duration
+	hasTimeout           bool
+	nested               bool
+	// embedded indicates a struct embedded by pointer, see nestedPath
+	embedded bool
+	// filterElements the type of the elements of the slice or array field, pointers resolved, if its filters apply
+	// to each element rather than to the field, nil otherwise
+	filterElements reflect.Type
+	// nullable describes how to unwrap the values of fields of nullable types such as sql.NullString, nil for other
+	// fields, see Nullable
+	nullable *nullableType
+	// resolved describes how to resolve the values of fields of types with a type resolver, nil for other fields, see
+	// RegisterTypeResolver
+	resolved *resolvedField
+	// steps the filters and validators of the rules tag in order of execution, nil for fields using the validator and
+	// filter tags, see ValidationOptions.RulesTagName
+	steps []ruleStep
+}
+
+// ruleStep is a filter or a validator of the rules tag, see ValidationOptions.RulesTagName
+type ruleStep struct {
+	filter    *fieldValueFilter
+	validator *fieldValueValidator
 }
 
 func (fc *fieldContext) isFlagSet(flag ValidationFlag) bool {
 	return slices.Contains(fc.flags, flag)
 }
 
-func (fc *fieldContext) isZero(v reflect.Value) bool {
-	return fc.zeroValue.Equal(v)
+// validatorTimeout returns the duration after which validators of the field are abandoned, 0 if never
+func (fc *fieldContext) validatorTimeout(opts *ValidationOptions) time.Duration {
+	if fc.hasTimeout {
+		return fc.timeout
+	}
+	return opts.ValidatorTimeout
+}
+
+// elementFilterType returns the type of the elements of the slice or array field, pointers resolved, if any of its
+// packaged filters supports the kind of the elements but not the kind of the field, e.g. trim on []string or
+// []*string. All filters of the field then apply to each element. It returns nil otherwise.
+func (fc *fieldContext) elementFilterType() reflect.Type {
+	if fc.fieldKind != reflect.Slice && fc.fieldKind != reflect.Array {
+		return nil
+	}
+	elemType := fc.fieldType.Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	for _, filter := range fc.filters {
+		if meta, ok := packagedFilterMetadata(filter); ok && !meta.supportsKind(fc.fieldKind) && meta.supportsKind(elemType.Kind()) {
+			return elemType
+		}
+	}
+	return nil
+}
+
+// packagedFilterMetadata returns the metadata of the given filter if it is a packaged filter which has not been
+// replaced
+func packagedFilterMetadata(filter *fieldValueFilter) (functionMetadata, bool) {
+	meta, ok := filterMetadata[filter.name]
+	return meta, ok && sameFunction(filter.fn, filterFunctions[filter.name])
+}
+
+// isEmptyValue tests whether the given field value is empty in the sense of the omitempty flag: a nil pointer or
+// interface, an empty string, slice or map, or any other zero value such as 0, false, a zero time.Time or an all
+// zero array. Pointers and interfaces are empty if the value they hold is empty.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil() || isEmptyValue(value.Elem())
+	case reflect.String, reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
+
+// activate tests whether the field is evaluated for the given activation triggers. Exact matches are preferred over
+// patterns such as admin.*, and the first matching rule applies:
+//
+//  1. the field is tagged with any of the given triggers: activated
+//  2. the field is tagged with any of the given triggers negated, e.g. !update: not activated
+//  3. the field is tagged with a pattern matching any of the given triggers: activated
+//  4. the field is tagged with a negated pattern matching any of the given triggers, e.g. !admin.*: not activated
+//  5. the field is tagged with 'all' or with any negated trigger: activated
+//  6. otherwise: not activated
+func (fc *fieldContext) activate(triggers []string) bool {
+	switch {
+	case fc.triggers.matchesExactly(triggers):
+		return true
+	case fc.negatedTriggers.matchesExactly(triggers):
+		return false
+	case fc.triggers.matchesPattern(triggers):
+		return true
+	case fc.negatedTriggers.matchesPattern(triggers):
+		return false
+	}
+	return slices.Contains(fc.triggers.exact, "all") || !fc.negatedTriggers.empty()
+}
+
+// value returns the value of the field in the given struct value as seen by its validators and filters: the resolved
+// value of fields of types with a type resolver, the value held by fields of nullable types, a nil pointer if they are
+// null, see RegisterTypeResolver and Nullable
+func (fc *fieldContext) value(structValue reflect.Value) reflect.Value {
+	value := structValue.FieldByIndex(fc.fieldIndex)
+	if fc.resolved != nil {
+		return fc.resolved.unwrap(value)
+	}
+	if fc.nullable != nil {
+		return fc.nullable.unwrap(value)
+	}
+	return value
+}
+
+// fieldPath returns the path of the field's label relative to the root struct
+func (fc *fieldContext) fieldPath(path string) string {
+	return path + fc.pathPrefix + fc.fieldLabel
+}
+
+// containerPath returns the path of the field's name relative to the root struct, used as the prefix for
+// nested struct values contained in the field
+func (fc *fieldContext) containerPath(path string) string {
+	return path + fc.pathPrefix + fc.fieldName
 }
 
-func (fc *fieldContext) activate(trigger string) bool {
-	if !slices.Contains(fc.triggers, trigger) {
-		return slices.Contains(fc.triggers, "all")
+// nestedPath returns the path of the structs contained in the field relative to the root struct. Fields of structs
+// embedded by pointer are promoted like those of structs embedded by value: their path does not include the name of
+// the embedded field.
+func (fc *fieldContext) nestedPath(path string) string {
+	if fc.embedded {
+		return strings.TrimSuffix(path+fc.pathPrefix, ".")
 	}
-	return true
+	return fc.containerPath(path)
 }
 
-func (fc *fieldContext) apply(structValue reflect.Value, opts *ValidationOptions) []FieldError {
-	field := structValue.FieldByName(fc.fieldName)
-	value := field.Addr().Elem()
+// protect calls fn, recovering from panics when ValidationOptions.RecoverFromPanics is set
+func protect(opts *ValidationOptions, fn func()) (recovered interface{}) {
+	if opts.RecoverFromPanics {
+		defer func() {
+			recovered = recover()
+		}()
+	}
+	fn()
+	return
+}
+
+// protect calls fn like protect, additionally recovering from panics with a *ValidationError value raised for
+// interface fields, such as type mismatches: the dynamic type of their value is input rather than a programming error.
+func (fc *fieldContext) protect(opts *ValidationOptions, fn func()) (recovered interface{}) {
+	if fc.fieldKind != reflect.Interface || opts.RecoverFromPanics {
+		return protect(opts, fn)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(*ValidationError); !ok {
+				panic(r)
+			}
+			recovered = r
+		}
+	}()
+	fn()
+	return
+}
 
-	ispointer := value.Kind() == reflect.Ptr
-	var isnull bool = false
+// panicErrors converts a panic recovered from the given function into an error of the given field if the panic value
+// is a *ValidationError, or into the top level error of the result otherwise. validator is the name of the validator
+// reported by the field error, empty for filters.
+func (fc *fieldContext) panicErrors(state *validationState, field string, function string, validator string, recovered interface{}) []FieldError {
+	if ve, ok := recovered.(*ValidationError); ok {
+		return []FieldError{{Field: field, Message: function + " failed: " + ve.Error(), Validator: validator}}
+	}
+	if state.res.Error == nil {
+		state.res.Error = newValidationError(field+": "+function+" panicked", fmt.Errorf("%v", recovered))
+	}
+	return nil
+}
 
-	var errorList []FieldError
+// apply applies the filters and validators of the field to its value in the given struct value. Filters run first,
+// so that validators see the filtered value, unless ValidationOptions.LegacyFilterOrder is set.
+func (fc *fieldContext) apply(state *validationState, structValue reflect.Value, path string) []FieldError {
+	// nullable and resolved values of unexpected types are reported like panics of validators
+	var value reflect.Value
+	if recovered := fc.protect(state.opts, func() { value = fc.value(structValue) }); recovered != nil {
+		return fc.panicErrors(state, fc.fieldPath(path), "reading the value", "", recovered)
+	}
 
-	if ispointer {
-		isnull = value.IsNil()
+	// see ValidationFlag for the decision table
+	if (fc.isFlagSet(OmitEmpty) || fc.isFlagSet(AllowZero)) && isEmptyValue(value) {
+		return nil
 	}
 
-	if fc.isFlagSet(AllowZero) {
-		if ispointer {
-			if value.IsZero() || fc.isZero(value.Elem()) {
-				return nil
+	opts := state.opts
+	filtered := !state.readOnly && !fc.isFlagSet(SkipFilters) && (len(fc.filters) > 0 || opts.StringAutoTrim)
+
+	if opts.StringAutoTrim && state.readOnly && !fc.isFlagSet(SkipFilters) {
+		if _, ok := fc.autoTrimmed(value); ok {
+			if state.res.Error == nil {
+				state.res.Error = newValidationError("field " + fc.fieldPath(path) + " must be trimmed, which requires a struct pointer")
 			}
-		} else if fc.isZero(value) {
 			return nil
 		}
 	}
 
+	// see ValidationOptions.SkipFiltersOnError
+	var restore func()
+	if filtered && (opts.SkipFiltersOnError || opts.SkipAllFiltersOnError) {
+		restore = fc.snapshot(structValue.FieldByIndex(fc.fieldIndex))
+		if opts.SkipAllFiltersOnError {
+			state.restores = append(state.restores, restore)
+		}
+	}
+	restoreOnError := func(errorList []FieldError) []FieldError {
+		if len(errorList) > 0 && opts.SkipFiltersOnError && restore != nil {
+			restore()
+		}
+		return errorList
+	}
+
+	if opts.StringAutoTrim && filtered {
+		fc.autoTrim(value)
+	}
+
+	if fc.steps != nil {
+		return restoreOnError(fc.applySteps(state, structValue, path))
+	}
+
+	if opts.LegacyFilterOrder {
+		errorList, stop := fc.applyValidators(state, structValue, path)
+		if stop || len(errorList) > 0 && opts.SkipFiltersOnError {
+			return restoreOnError(errorList)
+		}
+		filterErrors, _ := fc.applyFilters(state, structValue, path)
+		return restoreOnError(append(errorList, filterErrors...))
+	}
+
+	errorList, stop := fc.applyFilters(state, structValue, path)
+	if stop {
+		return restoreOnError(errorList)
+	}
+	validatorErrors, _ := fc.applyValidators(state, structValue, path)
+	return restoreOnError(append(errorList, validatorErrors...))
+}
+
+// snapshot returns a function restoring the given field value as it currently is, undoing the changes of filters,
+// see ValidationOptions.SkipFiltersOnError. Elements of slices filtered element-wise are restored as well, while
+// values referenced by pointers, maps and slices are not copied.
+func (fc *fieldContext) snapshot(value reflect.Value) func() {
+	original := reflect.New(value.Type()).Elem()
+	original.Set(value)
+
+	var elements, originalElements reflect.Value
+	if fc.filterElements != nil {
+		elements = value
+		if elements.Kind() == reflect.Ptr && !elements.IsNil() {
+			elements = elements.Elem()
+		}
+		if elements.Kind() == reflect.Slice {
+			originalElements = reflect.MakeSlice(elements.Type(), elements.Len(), elements.Len())
+			reflect.Copy(originalElements, elements)
+		}
+	}
+
+	return func() {
+		value.Set(original)
+		if originalElements.IsValid() {
+			reflect.Copy(elements, originalElements)
+		}
+	}
+}
+
+// autoTrim trims the given string or string pointer field value, as an implicit leading trim filter would, if the
+// field has validators or filters. Like trim, it replaces non-nil pointers rather than modifying the strings they
+// point to.
+func (fc *fieldContext) autoTrim(value reflect.Value) {
+	trimmed, ok := fc.autoTrimmed(value)
+	if !ok {
+		return
+	}
+	if value.Kind() == reflect.Ptr {
+		replacement := reflect.New(value.Type().Elem())
+		replacement.Elem().SetString(trimmed)
+		value.Set(replacement)
+		return
+	}
+	value.SetString(trimmed)
+}
+
+// autoTrimmed returns the trimmed string of the given field value, see autoTrim. The boolean result is false if the
+// value is left as is, because it needs no trimming or because the field is not trimmed.
+func (fc *fieldContext) autoTrimmed(value reflect.Value) (string, bool) {
+	// resolved values are only modified by filters, see RegisterTypeResolverWithInverse
+	if fc.fieldKind != reflect.String || fc.resolved != nil || len(fc.validators)+len(fc.filters) == 0 {
+		return "", false
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", false
+		}
+		value = value.Elem()
+	}
+	trimmed := strings.TrimSpace(value.String())
+	return trimmed, trimmed != value.String()
+}
+
+// newContext creates the context passed to the validators and filters of the field, reflecting its current value
+func (fc *fieldContext) newContext(state *validationState, structValue reflect.Value, path string, args []string) ValidationContext {
+	ctx := fc.newElementContext(state, structValue, fc.value(structValue), fc.containerPath(path), args)
+	if fc.fieldKind != reflect.Interface {
+		ctx.valueKind, ctx.elemKind, ctx.ValueType = fc.fieldKind, fc.elemKind, fc.fieldType
+	}
+	return ctx
+}
+
+// newElementContext creates the context passed to the filters of the field for the given element of its value, found
+// at the given path, see fieldContext.filterElements
+func (fc *fieldContext) newElementContext(state *validationState, structValue reflect.Value, value reflect.Value, fieldPath string, args []string) ValidationContext {
+	// interface values are resolved to their dynamic value, nil interfaces being treated like nil pointers
+	dynamic := value
+	if value.Kind() == reflect.Interface && !value.IsNil() {
+		dynamic = value.Elem()
+	}
+	ispointer := dynamic.Kind() == reflect.Ptr
+	ctx := ValidationContext{
+		IsPointer: ispointer,
+		IsNull:    (ispointer || dynamic.Kind() == reflect.Interface) && dynamic.IsNil(),
+		Options:   state.opts,
+		Args:      args,
+		value:     value,
+		parent:    structValue.FieldByIndex(fc.fieldIndex[:len(fc.fieldIndex)-1]),
+		root:      state.root,
+		fieldPath: fieldPath,
+		goContext: state.ctx,
+		engine:    state.engine,
+		Redacted:  fc.isFlagSet(Sensitive),
+	}
+	if fc.filterElements != nil {
+		ctx.valueKind, ctx.ValueType = fc.filterElements.Kind(), fc.filterElements
+	} else if value.Kind() == reflect.Interface {
+		ctx.valueKind, ctx.ValueType = reflect.Interface, value.Type()
+		if !ctx.IsNull {
+			ctx.ValueType = dynamic.Type()
+			if ispointer {
+				ctx.ValueType = ctx.ValueType.Elem()
+			}
+			ctx.valueKind = ctx.ValueType.Kind()
+			if ctx.valueKind == reflect.Array || ctx.valueKind == reflect.Map || ctx.valueKind == reflect.Slice {
+				ctx.elemKind = ctx.ValueType.Elem().Kind()
+			}
+		}
+	}
+	return ctx
+}
+
+// applyValidators applies the validators of the field. stop reports whether no further function must be applied
+// because of ValidationOptions.StopOnFirstError.
+func (fc *fieldContext) applyValidators(state *validationState, structValue reflect.Value, path string) (errorList []FieldError, stop bool) {
+	opts := state.opts
+	parent := structValue.FieldByIndex(fc.fieldIndex[:len(fc.fieldIndex)-1])
+	stopOnError := opts.StopOnFirstErrorPerField || fc.isFlagSet(StopOnError)
+
 	for _, validator := range fc.validators {
-		ctx := ValidationContext{
-			IsPointer: ispointer,
-			IsNull:    isnull,
-			Options:   opts,
-			Args:      validator.args,
-			value:     value,
-			valueKind: fc.fieldKind,
-		}
-
-		if !validator.fn(&ctx) {
-			fe := FieldError{Field: fc.fieldLabel}
-			if fc.hasMessagTemplate {
-				fe.Message = fc.fieldMessageTemplate
+		errs, failed, skipRemaining := fc.applyValidator(state, structValue, parent, path, validator)
+		if state.stats != nil {
+			state.stats.record(fc, validator.name, failed)
+		}
+
+		if failed {
+			errorList = append(errorList, errs...)
+			if opts.StopOnFirstError {
+				return errorList, true
+			}
+			if stopOnError {
+				break
+			}
+		}
+
+		if skipRemaining {
+			break
+		}
+	}
+
+	return errorList, false
+}
+
+// applySteps applies the filters and validators of the rules tag of the field strictly in order, each validator seeing
+// the value as filtered by the filters preceding it. The first failing filter or validator ends the chain, as the
+// following steps expect the values the preceding ones produce. Validators asking to skip the remaining validators
+// skip the following validators only.
+func (fc *fieldContext) applySteps(state *validationState, structValue reflect.Value, path string) []FieldError {
+	parent := structValue.FieldByIndex(fc.fieldIndex[:len(fc.fieldIndex)-1])
+	skipValidators := false
+
+	for _, step := range fc.steps {
+		if step.filter != nil {
+			if errorList, stop := fc.applyFilterList(state, structValue, path, []*fieldValueFilter{step.filter}); stop || len(errorList) > 0 {
+				return errorList
+			}
+			continue
+		}
+		if skipValidators {
+			continue
+		}
+
+		errorList, failed, skipRemaining := fc.applyValidator(state, structValue, parent, path, step.validator)
+		if state.stats != nil {
+			state.stats.record(fc, step.validator.name, failed)
+		}
+		if failed {
+			return errorList
+		}
+		skipValidators = skipRemaining
+	}
+	return nil
+}
+
+// applyValidator applies the given validator of the field, returning the errors it reported. failed reports whether
+// the validator failed, including panics reported as ValidationResult.Error, and skipRemaining whether it asked to skip
+// the remaining validators of the field, see ValidationContext.SkipRemaining.
+func (fc *fieldContext) applyValidator(state *validationState, structValue reflect.Value, parent reflect.Value, path string, validator *fieldValueValidator) (errorList []FieldError, failed bool, skipRemaining bool) {
+	opts := state.opts
+
+	args, err := resolveArguments(validator.args, parent, validator.checkArgs)
+	if err != nil {
+		return []FieldError{{Field: fc.fieldPath(path), Message: err.Error(), Validator: validator.name}}, true, false
+	}
+
+	ctx := fc.newContext(state, structValue, path, args)
+	fn := state.validatorFunction(validator)
+
+	var valid bool
+	var recovered interface{}
+	if timeout := fc.validatorTimeout(opts); timeout > 0 {
+		var err error
+		valid, recovered, err = fc.callWithTimeout(opts, &ctx, fn, timeout)
+		if err != nil {
+			return []FieldError{{
+				Field:     fc.fieldPath(path),
+				Message:   fmt.Sprintf(MsgValidatorTimeout, timeout),
+				Validator: validator.name,
+				Code:      CodeTimeout,
+				Cause:     err,
+			}}, true, false
+		}
+	} else {
+		recovered = fc.protect(opts, func() {
+			valid = fn(&ctx)
+		})
+	}
+
+	if recovered != nil {
+		return fc.panicErrors(state, fc.fieldPath(path), "validator "+validator.name, validator.name, recovered), true, false
+	}
+
+	if !valid {
+		fe := FieldError{Field: fc.fieldPath(path), Validator: validator.name, Code: ctx.ErrorCode, Cause: ctx.AdditionalError}
+		if fc.isFlagSet(Sensitive) {
+			// underlying errors, e.g. parse errors, may quote the value
+			fe.Cause = nil
+		}
+		if fc.hasMessagTemplate {
+			fe.Message = fc.fieldMessageTemplate
+		} else {
+			if len(ctx.ErrorMessage) > 0 {
+				fe.Message = ctx.ErrorMessage
 			} else {
-				if len(ctx.ErrorMessage) > 0 {
-					fe.Message = ctx.ErrorMessage
-				} else {
-					fe.Message = fc.fieldLabel + ": field validation failed"
-					if opts.ExposeValidatorNames {
-						fe.Message += " using function " + validator.name
-					}
+				fe.Message = fmt.Sprintf(MsgFieldValidationFailed, fc.fieldLabel)
+				if opts.ExposeValidatorNames {
+					fe.Message += " using function " + validator.name
 				}
 			}
-			errorList = append(errorList, fe)
-			if opts.StopOnFirstError {
-				return errorList
+			if opts.ExposeUnderlyingErrors && fe.Cause != nil {
+				fe.Message += ": " + fe.Cause.Error()
 			}
 		}
+		return []FieldError{fe}, true, ctx.SkipRemaining
 	}
 
-	for _, filter := range fc.filters {
-		ctx := ValidationContext{
-			IsPointer: ispointer,
-			IsNull:    isnull,
-			Options:   opts,
-			Args:      filter.args,
-			value:     value,
-			valueKind: fc.fieldKind,
+	return nil, false, ctx.SkipRemaining
+}
+
+// applyFilters applies the filters of the field, each filter receiving the value returned by the previous one.
+// Filters of slice and array fields apply to each element instead if the packaged filters they list expect the kind
+// of the elements, see fieldContext.filterElements.
+//
+// stop reports whether no further function must be applied, because of ValidationOptions.StopOnFirstError, because
+// the field cannot be modified or because a filter failed.
+func (fc *fieldContext) applyFilters(state *validationState, structValue reflect.Value, path string) (errorList []FieldError, stop bool) {
+	return fc.applyFilterList(state, structValue, path, fc.filters)
+}
+
+// applyFilterList applies the given filters of the field like applyFilters
+func (fc *fieldContext) applyFilterList(state *validationState, structValue reflect.Value, path string, filters []*fieldValueFilter) (errorList []FieldError, stop bool) {
+	if fc.isFlagSet(SkipFilters) {
+		filters = nil
+	}
+
+	if len(filters) > 0 && state.readOnly {
+		if state.res.Error == nil {
+			state.res.Error = newValidationError("field " + fc.fieldPath(path) + " has filters which require a struct pointer")
+		}
+		return nil, true
+	}
+
+	value := fc.value(structValue)
+	// null values of nullable types hold no value to filter
+	if (fc.nullable != nil || fc.resolved != nil) && value.Kind() == reflect.Ptr && value.IsNil() {
+		return nil, false
+	}
+	// filters of resolved values store their results through the inverse, see filterValue
+	if fc.resolved != nil {
+		value = structValue.FieldByIndex(fc.fieldIndex)
+	}
+	if fc.filterElements == nil || len(filters) == 0 {
+		return fc.filterValue(state, filters, structValue, path, value, "")
+	}
+
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, false
+		}
+		value = value.Elem()
+	}
+	for i := 0; i < value.Len(); i++ {
+		element := value.Index(i)
+		if element.Kind() == reflect.Ptr && element.IsNil() {
+			continue
+		}
+		elementErrors, elementStop := fc.filterValue(state, filters, structValue, path, element, "["+strconv.Itoa(i)+"]")
+		errorList = append(errorList, elementErrors...)
+		if elementStop && state.opts.StopOnFirstError {
+			return errorList, true
+		}
+		stop = stop || elementStop
+	}
+	return errorList, stop
+}
+
+// filterValue applies the given filters to the given value, either the value of the field or the element found at
+// the given index suffix, e.g. [2]. See applyFilters.
+func (fc *fieldContext) filterValue(state *validationState, filters []*fieldValueFilter, structValue reflect.Value, path string, value reflect.Value, index string) (errorList []FieldError, stop bool) {
+	opts := state.opts
+	field := fc.fieldPath(path) + index
+	parent := structValue.FieldByIndex(fc.fieldIndex[:len(fc.fieldIndex)-1])
+
+	// a failing filter leaves the value as it was before the first filter, see filterError
+	var original reflect.Value
+	if len(filters) > 1 {
+		original = reflect.New(value.Type()).Elem()
+		original.Set(value)
+	}
+
+	for _, filter := range filters {
+		args, err := resolveArguments(filter.args, parent, filter.checkArgs)
+		if err != nil {
+			errorList = append(errorList, FieldError{Field: field, Message: err.Error()})
+			if opts.StopOnFirstError {
+				return errorList, true
+			}
+			continue
+		}
+
+		var ctx ValidationContext
+		if fc.filterElements != nil {
+			ctx = fc.newElementContext(state, structValue, value, fc.containerPath(path)+index, args)
+		} else {
+			ctx = fc.newContext(state, structValue, path, args)
 		}
-		newValue := filter.fn(&ctx)
-		value.Set(newValue)
+
+		var newValue reflect.Value
+		recovered := fc.protect(opts, func() {
+			newValue = filter.fn(&ctx)
+		})
+
+		if recovered != nil {
+			errorList = append(errorList, fc.panicErrors(state, field, "filter "+filter.name, "", recovered)...)
+			if opts.StopOnFirstError {
+				return errorList, true
+			}
+			continue
+		}
+		if ctx.ErrorMessage != "" || ctx.AdditionalError != nil {
+			if original.IsValid() {
+				value.Set(original)
+			}
+			errorList = append(errorList, fc.filterError(state, field, &ctx))
+			return errorList, true
+		}
+		// filters setting the value themselves return an invalid value or the value set, see
+		// ValidationContext.SetReflectValue
+		if !newValue.IsValid() || ctx.isCurrentValue(newValue) {
+			newValue = ctx.value
+		}
+		if fc.resolved != nil {
+			if err := fc.resolved.store(value, newValue); err != nil {
+				if original.IsValid() {
+					value.Set(original)
+				}
+				errorList = append(errorList, FieldError{Field: field, Message: "filter " + filter.name + " failed: " + err.Error(), Cause: err})
+				return errorList, true
+			}
+			continue
+		}
+		ctx.value.Set(newValue)
+	}
+
+	return errorList, false
+}
+
+// filterError returns the error of the given field reported by a failing filter through ValidationContext.ErrorMessage
+// or ValidationContext.AdditionalError. The remaining filters are skipped and the value is left unmodified, undoing
+// the changes of the preceding filters.
+func (fc *fieldContext) filterError(state *validationState, field string, ctx *ValidationContext) FieldError {
+	fe := FieldError{Field: field, Message: ctx.ErrorMessage, Cause: ctx.AdditionalError}
+	if fc.isFlagSet(Sensitive) {
+		fe.Cause = nil
+	}
+	if fe.Message == "" {
+		fe.Message = fmt.Sprintf(MsgFilterFailed, fc.fieldLabel)
+	}
+	if state.opts.ExposeUnderlyingErrors && fe.Cause != nil {
+		fe.Message += ": " + fe.Cause.Error()
 	}
+	return fe
+}
+
+// callWithTimeout calls the given validator in a separate goroutine, bounding the context of the call by the given
+// timeout. A non-nil error, the error of the context, is returned if the validator did not return in time: the call
+// is then abandoned and its context, which it may still use, is never read again. Panics are handled as by protect,
+// being returned as recovered values or raised again in the calling goroutine.
+func (fc *fieldContext) callWithTimeout(opts *ValidationOptions, ctx *ValidationContext, fn ValidationFunction, timeout time.Duration) (valid bool, recovered interface{}, err error) {
+	goContext, cancel := context.WithTimeout(ctx.Context(), timeout)
+	defer cancel()
+	ctx.goContext = goContext
 
-	return errorList
+	type outcome struct {
+		valid     bool
+		recovered interface{}
+	}
+	// buffered, so that abandoned calls never block
+	done := make(chan outcome, 1)
+	go func() {
+		var o outcome
+		defer func() {
+			o.recovered = recover()
+			done <- o
+		}()
+		o.valid = fn(ctx)
+	}()
+
+	select {
+	case o := <-done:
+		recovered = fc.protect(opts, func() {
+			if o.recovered != nil {
+				panic(o.recovered)
+			}
+		})
+		return o.valid, recovered, nil
+	case <-goContext.Done():
+		return false, nil, goContext.Err()
+	}
+}
+
+// newTagError creates the problem reported for a problematic rule found while parsing the tags of the given field
+func newTagError(structType reflect.Type, field reflect.StructField, rule string, msg string, e ...error) *RuleProblem {
+	problem := RuleProblem{Struct: structType.String(), Field: field.Name, Rule: rule, Message: msg}
+	if len(e) > 0 {
+		problem.Cause = e[0]
+	}
+	return &problem
 }
 
-func mustParseField(field reflect.StructField, opts *ValidationOptions) (ctx *fieldContext) {
-	// skip over unexported fields
+// isSkipMarker tests whether the given validator tag is the `-` marker excluding a field from validation and filtering
+func isSkipMarker(validatorTag string) bool {
+	return strings.TrimSpace(validatorTag) == "-"
+}
+
+// parseField parses the tags of the given field declared in the given struct type.
+//
+// A nil context is returned for fields that neither need validation nor contain nested structs.
+func (v *Validator) parseField(structType reflect.Type, field reflect.StructField, opts *ValidationOptions) (ctx *fieldContext, problem *RuleProblem) {
+	embedded := field.Anonymous && field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct
+
+	// skip over unexported fields, except for structs embedded by pointer whose exported fields are promoted. Tags of
+	// such embedded fields are ignored, as their value cannot be read.
 	if field.Name[0] >= 'a' && field.Name[0] <= 'z' {
+		if !embedded {
+			return
+		}
+		field.Tag = ""
+	}
+
+	if problem := opts.TagLimits.checkTag(structType, field); problem != nil {
+		return nil, problem
+	}
+
+	// tag name options may list fallback names, see ValidationOptions.ValidatorTagName
+	var lookupErr error
+	lookup := func(option string) (string, bool) {
+		value, ok, err := lookupTag(field.Tag, option, opts.CaseInsensitiveTagNames)
+		if err != nil && lookupErr == nil {
+			lookupErr = err
+		}
+		return value, ok
+	}
+
+	flagTagValues, hasFlags := lookup(opts.FlagTagName)
+	filterTagValues, filters := lookup(opts.FilterTagName)
+	triggerTagValues, hasTriggers := lookup(opts.TriggerTagName)
+	validatorTagValues, validators := lookup(opts.ValidatorTagName)
+	messageTemplate, hasMsgTemplate := lookup(opts.MessageTagName)
+	label, hasLabel := lookup(opts.LabelTagName)
+	rulesTagValues, hasRules := lookup(opts.RulesTagName)
+	if lookupErr != nil {
+		return nil, newTagError(structType, field, "", lookupErr.Error())
+	}
+	if hasRules && (validators || filters) {
+		return nil, newTagError(structType, field, "", "the "+tagNames(opts.RulesTagName)[0]+" tag cannot be combined with the "+
+			tagNames(opts.ValidatorTagName)[0]+" and "+tagNames(opts.FilterTagName)[0]+" tags")
+	}
+
+	// `validator:"-"` opts the field out of validation and filtering, including nested structs
+	if validators && isSkipMarker(validatorTagValues) {
 		return
 	}
 
-	flagTagValues, hasFlags := field.Tag.Lookup(opts.FlagTagName)
-	filterTagValues, filters := field.Tag.Lookup(opts.FilterTagName)
-	triggerTagValues, hasTriggers := field.Tag.Lookup(opts.TriggerTagName)
-	validatorTagValues, validators := field.Tag.Lookup(opts.ValidatorTagName)
-	messageTemplate, hasMsgTemplate := field.Tag.Lookup(opts.MessageTagName)
-	label, hasLabel := field.Tag.Lookup(opts.LabelTagName)
+	// interface fields may hold structs at runtime, see ValidationOptions.ValidateInterfaceValues
+	// time.Time, types with a numeric adapter or a type resolver, nullable types and tagged fmt.Stringer types are
+	// validated as values, see isValueField
+	traversed := containsStruct(field.Type) && !v.isValueType(innerStructType(field.Type)) && !v.isValueField(field, opts)
+	nested := traversed || field.Type.Kind() == reflect.Interface
+
+	// fields containing structs are traversed, their own fields being checked instead
+	if opts.StrictTags && !traversed && strings.TrimSpace(validatorTagValues) == "" && strings.TrimSpace(rulesTagValues) == "" {
+		return nil, newTagError(structType, field, "", "field has no rules, add a validator tag or `"+tagNames(opts.ValidatorTagName)[0]+":\"-\"` to skip it (StrictTags)")
+	}
 
-	if !filters && !validators {
+	if !filters && !validators && !hasRules && !nested {
 		return
 	}
 
-	var zeroValue reflect.Value
+	fieldType := field.Type
+	var nullable *nullableType
+	var resolved *resolvedField
+	if resolver, ok := v.lookupTypeResolver(fieldType); ok {
+		resolved = newResolvedField(fieldType, resolver)
+	} else {
+		nullable = nullableOf(fieldType)
+	}
 
 	if field.Type.Kind() == reflect.Ptr {
-		zeroValue = reflect.Zero(field.Type.Elem())
-	} else {
-		zeroValue = reflect.Zero(field.Type)
+		fieldType = field.Type.Elem()
+	}
+	if nullable != nil {
+		fieldType = nullable.valueType
+	}
+	fieldKind := fieldType.Kind()
+	if resolved != nil {
+		fieldType, fieldKind = resolved.valueType, resolved.kind
 	}
 
 	fc := fieldContext{
@@ -136,24 +792,42 @@ func mustParseField(field reflect.StructField, opts *ValidationOptions) (ctx *fi
 		filters:           make([]*fieldValueFilter, 0),
 		hasLabel:          hasLabel,
 		hasMessagTemplate: hasMsgTemplate,
-		fieldKind:         field.Type.Kind(),
-		zeroValue:         zeroValue,
+		fieldKind:         fieldKind,
+		fieldType:         fieldType,
+		nested:            nested,
+		embedded:          embedded,
+		nullable:          nullable,
+		resolved:          resolved,
+		structType:        structType,
 	}
 
 	if hasTriggers {
-		triggers := strings.Split(triggerTagValues, ",")
-		fc.triggers = append(fc.triggers, triggers...)
+		for _, trigger := range strings.Split(triggerTagValues, ",") {
+			negated, isNegated := strings.CutPrefix(trigger, "!")
+			if !isNegated {
+				if err := fc.triggers.add(trigger); err != nil {
+					return nil, newTagError(structType, field, trigger, err.Error())
+				}
+				continue
+			}
+			if negated == "" || negated == "all" {
+				return nil, newTagError(structType, field, trigger, "invalid negated trigger `"+trigger+"`")
+			}
+			if err := fc.negatedTriggers.add(negated); err != nil {
+				return nil, newTagError(structType, field, trigger, err.Error())
+			}
+		}
 	} else {
-		fc.triggers = append(fc.triggers, "all")
+		fc.triggers.exact = append(fc.triggers.exact, "all")
 	}
 
 	fc.fieldName = field.Name
 
-	// resolve actual contained type
-	kinds := []reflect.Kind{reflect.Array, reflect.Map, reflect.Slice, reflect.Pointer}
+	// containers keep their own kind, the kind of their elements is resolved separately
+	containers := []reflect.Kind{reflect.Array, reflect.Map, reflect.Slice}
 
-	if slices.Contains(kinds, field.Type.Kind()) {
-		fc.fieldKind = field.Type.Elem().Kind()
+	if slices.Contains(containers, fieldType.Kind()) {
+		fc.elemKind = fieldType.Elem().Kind()
 	}
 
 	if hasLabel {
@@ -167,46 +841,86 @@ func mustParseField(field reflect.StructField, opts *ValidationOptions) (ctx *fi
 	}
 
 	if validators {
-		// split by "|"
+		// split by ValidationOptions.FunctionSeparator, outside of arguments
 		// `validate:"required|uuidv4|v1(arg1,arg2)"`
-		parts := strings.Split(validatorTagValues, "|")
-		if len(parts) > 0 {
-			for _, function := range parts {
-				// extract
-				name, args := extractFunctionInformation(function)
-
-				v, ok := validatorFunctions[name]
-				if !ok {
-					panic(newValidationError("validator `" + name + "` referenced by field " + field.Name + " not found"))
-				}
-
-				fc.validators = append(fc.validators, &fieldValueValidator{name: name, fn: v, args: args})
+		parts, err := splitTopLevel(validatorTagValues, opts.FunctionSeparator)
+		if err != nil {
+			return nil, newTagError(structType, field, validatorTagValues, "invalid validator tag", err)
+		}
+		if problem := opts.TagLimits.checkFunctions(structType, field, "validator", validatorTagValues, parts); problem != nil {
+			return nil, problem
+		}
+		if parts, err = v.expandRuleSets(parts, opts); err != nil {
+			return nil, newTagError(structType, field, validatorTagValues, err.Error())
+		}
+		for _, function := range parts {
+			validator, problem := v.parseValidator(structType, field, fieldType, function, opts)
+			if problem != nil {
+				return nil, problem
 			}
+			fc.validators = append(fc.validators, validator)
 		}
 	}
 
 	if filters {
-		parts := strings.Split(filterTagValues, "|")
-		if len(parts) > 0 {
-			for _, function := range parts {
-				// extract
-				name, args := extractFunctionInformation(function)
+		parts, err := splitTopLevel(filterTagValues, opts.FunctionSeparator)
+		if err != nil {
+			return nil, newTagError(structType, field, filterTagValues, "invalid filter tag", err)
+		}
+		if problem := opts.TagLimits.checkFunctions(structType, field, "filter", filterTagValues, parts); problem != nil {
+			return nil, problem
+		}
+		for _, function := range parts {
+			filter, problem := v.parseFilter(structType, field, function, opts)
+			if problem != nil {
+				return nil, problem
+			}
+			fc.filters = append(fc.filters, filter)
+		}
+	}
 
-				v, ok := filterFunctions[name]
-				if !ok {
-					panic(newValidationError("filter " + name + " referenced by field " + field.Name + " not found"))
-				}
+	if hasRules {
+		if problem := v.parseRules(&fc, structType, field, rulesTagValues, opts); problem != nil {
+			return nil, problem
+		}
+	}
 
-				fc.filters = append(fc.filters, &fieldValueFilter{name: name, fn: v, args: args})
+	if len(fc.filters) > 0 && nullable != nil && nullable.nullable {
+		return nil, newTagError(structType, field, fc.filters[0].rule, "filters cannot modify the values of Nullable type "+field.Type.String())
+	}
+	if len(fc.filters) > 0 && resolved != nil && resolved.inverse == nil {
+		return nil, newTagError(structType, field, fc.filters[0].rule, "filters cannot modify the values of "+field.Type.String()+
+			", whose type resolver has no inverse, see RegisterTypeResolverWithInverse")
+	}
+
+	if len(fc.filters) > 0 {
+		fc.filterElements = fc.elementFilterType()
+		kind := fc.fieldKind
+		if fc.filterElements != nil {
+			kind = fc.filterElements.Kind()
+		}
+		for _, filter := range fc.filters {
+			// the kind of interface values is only known at runtime
+			if meta, ok := packagedFilterMetadata(filter); ok && kind != reflect.Interface && !meta.supportsKind(kind) {
+				return nil, newTagError(structType, field, filter.rule, "filter `"+filter.name+"` does not support "+kind.String()+" values")
 			}
 		}
 	}
 
 	if hasFlags {
-		parts := strings.Split(flagTagValues, "|")
+		parts := strings.Split(flagTagValues, opts.FunctionSeparator)
 		if len(parts) > 0 {
 			for _, flag := range parts {
-				fc.flags = append(fc.flags, ValidationFlag(strings.TrimSpace(flag)))
+				flag = strings.TrimSpace(flag)
+				if value, ok := strings.CutPrefix(flag, string(Timeout)+"="); ok {
+					timeout, err := time.ParseDuration(value)
+					if err != nil || timeout < 0 {
+						return nil, newTagError(structType, field, flag, "invalid timeout flag, expected a duration such as timeout=2s", err)
+					}
+					fc.timeout, fc.hasTimeout = timeout, true
+					continue
+				}
+				fc.flags = append(fc.flags, ValidationFlag(flag))
 			}
 		}
 	}
@@ -215,18 +929,136 @@ func mustParseField(field reflect.StructField, opts *ValidationOptions) (ctx *fi
 	return
 }
 
-func extractFunctionInformation(funcDefinition string) (name string, args []string) {
-	if strings.HasSuffix(funcDefinition, "()") {
-		name = strings.Trim(funcDefinition, "()")
-		args = []string{}
-	} else if strings.ContainsAny(funcDefinition, "()") {
-		openParenthesisPosition := strings.Index(funcDefinition, "(")
-		closeParenthesisPosition := strings.LastIndex(funcDefinition, ")")
-		name = funcDefinition[0:openParenthesisPosition]
-		args = strings.Split(funcDefinition[openParenthesisPosition+1:closeParenthesisPosition], ",")
-	} else {
-		name = funcDefinition
-		args = []string{}
+// parseValidator parses the given validator function of the field, whose type is given with pointers resolved
+func (v *Validator) parseValidator(structType reflect.Type, field reflect.StructField, fieldType reflect.Type, function string, opts *ValidationOptions) (*fieldValueValidator, *RuleProblem) {
+	// extract
+	name, args, err := extractFunctionInformation(function)
+	if err != nil {
+		return nil, newTagError(structType, field, function, "invalid validator", err)
 	}
-	return
+	if problem := opts.TagLimits.checkArguments(structType, field, function, name, args); problem != nil {
+		return nil, problem
+	}
+
+	fn, ok := v.lookupValidator(name)
+	if !ok {
+		return nil, newTagError(structType, field, function, "validator `"+name+"` not found")
+	}
+
+	if err := checkPlaceholders(args); err != nil {
+		return nil, newTagError(structType, field, function, "validator `"+name+"` has invalid arguments", err)
+	}
+
+	var checkArgs func([]string) error
+	if meta, ok := validatorMetadata[name]; ok && sameFunction(fn, validatorFunctions[name]) {
+		// operands of types with a numeric adapter are parsed by the adapter, e.g. min(0.01)
+		if isNumericValidator(name) && v.isNumericType(fieldType) {
+			meta.checkArgs = nil
+		}
+		if err := meta.checkArguments(args); err != nil {
+			return nil, newTagError(structType, field, function, "validator `"+name+"` has invalid arguments", err)
+		}
+		// arguments with placeholders are verified once resolved, see resolveArguments
+		if hasPlaceholders(args) {
+			checkArgs = meta.checkArgs
+		}
+		// enum values are matched against their normalized form, e.g. 01 matching 1
+		if name == "enum" && !hasPlaceholders(args) {
+			if args, err = normalizeEnumArguments(args, quotedArguments(function), fieldType, opts.StrictTagParsing); err != nil {
+				return nil, newTagError(structType, field, function, "validator `"+name+"` has invalid arguments", err)
+			}
+		}
+		// patterns are registered per instance, see Validator.RegisterPattern
+		if name == "pattern" && !hasPlaceholders(args) {
+			if _, ok := v.lookupPattern(args[0]); !ok {
+				return nil, newTagError(structType, field, function, "pattern `"+args[0]+"` not found")
+			}
+		}
+	}
+
+	return &fieldValueValidator{name: name, fn: fn, args: args, rule: function, checkArgs: checkArgs}, nil
+}
+
+// parseFilter parses the given filter function of the field
+func (v *Validator) parseFilter(structType reflect.Type, field reflect.StructField, function string, opts *ValidationOptions) (*fieldValueFilter, *RuleProblem) {
+	// extract
+	name, args, err := extractFunctionInformation(function)
+	if err != nil {
+		return nil, newTagError(structType, field, function, "invalid filter", err)
+	}
+	if problem := opts.TagLimits.checkArguments(structType, field, function, name, args); problem != nil {
+		return nil, problem
+	}
+
+	fn, ok := v.lookupFilter(name)
+	if !ok {
+		return nil, newTagError(structType, field, function, "filter `"+name+"` not found")
+	}
+
+	if err := checkPlaceholders(args); err != nil {
+		return nil, newTagError(structType, field, function, "filter `"+name+"` has invalid arguments", err)
+	}
+
+	var checkArgs func([]string) error
+	if meta, ok := filterMetadata[name]; ok && sameFunction(fn, filterFunctions[name]) {
+		if err := meta.checkArguments(args); err != nil {
+			return nil, newTagError(structType, field, function, "filter `"+name+"` has invalid arguments", err)
+		}
+		if hasPlaceholders(args) {
+			checkArgs = meta.checkArgs
+		}
+	}
+
+	return &fieldValueFilter{name: name, fn: fn, args: args, rule: function, checkArgs: checkArgs}, nil
+}
+
+// parseRules parses the rules tag of the field, listing filters and validators, adding them to the steps of the given
+// context as well as to its filters and validators. Names are looked up among filters first, then among validators,
+// unless prefixed with f: or v:. Rule sets and aliases expand to validators.
+func (v *Validator) parseRules(fc *fieldContext, structType reflect.Type, field reflect.StructField, tagValue string, opts *ValidationOptions) *RuleProblem {
+	parts, err := splitTopLevel(tagValue, opts.FunctionSeparator)
+	if err != nil {
+		return newTagError(structType, field, tagValue, "invalid rules tag", err)
+	}
+	if problem := opts.TagLimits.checkFunctions(structType, field, "rule", tagValue, parts); problem != nil {
+		return problem
+	}
+
+	fc.steps = make([]ruleStep, 0, len(parts))
+	for _, part := range parts {
+		function := strings.TrimSpace(part)
+		isFilter := false
+		if filter, ok := strings.CutPrefix(function, "f:"); ok {
+			function, isFilter = filter, true
+		} else if validator, ok := strings.CutPrefix(function, "v:"); ok {
+			function = validator
+		} else {
+			name, _, _ := strings.Cut(function, "(")
+			_, isFilter = v.lookupFilter(strings.TrimSpace(name))
+		}
+
+		if isFilter {
+			filter, problem := v.parseFilter(structType, field, function, opts)
+			if problem != nil {
+				return problem
+			}
+			fc.filters = append(fc.filters, filter)
+			fc.steps = append(fc.steps, ruleStep{filter: filter})
+			continue
+		}
+
+		validators, err := v.expandRuleSets([]string{function}, opts)
+		if err != nil {
+			return newTagError(structType, field, tagValue, err.Error())
+		}
+		for _, function := range validators {
+			validator, problem := v.parseValidator(structType, field, fc.fieldType, function, opts)
+			if problem != nil {
+				return problem
+			}
+			fc.validators = append(fc.validators, validator)
+			fc.steps = append(fc.steps, ruleStep{validator: validator})
+		}
+	}
+	return nil
 }
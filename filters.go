@@ -6,6 +6,9 @@ type fieldValueFilter struct {
 	fn   FilterFunction
 	name string
 	args []string
+	rule string
+	// checkArgs verifies the arguments once their placeholders are resolved, nil if there is nothing to verify
+	checkArgs func([]string) error
 }
 
 func (f fieldValueFilter) Apply(ctx *ValidationContext) reflect.Value {
@@ -3,10 +3,50 @@ package validator
 // Flags used by the validation engine before calling validations and filters.
 //
 // Flags will alter behavior of the validator towards each field being evaluated.
+//
+// The following table describes which functions run for a field depending on its state. The first matching row applies.
+//
+//	condition                      validators   filters
+//	trigger not active             no           no
+//	omitempty and empty value      no           no
+//	nil pointer                    yes (1)      yes (1, 2)
+//	otherwise                      yes          yes (2)
+//
+// (1) functions receive ValidationContext.IsNull set to true and are expected to treat the value as absent.
+//
+// (2) unless skip_filters is set, in which case the field is never modified.
+//
+// A nil pointer is an empty value, so omitempty skips nil pointers as well.
+//
+// Independently of the table, a validator may skip the remaining validators of a field through
+// ValidationContext.SkipRemaining, as required_if and required_unless do when their condition does not apply.
+// Filters still run.
 type ValidationFlag string
 
 const (
-	// If a value contains zero value, allow the value to pass through by skipping
-	// validation since there's nothing to validate or filter.
+	// Skip all validators and filters of a field holding an empty value: a nil pointer, an empty string, slice or
+	// map, or another zero value such as 0, false or a zero time.Time. Pointers to empty values are empty as well.
+	// Non-empty values are evaluated by all functions, including required.
+	OmitEmpty ValidationFlag = "omitempty"
+
+	// Alias of OmitEmpty, kept for compatibility. Unlike in earlier versions, filters of empty values do not run.
 	AllowZero ValidationFlag = "allow_zero"
+
+	// Skip the filters of a field, including automatic ones such as ValidationOptions.StringAutoTrim, while
+	// validators still run. Useful for values which must never be modified, such as signatures.
+	SkipFilters ValidationFlag = "skip_filters"
+
+	// Stop evaluating the validators of a field after its first failing validator, e.g. to report only the failure
+	// of required rather than every validator chained after it. Other fields are still evaluated and filters still
+	// run. See ValidationOptions.StopOnFirstErrorPerField to apply it to all fields.
+	StopOnError ValidationFlag = "stop_on_error"
+
+	// Hide the value of a field, such as a password or token, from error messages. Packaged validators replace the
+	// value with RedactedValue and underlying errors, which may contain the value, are neither appended to messages
+	// nor reported as FieldError.Cause. Custom validators must consult ValidationContext.Redacted.
+	Sensitive ValidationFlag = "sensitive"
+
+	// Abandon validators of a field after the given duration, overriding ValidationOptions.ValidatorTimeout, e.g.
+	// `flags:"timeout=2s"`, or `flags:"timeout=0"` to disable the timeout for the field.
+	Timeout ValidationFlag = "timeout"
 )
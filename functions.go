@@ -8,27 +8,191 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"golang.org/x/exp/slices"
 )
 
 var validatorFunctions = map[string]ValidationFunction{
-	"required":       IsRequired,
-	"alphanum":       IsAlphaNumeric,
-	"uuid1":          IsUuid1,
-	"uuid2":          IsUuid2,
-	"uuid3":          IsUuid3,
-	"uuid4":          IsUuid4,
-	"min":            IsMin,
-	"max":            IsMax,
-	"enum":           IsEnum,
-	"email":          IsEmail,
-	"at_least_today": IsOrBeforeToday,
-	"at_most_today":  IsOrAfterToday,
-	"today":          IsToday,
-	"before_today":   IsBeforeToday,
-	"after_today":    IsAfterToday,
+	"required":        IsRequired,
+	"alphanum":        IsAlphaNumeric,
+	"uuid1":           IsUuid1,
+	"uuid2":           IsUuid2,
+	"uuid3":           IsUuid3,
+	"uuid4":           IsUuid4,
+	"uuid_bytes":      IsUuidBytes,
+	"uuid_canonical":  IsUuidCanonical,
+	"nonzero":         IsNonZero,
+	"min":             IsMin,
+	"max":             IsMax,
+	"length":          IsLength,
+	"enum":            IsEnum,
+	"enum_field":      IsEnumField,
+	"pattern":         IsPattern,
+	"email":           IsEmail,
+	"resolvable":      IsResolvable,
+	"webhook_url":     IsWebhookURL,
+	"at_least_today":  IsOrBeforeToday,
+	"at_most_today":   IsOrAfterToday,
+	"today":           IsToday,
+	"before_today":    IsBeforeToday,
+	"after_today":     IsAfterToday,
+	"between_dates":   IsBetweenDates,
+	"age_between":     IsAgeBetween,
+	"dob":             IsDateOfBirth,
+	"eqfield":         IsEqualToField,
+	"nefield":         IsNotEqualToField,
+	"gtfield":         IsGreaterThanField,
+	"gtefield":        IsGreaterThanOrEqualToField,
+	"ltfield":         IsLessThanField,
+	"ltefield":        IsLessThanOrEqualToField,
+	"required_if":     IsRequiredIf,
+	"required_unless": IsRequiredUnless,
+}
+
+// functionMetadata describes the expectations of a packaged validator or filter, allowing struct tags to be
+// checked without values
+type functionMetadata struct {
+	// description describes the function, see FunctionInfo
+	description string
+	// kinds lists the supported kinds of field values (element kinds for pointers, slices, arrays and maps).
+	// All kinds are supported if empty
+	kinds []reflect.Kind
+	// minArgs is the minimum number of arguments
+	minArgs int
+	// maxArgs is the maximum number of arguments, -1 if unlimited
+	maxArgs int
+	// checkArgs verifies the values of the arguments, if set
+	checkArgs func(args []string) error
+	// fieldArgument indicates that the first argument names a field declared in the same struct
+	fieldArgument bool
+}
+
+// checkArguments verifies the number of arguments followed by their values. Values are only verified if none of the
+// arguments is a ${Field} placeholder, which is resolved at validation time.
+func (m functionMetadata) checkArguments(args []string) error {
+	if len(args) < m.minArgs || (m.maxArgs >= 0 && len(args) > m.maxArgs) {
+		return errors.New("expected " + m.arity() + " but found " + strconv.Itoa(len(args)))
+	}
+	if m.checkArgs != nil && !hasPlaceholders(args) {
+		return m.checkArgs(args)
+	}
+	return nil
+}
+
+// arity describes the number of expected arguments
+func (m functionMetadata) arity() string {
+	switch {
+	case m.maxArgs == 0:
+		return "no arguments"
+	case m.maxArgs < 0:
+		return "at least " + strconv.Itoa(m.minArgs) + " argument(s)"
+	case m.minArgs == m.maxArgs:
+		return "exactly " + strconv.Itoa(m.minArgs) + " argument(s)"
+	default:
+		return strconv.Itoa(m.minArgs) + " to " + strconv.Itoa(m.maxArgs) + " arguments"
+	}
+}
+
+// supportsKind tests whether the given kind is supported
+func (m functionMetadata) supportsKind(kind reflect.Kind) bool {
+	return len(m.kinds) == 0 || slices.Contains(m.kinds, kind)
+}
+
+var (
+	integerKinds  = append(append([]reflect.Kind{}, signedIntegerKinds...), unsignedIntegerKinds...)
+	temporalKinds = append([]reflect.Kind{reflect.String, reflect.Struct}, integerKinds...)
+	stringKinds   = []reflect.Kind{reflect.String}
+	// comparableKinds lists the kinds compared by cross field validators, time.Time being the only supported struct
+	comparableKinds = append([]reflect.Kind{reflect.String, reflect.Float32, reflect.Float64, reflect.Struct}, integerKinds...)
+)
+
+// validatorMetadata describes packaged validators. It is used to check struct tags when they are parsed, as long
+// as the validators have not been replaced.
+var validatorMetadata = map[string]functionMetadata{
+	"required":        {description: "value must not be nil, nor an all zero array", maxArgs: 0},
+	"alphanum":        {description: "string must only contain lowercase letters and digits", kinds: stringKinds, maxArgs: 0},
+	"uuid1":           {description: "string must be a version 1 UUID", kinds: stringKinds, maxArgs: 0},
+	"uuid2":           {description: "string must be a version 2 UUID", kinds: stringKinds, maxArgs: 0},
+	"uuid3":           {description: "string must be a version 3 UUID", kinds: stringKinds, maxArgs: 0},
+	"uuid4":           {description: "string must be a version 4 UUID", kinds: stringKinds, maxArgs: 0},
+	"uuid_bytes":      {description: "16 byte array must be a UUID, optionally not the nil UUID", kinds: []reflect.Kind{reflect.Array}, maxArgs: 1, checkArgs: checkUuidBytesArgument},
+	"uuid_canonical":  {description: "string must be a lowercase hyphenated UUID", kinds: stringKinds, maxArgs: 0},
+	"nonzero":         {description: "value must differ from the zero value of its type", maxArgs: 0},
+	"email":           {description: "string must be an email address", kinds: stringKinds, maxArgs: 0},
+	"resolvable":      {description: "host name or URL must resolve, performing DNS lookups", kinds: stringKinds, maxArgs: 1, checkArgs: checkTimeoutArgument},
+	"webhook_url":     {description: "string must be an https URL suitable for webhooks", kinds: stringKinds, maxArgs: 4, checkArgs: checkWebhookArguments},
+	"min":             {description: "integer must be at least the argument, or list must contain at least as many items", kinds: append([]reflect.Kind{reflect.String, reflect.Slice, reflect.Array, reflect.Map}, integerKinds...), minArgs: 1, maxArgs: 1, checkArgs: checkIntegerArgument},
+	"max":             {description: "integer must not exceed the argument, or list must contain at most as many items", kinds: append([]reflect.Kind{reflect.String, reflect.Slice, reflect.Array, reflect.Map}, integerKinds...), minArgs: 1, maxArgs: 1, checkArgs: checkIntegerArgument},
+	"length":          {description: "length must be within the given bounds", minArgs: 2, maxArgs: 3, checkArgs: checkLengthArguments},
+	"enum":            {description: "value must be any of the arguments", kinds: append([]reflect.Kind{reflect.String}, integerKinds...), minArgs: 1, maxArgs: -1},
+	"enum_field":      {description: "value must be among the elements of the given slice field", kinds: append([]reflect.Kind{reflect.String}, integerKinds...), minArgs: 1, maxArgs: 1, fieldArgument: true},
+	"pattern":         {description: "string must match the given registered pattern", kinds: stringKinds, minArgs: 1, maxArgs: 1},
+	"at_least_today":  {description: "date must be today or before today", kinds: temporalKinds, maxArgs: 1, checkArgs: checkLayoutArgument},
+	"at_most_today":   {description: "date must be today or after today", kinds: temporalKinds, maxArgs: 1, checkArgs: checkLayoutArgument},
+	"today":           {description: "date must be today", kinds: temporalKinds, maxArgs: 1, checkArgs: checkLayoutArgument},
+	"before_today":    {description: "date must be before today", kinds: temporalKinds, maxArgs: 1, checkArgs: checkLayoutArgument},
+	"after_today":     {description: "date must be after today", kinds: temporalKinds, maxArgs: 1, checkArgs: checkLayoutArgument},
+	"between_dates":   {description: "date must be within the given range", kinds: temporalKinds, minArgs: 2, maxArgs: 4, checkArgs: checkDateRangeArguments},
+	"age_between":     {description: "age implied by a date of birth must be within the given bounds", kinds: temporalKinds, minArgs: 2, maxArgs: 3, checkArgs: checkAgeRangeArguments},
+	"dob":             {description: "date must be a plausible date of birth", kinds: temporalKinds, maxArgs: 1, checkArgs: checkLayoutArgument},
+	"eqfield":         {description: "value must equal the given field", kinds: comparableKinds, minArgs: 1, maxArgs: 1, fieldArgument: true},
+	"nefield":         {description: "value must differ from the given field", kinds: comparableKinds, minArgs: 1, maxArgs: 1, fieldArgument: true},
+	"gtfield":         {description: "value must be greater than the given field", kinds: comparableKinds, minArgs: 1, maxArgs: 1, fieldArgument: true},
+	"gtefield":        {description: "value must be greater than or equal to the given field", kinds: comparableKinds, minArgs: 1, maxArgs: 1, fieldArgument: true},
+	"ltfield":         {description: "value must be less than the given field", kinds: comparableKinds, minArgs: 1, maxArgs: 1, fieldArgument: true},
+	"ltefield":        {description: "value must be less than or equal to the given field", kinds: comparableKinds, minArgs: 1, maxArgs: 1, fieldArgument: true},
+	"required_if":     {description: "value must be set if the given field has any of the given values", minArgs: 2, maxArgs: -1, fieldArgument: true},
+	"required_unless": {description: "value must be set unless the given field has any of the given values", minArgs: 2, maxArgs: -1, fieldArgument: true},
+}
+
+// filterMetadata describes packaged filters. It is used to check struct tags when they are parsed, as long
+// as the filters have not been replaced.
+var filterMetadata = map[string]functionMetadata{
+	"trim":           {description: "removes leading and trailing whitespace", kinds: stringKinds, maxArgs: 0},
+	"null_if_empty":  {description: "replaces empty strings with nil", kinds: stringKinds, maxArgs: 0},
+	"canonical_uuid": {description: "rewrites UUIDs in lowercase hyphenated form", kinds: stringKinds, maxArgs: 0},
+}
+
+// checkIntegerArgument verifies that the arguments are integers
+func checkIntegerArgument(args []string) error {
+	for _, arg := range args {
+		if _, err := strconv.ParseInt(arg, 10, 64); err != nil {
+			return errors.New("expected an integer argument but found " + arg)
+		}
+	}
+	return nil
+}
+
+// checkUuidBytesArgument verifies the optional 'nonzero' argument of the uuid_bytes validator
+func checkUuidBytesArgument(args []string) error {
+	if len(args) == 1 && args[0] != "nonzero" {
+		return errors.New("unknown argument " + args[0])
+	}
+	return nil
+}
+
+// checkLengthArguments verifies the arguments of the length validator
+func checkLengthArguments(args []string) error {
+	for _, arg := range args[:2] {
+		if arg == "_" {
+			continue
+		}
+		if _, err := strconv.ParseInt(arg, 10, 64); err != nil {
+			return errors.New("expected an integer or '_' but found " + arg)
+		}
+	}
+	if len(args) == 3 && args[2] != "bytes" {
+		return errors.New("unknown argument " + args[2])
+	}
+	return nil
+}
+
+// sameFunction tests whether both functions refer to the same code, which is used to apply parse time checks
+// of packaged functions only when they have not been replaced.
+func sameFunction(a, b interface{}) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
 }
 
 var emailHostNameMatcher *regexp.Regexp
@@ -41,31 +205,65 @@ func init() {
 	}
 }
 
+// temporalValue resolves the time value held by the context using the given resolved layout.
+//
+// errAbsentTime is returned for blank strings and zero timestamps unless ValidationOptions.RejectEmptyDates is set.
+func temporalValue(ctx *ValidationContext, layout string) (time.Time, error) {
+	if ctx.IsValueOfKind(reflect.String) {
+		value := ctx.GetValue().String()
+		// blank strings are treated as absent values, leaving presence checks to the required validator
+		if len(strings.TrimSpace(value)) == 0 && !ctx.Options.RejectEmptyDates {
+			return time.Time{}, errAbsentTime
+		}
+		then, err := parseTime(value, layout)
+		if err != nil {
+			ctx.AdditionalError = err
+			ctx.ErrorMessage = fmt.Sprintf(MsgDateFormat, layout)
+			return then, err
+		}
+		return then, nil
+	}
+
+	// defined types such as `type Date time.Time` are accepted as well
+	if value, ok := ctx.ConvertValueTo(time.Time{}); ok {
+		return value.Interface().(time.Time), nil
+	}
+
+	// integers are Unix timestamps in seconds, or milliseconds when using the unixmilli layout
+	if ctx.IsValueOfKind(signedIntegerKinds...) || ctx.IsValueOfKind(unsignedIntegerKinds...) {
+		var timestamp int64
+		if ctx.IsValueOfKind(signedIntegerKinds...) {
+			timestamp = ctx.GetValue().Int()
+		} else {
+			timestamp = int64(ctx.GetValue().Uint())
+		}
+		// zero timestamps are treated as absent values, consistent with blank strings
+		if timestamp == 0 && !ctx.Options.RejectEmptyDates {
+			return time.Time{}, errAbsentTime
+		}
+		return unixTime(timestamp, layout), nil
+	}
+
+	panic(newValidationError("only time.Time, string and integer types, types convertible to them and their pointer types are supported"))
+}
+
 func timeValidator(ctx *ValidationContext, comparator Comparator) bool {
-	var err error
-	var then time.Time
-	today := time.Now()
-	layout := "2006-01-02"
+	today := currentTime(ctx.Options)
+	layout := defaultLayout
 
 	if ctx.IsPointer && ctx.IsNull {
 		return true
 	}
 
 	if ctx.ArgCount() == 1 {
-		layout = ctx.Args[0]
+		layout = mustResolveLayout(ctx.Args[0])
 	}
 
-	if ctx.IsValueOfKind(reflect.String) {
-		then, err = time.Parse(layout, ctx.GetValue().String())
-		if err != nil {
-			ctx.AdditionalError = err
-			ctx.ErrorMessage = "invalid date format. expected format is " + layout
-			return false
-		}
-	} else if ctx.IsValueOfType(&then) {
-		then = ctx.GetValue().Interface().(time.Time)
-	} else {
-		panic(newValidationError("only time.Time and string and their pointer types are supported"))
+	then, err := temporalValue(ctx, layout)
+	if err == errAbsentTime {
+		return true
+	} else if err != nil {
+		return false
 	}
 
 	match := false
@@ -84,17 +282,17 @@ func timeValidator(ctx *ValidationContext, comparator Comparator) bool {
 
 	if !match {
 		ctx.ErrorMessage = fmt.Sprintf(
-			"%s must be %s %s",
-			then.Format(layout),
+			MsgDateComparison,
+			ctx.Redact(formatTime(then, layout)),
 			comparator.TemporalDescription(),
-			today.Format(layout),
+			formatTime(today, layout),
 		)
 	}
 
 	return match
 }
 
-// IsBeforeToday tests whether the given date is today or before today.
+// IsOrBeforeToday tests whether the given date is today or before today.
 //
 // If the time layout is not specified, '2006-01-02' will be used
 func IsOrBeforeToday(ctx *ValidationContext) bool {
@@ -136,6 +334,109 @@ func IsNotToday(ctx *ValidationContext) bool {
 	return timeValidator(ctx, NOT_EQUAL)
 }
 
+// IsBetweenDates tests whether the given date falls within the given range, including both ends.
+//
+// Arguments: from and to dates, followed by an optional layout and an optional 'exclusive' modifier which excludes
+// both ends, e.g. between_dates(2024-01-01,2024-12-31) or between_dates(01/2024,12/2024,01/2006,exclusive).
+//
+// If the time layout is not specified, '2006-01-02' will be used
+func IsBetweenDates(ctx *ValidationContext) bool {
+	r, err := parseDateRange(ctx.Args)
+	if err != nil {
+		panic(newValidationError(err.Error()))
+	}
+
+	if ctx.IsPointer && ctx.IsNull {
+		return true
+	}
+
+	then, err := temporalValue(ctx, r.layout)
+	if err == errAbsentTime {
+		return true
+	} else if err != nil {
+		return false
+	}
+
+	var match bool
+	if r.exclusive {
+		match = then.After(r.from) && then.Before(r.to)
+	} else {
+		match = !then.Before(r.from) && !then.After(r.to)
+	}
+
+	if !match {
+		bounds := "between"
+		if r.exclusive {
+			bounds = "strictly between"
+		}
+		ctx.ErrorMessage = fmt.Sprintf(
+			MsgDateRange,
+			ctx.Redact(formatTime(then, r.layout)),
+			bounds,
+			formatTime(r.from, r.layout),
+			formatTime(r.to, r.layout),
+		)
+	}
+
+	return match
+}
+
+// ageValidator tests whether the age implied by the given date of birth falls within the given bounds
+func ageValidator(ctx *ValidationContext, layout string, minAge int, maxAge int) bool {
+	if ctx.IsPointer && ctx.IsNull {
+		return true
+	}
+
+	dob, err := temporalValue(ctx, layout)
+	if err == errAbsentTime {
+		return true
+	} else if err != nil {
+		return false
+	}
+
+	now := currentTime(ctx.Options)
+	if dob.After(now) {
+		ctx.ErrorMessage = MsgDateOfBirth
+		return false
+	}
+
+	actual := age(dob, now)
+	if actual >= minAge && actual <= maxAge {
+		return true
+	}
+
+	ctx.ErrorMessage = fmt.Sprintf(MsgAgeRange, minAge, maxAge)
+	if ctx.Options.IncludeFieldValues && !ctx.Redacted {
+		ctx.ErrorMessage = fmt.Sprintf(MsgAgeRangeWithValue, actual, minAge, maxAge)
+	}
+	return false
+}
+
+// IsAgeBetween tests whether the age implied by the given date of birth is within the given bounds, inclusive.
+//
+// Arguments: minimum and maximum age followed by an optional layout, e.g. age_between(18,65).
+//
+// If the time layout is not specified, '2006-01-02' will be used
+func IsAgeBetween(ctx *ValidationContext) bool {
+	r, err := parseAgeRange(ctx.Args)
+	if err != nil {
+		panic(newValidationError(err.Error()))
+	}
+	return ageValidator(ctx, r.layout, r.min, r.max)
+}
+
+// IsDateOfBirth tests whether the given date is a plausible date of birth: a date in the past implying
+// an age of at most 130 years.
+//
+// If the time layout is not specified, '2006-01-02' will be used
+func IsDateOfBirth(ctx *ValidationContext) bool {
+	layout := defaultLayout
+	if ctx.ArgCount() == 1 {
+		layout = mustResolveLayout(ctx.Args[0])
+	}
+	return ageValidator(ctx, layout, 0, maxPlausibleAge)
+}
+
 // IsEmail tests if the input value matches an email format.
 //
 // The validation rules used here do not conform to RFC and only allow only a few latin character set values.
@@ -179,7 +480,13 @@ func IsEmail(ctx *ValidationContext) bool {
 	return true
 }
 
-// IsEnum tests if the input value matches any of the values passed in the arguments
+// IsEnum tests if the input value matches any of the values passed in the arguments.
+//
+// Integer values whose type implements fmt.Stringer, such as iota based enums, are compared by name when any argument
+// is not an integer, e.g. enum(PENDING,APPROVED): the output of their String method must match an argument.
+//
+// Arguments are normalized when parsing tags, e.g. enum(01, 2) matching 1 and 2 on integer fields, see
+// normalizeEnumArguments.
 func IsEnum(ctx *ValidationContext) bool {
 	if ctx.IsNull {
 		return true
@@ -191,10 +498,12 @@ func IsEnum(ctx *ValidationContext) bool {
 		panic(newValidationError("enum: At least one enum value must be specified"))
 	}
 
-	if ctx.IsValueOfKind(reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64) {
+	if name, ok := enumName(ctx); ok {
+		match = slices.Contains(ctx.Args, name)
+	} else if ctx.IsValueOfKind(signedIntegerKinds...) {
 		value := strconv.FormatInt(ctx.GetValue().Int(), 10)
 		match = slices.Contains(ctx.Args, value)
-	} else if ctx.IsValueOfKind(reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64) {
+	} else if ctx.IsValueOfKind(unsignedIntegerKinds...) {
 		value := strconv.FormatUint(ctx.GetValue().Uint(), 10)
 		match = slices.Contains(ctx.Args, value)
 	} else if ctx.IsValueOfKind(reflect.String) {
@@ -204,35 +513,188 @@ func IsEnum(ctx *ValidationContext) bool {
 	}
 
 	if !match {
-		ctx.ErrorMessage = "invalid value specified"
+		ctx.ErrorMessage = MsgEnum
 		if ctx.Options.ExposeEnumValues {
-			ctx.ErrorMessage += ". expected any of " + strings.Join(ctx.Args, ",")
+			ctx.ErrorMessage += fmt.Sprintf(MsgEnumValues, strings.Join(ctx.Args, ","))
 		}
 	}
 
 	return match
 }
 
-// IsMin tests if the given input (string, integer, list) contains at least the given number of elements
-func IsMin(ctx *ValidationContext) bool {
-	ctx.ValueMustBeOfKind(
-		reflect.Int,
-		reflect.Int8,
-		reflect.Int16,
-		reflect.Int32,
-		reflect.Int64,
-		reflect.Uint,
-		reflect.Uint8,
-		reflect.Uint16,
-		reflect.Uint32,
-		reflect.Uint64,
-		reflect.String,
-	)
+// IsEnumField tests if the input value is among the elements of the slice field named by the argument, e.g.
+// enum_field(Options) for options provided in the same payload. Strings and integers are compared by their string
+// representation, so integer values may be checked against string options and vice versa.
+//
+// Nil values pass. When the field is nil or empty, empty values pass and any other value fails with MsgEnumFieldEmpty.
+func IsEnumField(ctx *ValidationContext) bool {
+	if ctx.ArgCount() != 1 {
+		panic(newValidationError("enum_field: expected the name of the field holding the options"))
+	}
+	name := ctx.Args[0]
+
+	options, ok := ctx.SiblingValues(name)
+	if !ok {
+		ctx.ErrorMessage = fmt.Sprintf(MsgFieldNotFound, name)
+		return false
+	}
 
+	if ctx.IsNull {
+		return true
+	}
+
+	var value string
+	if ctx.IsValueOfKind(signedIntegerKinds...) {
+		value = strconv.FormatInt(ctx.GetValue().Int(), 10)
+	} else if ctx.IsValueOfKind(unsignedIntegerKinds...) {
+		value = strconv.FormatUint(ctx.GetValue().Uint(), 10)
+	} else if ctx.IsValueOfKind(reflect.String) {
+		value = ctx.GetValue().String()
+	} else {
+		panic(newValidationError("enum_field: unsupported type " + ctx.valueKind.String()))
+	}
+
+	if len(options) == 0 {
+		if isEmptyValue(ctx.GetValue()) {
+			return true
+		}
+		ctx.ErrorMessage = fmt.Sprintf(MsgEnumFieldEmpty, name)
+		return false
+	}
+
+	if !slices.Contains(options, value) {
+		ctx.ErrorMessage = MsgEnumField
+		if ctx.Options.ExposeEnumValues {
+			ctx.ErrorMessage += fmt.Sprintf(MsgEnumValues, strings.Join(options, ","))
+		}
+		return false
+	}
+	return true
+}
+
+// enumName returns the name of the integer input value as returned by its String method, if its type implements
+// fmt.Stringer and the enum arguments are names rather than integers
+func enumName(ctx *ValidationContext) (string, bool) {
+	if !ctx.IsValueOfKind(integerKinds...) {
+		return "", false
+	}
+	names := false
+	for _, arg := range ctx.Args {
+		_, signedErr := strconv.ParseInt(arg, 10, 64)
+		_, unsignedErr := strconv.ParseUint(arg, 10, 64)
+		if signedErr != nil && unsignedErr != nil {
+			names = true
+			break
+		}
+	}
+	if !names {
+		return "", false
+	}
+
+	value := ctx.GetValue()
+	if value.CanInterface() {
+		if stringer, ok := value.Interface().(fmt.Stringer); ok {
+			return stringer.String(), true
+		}
+	}
+	if value.CanAddr() && value.Addr().CanInterface() {
+		if stringer, ok := value.Addr().Interface().(fmt.Stringer); ok {
+			return stringer.String(), true
+		}
+	}
+	return "", false
+}
+
+// normalizeEnumArguments returns the arguments of an enum validator of values of the given type, trimmed unless they
+// were quoted, as reported by quoted, and, for integer types, parsed and formatted again so that e.g. 01 and +1 match
+// 1. Arguments that cannot be parsed as values of the type are rejected, unless the type implements fmt.Stringer and
+// the arguments are names, see enumName. Duplicate values are dropped, or rejected if strict is set, see
+// ValidationOptions.StrictTagParsing.
+func normalizeEnumArguments(args []string, quoted []bool, t reflect.Type, strict bool) ([]string, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	normalized := make([]string, 0, len(args))
+	for i, arg := range args {
+		// quoted arguments are kept as is, e.g. enum(' ') matching a single space
+		if i < len(quoted) && quoted[i] {
+			normalized = append(normalized, arg)
+			continue
+		}
+		normalized = append(normalized, strings.TrimSpace(arg))
+	}
+
+	signed, unsigned := slices.Contains(signedIntegerKinds, t.Kind()), slices.Contains(unsignedIntegerKinds, t.Kind())
+	if (signed || unsigned) && implements(t, stringerInterface) {
+		for _, arg := range normalized {
+			_, signedErr := strconv.ParseInt(arg, 10, 64)
+			_, unsignedErr := strconv.ParseUint(arg, 10, 64)
+			if signedErr != nil && unsignedErr != nil {
+				signed, unsigned = false, false
+				break
+			}
+		}
+	}
+	for i, arg := range normalized {
+		switch {
+		case signed:
+			value, err := strconv.ParseInt(arg, 10, t.Bits())
+			if err != nil {
+				return nil, errors.New("`" + arg + "` is not a valid " + t.Kind().String() + " value")
+			}
+			normalized[i] = strconv.FormatInt(value, 10)
+		case unsigned:
+			value, err := strconv.ParseUint(strings.TrimPrefix(arg, "+"), 10, t.Bits())
+			if err != nil {
+				return nil, errors.New("`" + arg + "` is not a valid " + t.Kind().String() + " value")
+			}
+			normalized[i] = strconv.FormatUint(value, 10)
+		}
+	}
+
+	seen := make(map[string]bool, len(normalized))
+	unique := normalized[:0]
+	for i, arg := range normalized {
+		if seen[arg] {
+			if strict {
+				return nil, errors.New("duplicate value `" + args[i] + "`, same as `" + arg + "`")
+			}
+			continue
+		}
+		seen[arg] = true
+		unique = append(unique, arg)
+	}
+	return unique, nil
+}
+
+// minMaxKinds returns the kinds accepted by min and max, the same for both.
+//
+// Strings are only accepted (and measured by length) when ValidationOptions.LegacyMinMaxStringLength is set.
+func minMaxKinds(ctx *ValidationContext) []reflect.Kind {
+	kinds := append(append([]reflect.Kind{}, signedIntegerKinds...), unsignedIntegerKinds...)
+	kinds = append(kinds, reflect.Slice, reflect.Array, reflect.Map)
+	if ctx.Options.LegacyMinMaxStringLength {
+		kinds = append(kinds, reflect.String)
+	}
+	return kinds
+}
+
+// IsMin tests if the given integer is at least the argument, or if the given slice, array or map contains at least
+// the given number of items, nil slices and maps containing none. Values of types with a numeric adapter, such as
+// math/big.Int, are compared by the adapter, see Validator.RegisterNumericAdapter
+func IsMin(ctx *ValidationContext) bool {
 	if ctx.ArgCount() == 0 {
 		panic(newValidationError("min: expected length or size parameter"))
 	}
 
+	// types such as math/big.Int are compared by their adapter, see Validator.RegisterNumericAdapter
+	if match, adapted := checkNumericBound(ctx, MsgMin, func(result int) bool { return result >= 0 }); adapted {
+		return match
+	}
+
+	ctx.ValueMustBeOfKind(minMaxKinds(ctx)...)
+
 	if ctx.IsNull {
 		return true
 	}
@@ -241,46 +703,48 @@ func IsMin(ctx *ValidationContext) bool {
 	propertyName := "value"
 	var expected int64 = ctx.MustGetIntArg(0)
 
+	if ctx.IsValueOfKind(reflect.Slice, reflect.Array, reflect.Map) {
+		if match = int64(ctx.GetValue().Len()) >= expected; !match {
+			ctx.ErrorMessage = fmt.Sprintf(MsgMinItems, ctx.Args[0])
+		}
+		return match
+	}
+
 	if ctx.IsValueOfKind(reflect.String) {
-		actual := len(ctx.GetValue().String())
+		actual := stringLength(ctx.GetValue().String(), ctx.Options.LengthInBytes)
 		match = int64(actual) >= expected
 		propertyName = "length"
-	} else if ctx.IsValueOfKind(reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64) {
+	} else if ctx.IsValueOfKind(signedIntegerKinds...) {
 		actual := ctx.GetValue().Int()
 		match = actual >= expected
-	} else if ctx.IsValueOfKind(reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64) {
+	} else if ctx.IsValueOfKind(unsignedIntegerKinds...) {
 		expected := ctx.MustGetUintArg(0)
 		actual := ctx.GetValue().Uint()
 		match = actual >= expected
 	}
 
 	if !match {
-		ctx.ErrorMessage = fmt.Sprintf("%s (%v) must be at least %v", propertyName, ctx.GetValue(), ctx.Args[0])
+		ctx.ErrorMessage = fmt.Sprintf(MsgMin, propertyName, ctx.Redact(ctx.GetValue()), ctx.Args[0])
 	}
 
 	return match
 }
 
-// IsMax tests if the given input (string, integer, list) contains at least the given number of elements
+// IsMax tests if the given integer does not exceed the argument, or if the given slice, array or map contains at most
+// the given number of items. Values of types with a numeric adapter, such as math/big.Int, are compared by the adapter,
+// see Validator.RegisterNumericAdapter
 func IsMax(ctx *ValidationContext) bool {
-	ctx.ValueMustBeOfKind(
-		reflect.Int,
-		reflect.Int8,
-		reflect.Int16,
-		reflect.Int32,
-		reflect.Int64,
-		reflect.Uint,
-		reflect.Uint8,
-		reflect.Uint16,
-		reflect.Uint32,
-		reflect.Uint64,
-		reflect.String,
-	)
-
 	if ctx.ArgCount() == 0 {
 		panic(newValidationError("max: expected length or size parameter"))
 	}
 
+	// types such as math/big.Int are compared by their adapter, see Validator.RegisterNumericAdapter
+	if match, adapted := checkNumericBound(ctx, MsgMax, func(result int) bool { return result <= 0 }); adapted {
+		return match
+	}
+
+	ctx.ValueMustBeOfKind(minMaxKinds(ctx)...)
+
 	if ctx.IsNull {
 		return true
 	}
@@ -289,26 +753,96 @@ func IsMax(ctx *ValidationContext) bool {
 	propertyName := "value"
 	var expected int64 = ctx.MustGetIntArg(0)
 
+	if ctx.IsValueOfKind(reflect.Slice, reflect.Array, reflect.Map) {
+		if match = int64(ctx.GetValue().Len()) <= expected; !match {
+			ctx.ErrorMessage = fmt.Sprintf(MsgMaxItems, ctx.Args[0])
+		}
+		return match
+	}
+
 	if ctx.IsValueOfKind(reflect.String) {
-		actual := len(ctx.GetValue().String())
+		actual := stringLength(ctx.GetValue().String(), ctx.Options.LengthInBytes)
 		match = int64(actual) <= expected
 		propertyName = "length"
-	} else if ctx.IsValueOfKind(reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64) {
+	} else if ctx.IsValueOfKind(signedIntegerKinds...) {
 		actual := ctx.GetValue().Int()
 		match = actual <= expected
-	} else if ctx.IsValueOfKind(reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64) {
+	} else if ctx.IsValueOfKind(unsignedIntegerKinds...) {
 		expected := ctx.MustGetUintArg(0)
 		actual := ctx.GetValue().Uint()
 		match = actual <= expected
 	}
 
 	if !match {
-		ctx.ErrorMessage = fmt.Sprintf("%s (%v) must not exceed %v", propertyName, ctx.GetValue(), ctx.Args[0])
+		ctx.ErrorMessage = fmt.Sprintf(MsgMax, propertyName, ctx.Redact(ctx.GetValue()), ctx.Args[0])
 	}
 
 	return match
 }
 
+// stringLength returns the number of runes in the given string, or the number of bytes if inBytes is set.
+//
+// Runes are not grapheme clusters: an emoji made up of several code points (e.g. a flag or a skin tone
+// modifier sequence) counts as several runes.
+func stringLength(s string, inBytes bool) int {
+	if inBytes {
+		return len(s)
+	}
+	return utf8.RuneCountInString(s)
+}
+
+// IsLength tests if the length of the given input (string, slice, array, map) falls within the given bounds.
+//
+// Either bound may be omitted using '_', e.g. length(_,80) or length(2,_). String lengths are measured in runes
+// unless ValidationOptions.LengthInBytes is set or the optional third argument 'bytes' is specified, e.g. length(_,255,bytes).
+func IsLength(ctx *ValidationContext) bool {
+	if ctx.ArgCount() != 2 && ctx.ArgCount() != 3 {
+		panic(newValidationError("length: expected minimum and maximum length parameters"))
+	}
+
+	inBytes := ctx.Options.LengthInBytes
+	if ctx.ArgCount() == 3 {
+		if ctx.Args[2] != "bytes" {
+			panic(newValidationError("length: unknown argument " + ctx.Args[2]))
+		}
+		inBytes = true
+	}
+
+	if ctx.IsNull {
+		return true
+	}
+
+	var actual int
+	value := ctx.GetValue()
+
+	switch value.Kind() {
+	case reflect.String:
+		actual = stringLength(value.String(), inBytes)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		actual = value.Len()
+	default:
+		panic(newValidationError("length: unsupported type " + value.Kind().String()))
+	}
+
+	if ctx.Args[0] != "_" {
+		expected := ctx.MustGetIntArg(0)
+		if int64(actual) < expected {
+			ctx.ErrorMessage = fmt.Sprintf(MsgLengthTooShort, actual, expected)
+			return false
+		}
+	}
+
+	if ctx.Args[1] != "_" {
+		expected := ctx.MustGetIntArg(1)
+		if int64(actual) > expected {
+			ctx.ErrorMessage = fmt.Sprintf(MsgLengthTooLong, actual, expected)
+			return false
+		}
+	}
+
+	return true
+}
+
 // IsAlphaNumeric verifies that the given string is alphanumeric
 func IsAlphaNumeric(ctx *ValidationContext) bool {
 	ctx.ValueMustBeOfKind(reflect.String)
@@ -323,25 +857,76 @@ func IsAlphaNumeric(ctx *ValidationContext) bool {
 		panic(newValidationError("regex error when validating input", err))
 	}
 	if !m {
-		ctx.ErrorMessage = "must be alphanumeric"
+		ctx.ErrorMessage = MsgAlphaNumeric
 	}
 	return m
 }
 
-// IsRequired check if the required field has values.
+// IsRequired tests if the input value is present: pointers must not be nil and fixed size arrays, such as [16]byte,
+// must not be all zero. Other values, such as empty strings, pass and can subsequently be validated appropriately.
 //
-// For literal values, the function always returns true because the values are present and can subsequnetly
-// be validated appropriately.
-//
-// For pointer types, the function will return false if the pointer is null or true if the pointer is not null
+// With ValidationOptions.RequiredRejectsZeroValues, empty strings, empty or nil slices and maps, and zero structs such
+// as a zero time.Time fail as well, pointers being dereferenced. Numbers and booleans still pass.
 func IsRequired(ctx *ValidationContext) bool {
 	if ctx.IsNull {
-		ctx.ErrorMessage = "this field is requiredd"
+		ctx.ErrorMessage = legacyMessage(ctx.Options, MsgRequired, MsgRequiredLegacy)
+		ctx.ErrorCode = CodeMissing
+		return false
+	}
+	if ctx.IsValueOfKind(reflect.Array) && ctx.GetValue().IsZero() {
+		ctx.ErrorMessage = legacyMessage(ctx.Options, MsgEmpty, MsgRequiredLegacy)
+		ctx.ErrorCode = CodeEmpty
+		return false
+	}
+	if ctx.Options.RequiredRejectsZeroValues && isBlankValue(ctx.GetValue()) {
+		ctx.ErrorCode = absenceCode(ctx)
+		ctx.ErrorMessage = legacyMessage(ctx.Options, MsgEmpty, MsgRequiredLegacy)
+		if ctx.ErrorCode == CodeMissing {
+			ctx.ErrorMessage = legacyMessage(ctx.Options, MsgRequired, MsgRequiredLegacy)
+		}
+		return false
+	}
+	return true
+}
+
+// isBlankValue tests whether the given value is an empty string, slice or map, or a zero struct, see
+// ValidationOptions.RequiredRejectsZeroValues
+func isBlankValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Struct:
+		return value.IsZero()
+	}
+	return false
+}
+
+// IsNonZero tests if the input value differs from the zero value of its type, e.g. 0, "", or an all zero array.
+// Nil pointers are rejected.
+func IsNonZero(ctx *ValidationContext) bool {
+	if ctx.IsNull || ctx.GetValue().IsZero() {
+		ctx.ErrorMessage = MsgNonZero
+		ctx.ErrorCode = absenceCode(ctx)
 		return false
 	}
 	return true
 }
 
+// absenceCode returns the code of a value failing a requirement: CodeMissing for values that were not provided, that
+// is nil pointers, interfaces, slices and maps, and CodeEmpty for other values
+func absenceCode(ctx *ValidationContext) string {
+	if ctx.IsNull {
+		return CodeMissing
+	}
+	switch value := ctx.GetValue(); value.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return CodeMissing
+		}
+	}
+	return CodeEmpty
+}
+
 func uuidFn(ctx *ValidationContext, version int) bool {
 	ctx.ValueMustBeOfKind(reflect.String)
 
@@ -351,12 +936,12 @@ func uuidFn(ctx *ValidationContext, version int) bool {
 
 	id, err := uuid.Parse(ctx.GetValue().String())
 	if err != nil {
-		ctx.ErrorMessage = "invalid uuid format"
+		ctx.ErrorMessage = MsgUUIDFormat
 		return false
 	}
 	match := id.Version() == uuid.Version(version)
 	if !match {
-		ctx.ErrorMessage = fmt.Sprintf("expectedd UUIDv%d but found UUIDv%d", version, int(id.Version()))
+		ctx.ErrorMessage = fmt.Sprintf(legacyMessage(ctx.Options, MsgUUIDVersionMismatch, MsgUUIDVersionMismatchLegacy), version, int(id.Version()))
 	}
 	return match
 }
@@ -377,24 +962,82 @@ func IsUuid4(ctx *ValidationContext) bool {
 	return uuidFn(ctx, 4)
 }
 
+// IsUuidCanonical tests if the input value is a UUID string in its canonical form: 36 characters of lowercase
+// hexadecimal digits and hyphens, e.g. 6ba7b810-9dad-11d1-80b4-00c04fd430c8. Any version is accepted.
+//
+// Use the canonical_uuid filter to normalize UUIDs instead of rejecting them.
+func IsUuidCanonical(ctx *ValidationContext) bool {
+	ctx.ValueMustBeOfKind(reflect.String)
+
+	if ctx.IsNull {
+		return true
+	}
+
+	value := ctx.GetValue().String()
+	id, err := uuid.Parse(value)
+	if err != nil {
+		ctx.ErrorMessage = MsgUUIDFormat
+		return false
+	}
+	if value != id.String() {
+		ctx.ErrorMessage = MsgUUIDCanonical
+		return false
+	}
+	return true
+}
+
+// IsUuidBytes tests if the input value is a UUID in its 16 byte array form, such as [16]byte or uuid.UUID.
+//
+// The nil UUID (all zero bytes) is only rejected when the argument 'nonzero' is specified, e.g. uuid_bytes(nonzero).
+func IsUuidBytes(ctx *ValidationContext) bool {
+	ctx.ValueMustBeOfKind(reflect.Array)
+	if ctx.ElementKind() != reflect.Uint8 || ctx.ValueType.Len() != 16 {
+		panic(newValidationError("unexpected type found: " + ctx.ValueType.String()))
+	}
+
+	if ctx.IsNull {
+		return true
+	}
+
+	if ctx.ArgCount() == 1 && ctx.Args[0] == "nonzero" && ctx.GetValue().IsZero() {
+		ctx.ErrorMessage = MsgNilUUID
+		return false
+	}
+	return true
+}
+
 var filterFunctions = map[string]FilterFunction{
-	"trim":          Trim,
-	"null_if_empty": NullIfEmpty,
+	"trim":           Trim,
+	"null_if_empty":  NullIfEmpty,
+	"canonical_uuid": CanonicalUuid,
 }
 
+// Trim Trim removes leading and trailing whitespace from strings, string pointers and defined string types, returning
+// the trimmed value so that it can be composed, e.g. Trim(ctx).String() in a custom filter of string fields.
 func Trim(ctx *ValidationContext) reflect.Value {
 	ctx.ValueMustBeOfKind(reflect.String)
 
-	if ctx.IsPointer && !ctx.IsNull {
-		value := ctx.GetValue().String()
-		trimmed := strings.TrimSpace(value)
-		return reflect.ValueOf(&trimmed)
-	} else {
-		if ctx.IsNull {
-			return ctx.value
-		}
-		return reflect.ValueOf(strings.TrimSpace(ctx.GetValue().String()))
+	if !ctx.IsNull {
+		ctx.SetValue(strings.TrimSpace(ctx.GetValue().String()))
+	}
+	return ctx.value
+}
+
+// CanonicalUuid CanonicalUuid converts UUID strings into their canonical form, lowercase and hyphenated, e.g.
+// {6BA7B810-9DAD-11D1-80B4-00C04FD430C8} becomes 6ba7b810-9dad-11d1-80b4-00c04fd430c8. Other values are left as is.
+func CanonicalUuid(ctx *ValidationContext) reflect.Value {
+	ctx.ValueMustBeOfKind(reflect.String)
+
+	if ctx.IsNull {
+		return ctx.value
+	}
+
+	id, err := uuid.Parse(ctx.GetValue().String())
+	if err != nil {
+		return ctx.value
 	}
+	ctx.SetValue(id.String())
+	return ctx.value
 }
 
 // NullIfEmpty Sets the given string pointer's value to null if the string is empty
@@ -412,5 +1055,5 @@ func NullIfEmpty(ctx *ValidationContext) reflect.Value {
 			return reflect.ValueOf(nullString)
 		}
 	}
-	return ctx.GetValue()
+	return ctx.value
 }
@@ -0,0 +1,38 @@
+package validator
+
+import "strings"
+
+// ValidateT ValidateT validates the given struct, providing compile time assurance that a pointer is passed.
+//
+// T must be a struct type. Other types result in ValidationResult.Error being set.
+func ValidateT[T any](v *T, trigger ...string) *ValidationResult {
+	return Validate(v, trigger...)
+}
+
+// MustValidateT MustValidateT validates the given struct and returns it, panicking with a *ValidationError
+// describing the problems if validation fails.
+//
+// Useful for fluent use in tests and when loading configuration.
+func MustValidateT[T any](v *T, trigger ...string) *T {
+	res := ValidateT(v, trigger...)
+	if !res.IsValid() {
+		panic(res.asError())
+	}
+	return v
+}
+
+// asError summarizes the result into a single error
+func (r ValidationResult) asError() *ValidationError {
+	if r.Error != nil && len(r.FieldErrors) == 0 {
+		return r.Error
+	}
+	messages := make([]string, len(r.FieldErrors))
+	for i, fe := range r.FieldErrors {
+		messages[i] = fe.Error()
+	}
+	ve := newValidationError("validation failed: " + strings.Join(messages, "; "))
+	if r.Error != nil {
+		ve.ErrorDelegate = r.Error
+	}
+	return ve
+}
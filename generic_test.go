@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type exampleSignup struct {
+	Email string `validator:"email"`
+	Age   int    `validator:"min(18)"`
+}
+
+func ExampleValidateT() {
+	signup := exampleSignup{Email: "john@example.com", Age: 16}
+
+	res := ValidateT(&signup)
+	fmt.Println(res.IsValid())
+	fmt.Println(res.FieldErrors[0])
+	// Output:
+	// false
+	// Age: value (16) must be at least 18
+}
+
+func ExampleMustValidateT() {
+	signup := MustValidateT(&exampleSignup{Email: "john@example.com", Age: 21})
+	fmt.Println(signup.Age)
+	// Output:
+	// 21
+}
+
+func TestValidateT(t *testing.T) {
+	res := ValidateT(&exampleSignup{Email: "john@example.com", Age: 18})
+	assertTrue(t, res.IsValid(), "validation failed")
+
+	number := 10
+	res = ValidateT(&number)
+	assertEqual(t, "Invalid input type. Expected struct pointer but found *int", res.Error.Error())
+
+	assert.PanicsWithError(t, "validation failed: Age: value (16) must be at least 18", func() {
+		MustValidateT(&exampleSignup{Email: "john@example.com", Age: 16})
+	})
+}
@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// MustValidate MustValidate validates the given struct using this instance and panics with a *ValidationError if
+// validation fails. The message of the error has one line per field error, e.g.
+//
+//	validation of main.Config failed:
+//	  Port: value (0) must be at least 1
+//	  Name: this field is required
+//
+// It is meant for situations where invalid input is a programming or deployment error, such as loading configuration
+// or setting up tests. See Validate for details about the parameters.
+func (v *Validator) MustValidate(structPtr interface{}, trigger ...string) {
+	res := v.Validate(structPtr, trigger...)
+	if res.IsValid() {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("validation of " + structName(structPtr) + " failed:")
+	if res.Error != nil {
+		sb.WriteString("\n  " + res.Error.Error())
+	}
+	for _, fe := range res.FieldErrors {
+		sb.WriteString("\n  " + fe.Error())
+	}
+	panic(newValidationError(sb.String()))
+}
+
+// MustValidate MustValidate validates the given struct using the default instance and panics with a *ValidationError
+// if validation fails.
+//
+// See Validator.MustValidate for details.
+func MustValidate(structPtr interface{}, trigger ...string) {
+	defaultValidator.MustValidate(structPtr, trigger...)
+}
+
+// ValidateAndLog ValidateAndLog validates the given struct using this instance and logs failures to the given logger,
+// or to slog.Default() if nil. The result is returned as is.
+//
+// Each field error is logged at warning level with the attributes struct, field, validator and message. The top
+// level error of the result, if any, is logged at error level with the attributes struct and error.
+func (v *Validator) ValidateAndLog(logger *slog.Logger, structPtr interface{}, trigger ...string) *ValidationResult {
+	res := v.Validate(structPtr, trigger...)
+	if res.IsValid() {
+		return res
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+	name := structName(structPtr)
+	if res.Error != nil {
+		logger.Error("validation error", slog.String("struct", name), slog.String("error", res.Error.Error()))
+	}
+	for _, fe := range res.FieldErrors {
+		logger.Warn("validation failed",
+			slog.String("struct", name),
+			slog.String("field", fe.Field),
+			slog.String("validator", fe.Validator),
+			slog.String("message", fe.Message),
+		)
+	}
+	return res
+}
+
+// ValidateAndLog ValidateAndLog validates the given struct using the default instance and logs failures to the given
+// logger, or to slog.Default() if nil.
+//
+// See Validator.ValidateAndLog for details.
+func ValidateAndLog(logger *slog.Logger, structPtr interface{}, trigger ...string) *ValidationResult {
+	return defaultValidator.ValidateAndLog(logger, structPtr, trigger...)
+}
+
+// structName returns the name of the type of the given struct (pointer), e.g. main.Config
+func structName(structPtr interface{}) string {
+	t := reflect.TypeOf(structPtr)
+	if t == nil {
+		return "<nil>"
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.String()
+}
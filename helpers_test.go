@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type serverConfig struct {
+	Host string `validator:"length(1,_)"`
+	Port int    `validator:"min(1)|max(65535)"`
+}
+
+func TestMustValidate(t *testing.T) {
+	v := New()
+
+	assert.NotPanics(t, func() {
+		v.MustValidate(&serverConfig{Host: "localhost", Port: 8080})
+	})
+
+	assert.PanicsWithError(t, "validation of validator.serverConfig failed:\n"+
+		"  Host: length (0) must be at least 1\n"+
+		"  Port: value (0) must be at least 1", func() {
+		v.MustValidate(&serverConfig{})
+	})
+
+	assert.PanicsWithError(t, "validation of int failed:\n"+
+		"  Invalid input type. Expected struct pointer but found *int", func() {
+		v.MustValidate(new(int))
+	})
+}
+
+func TestValidateAndLog(t *testing.T) {
+	v := New()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	res := v.ValidateAndLog(logger, &serverConfig{Host: "localhost", Port: 8080})
+	assertTrue(t, res.IsValid(), "Validation failed")
+	assertEqual(t, 0, buf.Len())
+
+	res = v.ValidateAndLog(logger, &serverConfig{Port: 70000})
+	assertFalse(t, res.IsValid(), "Validation failed")
+	assertEqual(t, 2, len(res.FieldErrors))
+
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var record map[string]interface{}
+		assert.NoError(t, decoder.Decode(&record))
+		delete(record, "time")
+		records = append(records, record)
+	}
+	assertEqual(t, []map[string]interface{}{
+		{
+			"level": "WARN", "msg": "validation failed", "struct": "validator.serverConfig",
+			"field": "Host", "validator": "length", "message": "length (0) must be at least 1",
+		},
+		{
+			"level": "WARN", "msg": "validation failed", "struct": "validator.serverConfig",
+			"field": "Port", "validator": "max", "message": "value (70000) must not exceed 65535",
+		},
+	}, records)
+}
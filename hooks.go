@@ -0,0 +1,84 @@
+package validator
+
+// BeforeValidator is implemented by structs preparing their data before validation, e.g. applying default values.
+//
+// BeforeValidate is called on the validated struct before any validator or filter runs, with the active triggers,
+// comma separated (e.g. "update,audit").
+type BeforeValidator interface {
+	BeforeValidate(trigger string)
+}
+
+// AfterValidator is implemented by structs reacting to the outcome of their validation, e.g. emitting audit events.
+//
+// AfterValidate is called on the validated struct once validation, including struct level validation, has completed.
+// The result is a copy: modifying it does not change the result returned by Validate.
+type AfterValidator interface {
+	AfterValidate(result *ValidationResult)
+}
+
+// Hooks Hooks are called for every struct validated by an instance, for cross-cutting concerns such as metrics.
+//
+// The global Before hook runs before the BeforeValidate method of the struct and the global After hook runs after its
+// AfterValidate method. Hooks are not called when the input is not a struct (pointer).
+type Hooks struct {
+	// Before Before is called before validation with the validated struct (pointer) and the active triggers,
+	// comma separated
+	Before func(structPtr interface{}, trigger string)
+	// After After is called after validation with the validated struct (pointer), the active triggers (comma separated)
+	// and a copy of the result
+	After func(structPtr interface{}, trigger string, result *ValidationResult)
+}
+
+// SetHooks SetHooks replaces the hooks called for every struct validated by this instance.
+func (v *Validator) SetHooks(hooks Hooks) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.hooks = hooks
+}
+
+// SetHooks SetHooks replaces the hooks called for every struct validated by the default instance.
+func SetHooks(hooks Hooks) {
+	defaultValidator.SetHooks(hooks)
+}
+
+// beforeValidate calls the global Before hook followed by the BeforeValidate method of the struct
+func (v *Validator) beforeValidate(structPtr interface{}, trigger string) {
+	v.mu.RLock()
+	before := v.hooks.Before
+	v.mu.RUnlock()
+
+	if before != nil {
+		before(structPtr, trigger)
+	}
+	if s, ok := structPtr.(BeforeValidator); ok {
+		s.BeforeValidate(trigger)
+	}
+}
+
+// afterValidate calls the AfterValidate method of the struct followed by the global After hook, passing each a copy
+// of the result
+func (v *Validator) afterValidate(structPtr interface{}, trigger string, res *ValidationResult) {
+	v.mu.RLock()
+	after := v.hooks.After
+	v.mu.RUnlock()
+
+	if s, ok := structPtr.(AfterValidator); ok {
+		s.AfterValidate(res.copy())
+	}
+	if after != nil {
+		after(structPtr, trigger, res.copy())
+	}
+}
+
+// copy returns a copy of the result which does not share field errors or sampled indexes with the original
+func (r *ValidationResult) copy() *ValidationResult {
+	c := *r
+	c.FieldErrors = append([]FieldError(nil), r.FieldErrors...)
+	if r.SampledIndexes != nil {
+		c.SampledIndexes = make(map[string][]int, len(r.SampledIndexes))
+		for path, indexes := range r.SampledIndexes {
+			c.SampledIndexes[path] = append([]int(nil), indexes...)
+		}
+	}
+	return &c
+}
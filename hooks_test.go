@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type hookedAddress struct {
+	Country string `validator:"checked" filter:"tracked"`
+	log     *[]string
+}
+
+func (a *hookedAddress) BeforeValidate(trigger string) {
+	*a.log = append(*a.log, "before:"+trigger)
+	if a.Country == "" {
+		a.Country = "US"
+	}
+}
+
+func (a *hookedAddress) ValidateStruct(sl StructLevel) {
+	*a.log = append(*a.log, "struct level")
+	sl.AddFieldError("Country", "unsupported country")
+}
+
+func (a *hookedAddress) AfterValidate(result *ValidationResult) {
+	*a.log = append(*a.log, "after:"+result.FieldErrors[0].Message)
+	result.FieldErrors = nil
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	var log []string
+
+	v := New()
+	v.AddValidator("checked", func(ctx *ValidationContext) bool {
+		log = append(log, "validator:"+ctx.GetValue().String())
+		return true
+	})
+	v.AddFilter("tracked", func(ctx *ValidationContext) reflect.Value {
+		log = append(log, "filter")
+		return ctx.GetValue()
+	})
+	v.SetHooks(Hooks{
+		Before: func(structPtr interface{}, trigger string) {
+			log = append(log, "global before:"+trigger)
+		},
+		After: func(structPtr interface{}, trigger string, result *ValidationResult) {
+			log = append(log, "global after:"+trigger+":"+result.FieldErrors[0].Field)
+		},
+	})
+
+	address := hookedAddress{log: &log}
+	res := v.Validate(&address, "create")
+
+	assertEqual(t, []string{
+		"global before:create",
+		"before:create",
+		"filter",
+		"validator:US",
+		"struct level",
+		"after:unsupported country",
+		"global after:create:Country",
+	}, log)
+
+	// the hooks only received copies of the result
+	assertFalse(t, res.IsValid(), "expected validation to fail")
+	assertEqual(t, 1, len(res.FieldErrors))
+}
+
+func TestLifecycleHooksDefaultTrigger(t *testing.T) {
+	var triggers []string
+
+	v := New()
+	v.SetHooks(Hooks{
+		Before: func(structPtr interface{}, trigger string) {
+			triggers = append(triggers, trigger)
+		},
+	})
+
+	type Form struct {
+		Name string
+	}
+	v.Validate(&Form{})
+	v.Validate(Form{}, "update")
+	v.Validate(10)
+	assertEqual(t, []string{"all", "update"}, triggers)
+
+	v.SetHooks(Hooks{})
+	v.Validate(&Form{})
+	assertEqual(t, 2, len(triggers))
+}
@@ -0,0 +1,520 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Validator Validator is a validation engine with its own options, validation and filter functions, and struct cache.
+//
+// Instances are independent of each other, which allows different modules to register functions using the same
+// names or to use different options. The package level functions operate on a default instance.
+//
+// Instances must be created with New. Validation calls of the zero value, and of the instances its WithTrigger and
+// WithoutCache return, report invalid options through ValidationResult.Error.
+type Validator struct {
+	*engine
+	// triggers the activation triggers used when validation calls pass none, see WithTrigger
+	triggers []string
+	// noCache indicates that structs are parsed for every call, see WithoutCache
+	noCache bool
+}
+
+// engine holds the state of a Validator, shared by the instances returned by Validator.WithTrigger
+type engine struct {
+	options ValidationOptions
+	// mu guards options, validators, filters, rule sets, aliases, patterns, numeric adapters, type resolvers and hooks
+	mu         sync.RWMutex
+	validators map[string]ValidationFunction
+	filters    map[string]FilterFunction
+	ruleSets   map[string]string
+	aliases    map[string]string
+	patterns   map[string]*regexp.Regexp
+	// numericAdapters holds the adapters registered per type, see RegisterNumericAdapter
+	numericAdapters map[reflect.Type]NumericAdapter
+	// resolvers holds the type resolvers registered per type, see RegisterTypeResolver
+	resolvers map[reflect.Type]*typeResolver
+	// validatorInfo and filterInfo hold the information passed to AddValidatorWithInfo and AddFilterWithInfo
+	validatorInfo map[string]FunctionInfo
+	filterInfo    map[string]FunctionInfo
+	hooks         Hooks
+	cache         fieldCache
+	// varTypes holds the struct types built by ValidateVarNamed, see varStructType
+	varTypes sync.Map
+	// stats counts evaluations and failures of validators if enabled, see EnableStats
+	stats atomic.Pointer[validationStats]
+	// generation is incremented whenever validators, filters, rule sets, aliases, patterns, numeric adapters or type
+	// resolvers are added or replaced, invalidating cached structs parsed before
+	generation atomic.Uint64
+}
+
+// New New creates a validator using the default options and the packaged validation and filter functions.
+//
+// The given callbacks are applied to the options of the new instance in order.
+func New(opts ...func(*ValidationOptions)) *Validator {
+	v := &Validator{engine: &engine{
+		options:    defaultOptions(),
+		validators: make(map[string]ValidationFunction, len(validatorFunctions)),
+		filters:    make(map[string]FilterFunction, len(filterFunctions)),
+
+		numericAdapters: make(map[reflect.Type]NumericAdapter, len(numericAdapters)),
+		resolvers:       make(map[reflect.Type]*typeResolver),
+	}}
+	for t, adapter := range numericAdapters {
+		v.numericAdapters[t] = adapter
+	}
+	for name, fn := range validatorFunctions {
+		v.validators[name] = fn
+	}
+	for name, fn := range filterFunctions {
+		v.filters[name] = fn
+	}
+	for _, configCallback := range opts {
+		configCallback(&v.options)
+	}
+	return v
+}
+
+// WithTrigger WithTrigger returns a copy of this instance bound to the given activation triggers, which apply to
+// validation calls passing no trigger, e.g.
+//
+//	createValidator := validator.New().WithTrigger("create")
+//	res := createValidator.Validate(&req)           // validates fields active for "create"
+//	res = createValidator.Validate(&req, "update")  // explicit triggers take precedence
+//
+// The copy is shallow: options, functions, rule sets, hooks and the struct cache are shared with this instance, so
+// changing them through either instance affects both. Calling WithTrigger without triggers returns a copy using no
+// default trigger, which validates fields active for all triggers.
+func (v *Validator) WithTrigger(triggers ...string) *Validator {
+	return &Validator{engine: v.engine, triggers: append([]string(nil), triggers...), noCache: v.noCache}
+}
+
+// WithoutCache WithoutCache returns a copy of this instance which parses the tags of structs for every validation
+// call, neither reading nor populating the struct cache, e.g. for struct types built at runtime with
+// reflect.StructOf whose tags vary from call to call:
+//
+//	res := v.WithoutCache().Validate(tenantPayload)
+//
+// Like WithTrigger, the copy shares options, functions, rule sets and hooks with this instance. Parsing is repeated
+// for every call, so the copy is slower than this instance. See ParseStruct for managing parsed rules yourself.
+func (v *Validator) WithoutCache() *Validator {
+	return &Validator{engine: v.engine, triggers: v.triggers, noCache: true}
+}
+
+// SetupOptions SetupOptions allows you to configure the options of this instance.
+//
+// The callback receives a copy of the current options, which replaces them only if it passes ValidationOptions.Check.
+// Otherwise the error is returned and the current options are left unchanged.
+//
+// Options can be changed at any time and affect subsequent validation calls only: each call takes a copy of the
+// options when it starts and uses it throughout, so a concurrent call to SetupOptions never changes the behavior of a
+// validation in progress. Since parsed struct tags depend on the options, the struct cache of this instance is cleared
+// and structs are parsed again with the new options upon their next validation.
+func (v *Validator) SetupOptions(configCallback func(*ValidationOptions)) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	opts := v.options
+	configCallback(&opts)
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	v.options = opts
+	v.cache.Clear()
+	return nil
+}
+
+// CopyOptions CopyOptions Copies the options of this instance into the specified destination.
+func (v *Validator) CopyOptions(opts *ValidationOptions) {
+	*opts = v.currentOptions()
+}
+
+// currentOptions returns a copy of the options of this instance, unaffected by concurrent calls to SetupOptions
+func (v *Validator) currentOptions() ValidationOptions {
+	// the zero value has no engine, its zero options failing ValidationOptions.Check
+	if v.engine == nil {
+		return ValidationOptions{}
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.options
+}
+
+// ClearCache ClearCache removes all parsed struct information from the cache of this instance.
+func (v *Validator) ClearCache() {
+	v.cache.Clear()
+}
+
+// AddValidator adds the given validator function to the list of validators of this instance.
+//
+// The function is safe to call concurrently with validation. It will panic if the name already exists,
+// unless ValidationOptions.NoPanicOnFunctionConflict is set.
+func (v *Validator) AddValidator(name string, fn ValidationFunction) {
+	v.addValidator(name, fn, nil)
+}
+
+// addValidator adds the given validator function along with the given information, if any, see AddValidator
+func (v *Validator) addValidator(name string, fn ValidationFunction, info *FunctionInfo) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	_, exists := v.validators[name]
+	if _, alias := v.aliases[name]; alias {
+		exists = true
+	}
+	if exists && !v.options.NoPanicOnFunctionConflict {
+		panic(errors.New("a validator by the name of " + name + " already exists"))
+	}
+	delete(v.aliases, name)
+	v.validators[name] = fn
+	v.validatorInfo = storeFunctionInfo(v.validatorInfo, name, info)
+	v.generation.Add(1)
+}
+
+// AddFilter adds the given filter function to the list of filters of this instance.
+//
+// The function is safe to call concurrently with validation. It will panic if the name already exists,
+// unless ValidationOptions.NoPanicOnFunctionConflict is set.
+func (v *Validator) AddFilter(name string, fn FilterFunction) {
+	v.addFilter(name, fn, nil)
+}
+
+// addFilter adds the given filter function along with the given information, if any, see AddFilter
+func (v *Validator) addFilter(name string, fn FilterFunction, info *FunctionInfo) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	_, exists := v.filters[name]
+	if exists && !v.options.NoPanicOnFunctionConflict {
+		panic(errors.New("a filter by the name of " + name + " already exists"))
+	}
+	v.filters[name] = fn
+	v.filterInfo = storeFunctionInfo(v.filterInfo, name, info)
+	v.generation.Add(1)
+}
+
+// ReplaceValidator ReplaceValidator registers the given validator function under the given name, replacing the
+// validator or alias registered under the name, if any, e.g. to stub a validator performing I/O in tests or to swap a
+// packaged validator for another implementation.
+//
+// The function is safe to call concurrently with validation. Structs parsed before are parsed again upon their next
+// validation, so they use the new function.
+func (v *Validator) ReplaceValidator(name string, fn ValidationFunction) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.aliases, name)
+	delete(v.validatorInfo, name)
+	v.validators[name] = fn
+	v.generation.Add(1)
+}
+
+// RemoveValidator RemoveValidator removes the validator registered under the given name, if any. Structs
+// referencing it are then reported as having invalid tags upon their next validation.
+//
+// The function is safe to call concurrently with validation.
+func (v *Validator) RemoveValidator(name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.validators, name)
+	delete(v.validatorInfo, name)
+	v.generation.Add(1)
+}
+
+// ReplaceFilter ReplaceFilter registers the given filter function under the given name, replacing the filter
+// registered under the name, if any.
+//
+// The function is safe to call concurrently with validation. Structs parsed before are parsed again upon their next
+// validation, so they use the new function.
+func (v *Validator) ReplaceFilter(name string, fn FilterFunction) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.filterInfo, name)
+	v.filters[name] = fn
+	v.generation.Add(1)
+}
+
+// RemoveFilter RemoveFilter removes the filter registered under the given name, if any. Structs referencing it are
+// then reported as having invalid tags upon their next validation.
+//
+// The function is safe to call concurrently with validation.
+func (v *Validator) RemoveFilter(name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.filters, name)
+	delete(v.filterInfo, name)
+	v.generation.Add(1)
+}
+
+// lookupValidator returns the validator function registered under the given name
+func (v *Validator) lookupValidator(name string) (fn ValidationFunction, ok bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	fn, ok = v.validators[name]
+	return
+}
+
+// lookupFilter returns the filter function registered under the given name
+func (v *Validator) lookupFilter(name string) (fn FilterFunction, ok bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	fn, ok = v.filters[name]
+	return
+}
+
+// Validate validates the given struct using this instance.
+//
+// See Validate for details about the parameters.
+func (v *Validator) Validate(structPtr interface{}, trigger ...string) (res *ValidationResult) {
+	opts := v.currentOptions()
+	return v.validate(context.Background(), structPtr, &opts, trigger, nil)
+}
+
+// ValidateContext validates the given struct using this instance, passing the given context to validators and
+// filters through ValidationContext.Context.
+//
+// See ValidateContext for details.
+func (v *Validator) ValidateContext(ctx context.Context, structPtr interface{}, trigger ...string) (res *ValidationResult) {
+	opts := v.currentOptions()
+	return v.validate(ctx, structPtr, &opts, trigger, nil)
+}
+
+// ValidateWithOptions validates the given struct using this instance's functions and cache, but with the given options
+// instead of the options of this instance.
+//
+// See ValidateWithOptions for details.
+func (v *Validator) ValidateWithOptions(structPtr interface{}, opts ValidationOptions, trigger ...string) (res *ValidationResult) {
+	return v.validate(context.Background(), structPtr, &opts, trigger, nil)
+}
+
+func (v *Validator) validate(ctx context.Context, structPtr interface{}, opts *ValidationOptions, trigger []string, selection *fieldSelection) (res *ValidationResult) {
+	t := reflect.TypeOf(structPtr)
+	res = &ValidationResult{
+		valid: false,
+	}
+
+	if t == nil {
+		res.Error = newValidationError("Invalid input type. Expected struct pointer but found nil")
+		return
+	}
+
+	if err := opts.Check(); err != nil {
+		res.Error = err.(*ValidationError)
+		return
+	}
+	if v.engine == nil {
+		res.Error = newValidationError("Invalid validator. Instances must be created with New")
+		return
+	}
+
+	structValue := reflect.ValueOf(structPtr)
+	readOnly := t.Kind() == reflect.Struct
+
+	if !readOnly {
+		if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+			res.Error = newValidationError("Invalid input type. Expected struct pointer but found " + t.String())
+			return
+		}
+		if structValue.IsNil() {
+			res.Error = newValidationError("Invalid input type. Expected struct pointer but found nil " + t.String())
+			return
+		}
+		structValue = structValue.Elem()
+	}
+
+	// see WithTrigger
+	if len(trigger) == 0 {
+		trigger = v.triggers
+	}
+	triggers := activationTriggers(trigger)
+	activationTrigger := strings.Join(triggers, ",")
+
+	v.beforeValidate(structPtr, activationTrigger)
+
+	state := &validationState{engine: v.engine, ctx: ctx, opts: opts, triggers: triggers, res: res, readOnly: readOnly, root: structValue, selection: selection, stats: v.stats.Load(), overrides: validatorOverrides(ctx)}
+	if !readOnly {
+		state.visiting = map[visitedPointer]bool{{pointer: structValue.Addr().Pointer(), valueType: t}: true}
+	}
+	res.FieldErrors = v.validateStruct(state, structValue, "", 0)
+	if state.tagError {
+		res.FieldErrors = nil
+	}
+	if opts.DeduplicateFieldErrors {
+		res.FieldErrors = deduplicateFieldErrors(res.FieldErrors)
+	}
+
+	res.updateValidity()
+	if !res.valid && opts.SkipAllFiltersOnError {
+		// latest changes first, so that nested values are restored before the values containing them
+		for i := len(state.restores) - 1; i >= 0; i-- {
+			state.restores[i]()
+		}
+	}
+
+	v.afterValidate(structPtr, activationTrigger, res)
+
+	return
+}
+
+// structLevel is a struct type being traversed along with the index sequence leading to it from the root struct and
+// the index of the next field to parse
+type structLevel struct {
+	structType reflect.Type
+	index      []int
+	prefix     string
+	next       int
+}
+
+func (v *Validator) getStructFields(t reflect.Type, opts *ValidationOptions) ([]*fieldContext, error) {
+	generation := v.generation.Load()
+	if !v.noCache {
+		if contexts, ok := v.cache.Get(t, opts, generation); ok {
+			return contexts, nil
+		}
+	}
+
+	contexts, problems := v.parseStruct(t, opts)
+	if len(problems) > 0 {
+		return nil, problemsError(t, problems, opts)
+	}
+
+	// add to cache, see WithoutCache
+	if !v.noCache {
+		v.cache.Store(t, opts, generation, contexts)
+	}
+
+	return contexts, nil
+}
+
+// parseStruct parses the fields of the given struct type, including fields of embedded and nested struct values,
+// collecting all problems found.
+//
+// Fields are parsed in declaration order, the fields of embedded and nested struct values being parsed depth-first
+// at the position of the embedding field, which makes the order of field errors deterministic.
+func (v *Validator) parseStruct(t reflect.Type, opts *ValidationOptions) (contexts []*fieldContext, problems []*RuleProblem) {
+	stack := Stack{}
+	stack.Push(&structLevel{structType: t})
+	contexts = make([]*fieldContext, 0)
+
+	for !stack.IsEmpty() {
+		level := stack.Peek().(*structLevel)
+		if level.next == level.structType.NumField() {
+			stack.Pop()
+			continue
+		}
+		i := level.next
+		level.next++
+
+		field := level.structType.Field(i)
+		index := append(append([]int{}, level.index...), i)
+		if field.Type.Kind() == reflect.Struct && !v.isValueField(field, opts) {
+			// nested structs marked with `validator:"-"` are skipped altogether
+			if tag, ok, _ := lookupTag(field.Tag, opts.ValidatorTagName, opts.CaseInsensitiveTagNames); ok && isSkipMarker(tag) {
+				continue
+			}
+			prefix := level.prefix
+			if !field.Anonymous {
+				prefix += field.Name + "."
+			}
+			stack.Push(&structLevel{structType: field.Type, index: index, prefix: prefix})
+			continue
+		}
+		if opts.StrictTagParsing {
+			if strict := strictTagProblems(level.structType, field, opts); len(strict) > 0 {
+				problems = append(problems, strict...)
+				continue
+			}
+		}
+		fc, problem := v.parseField(level.structType, field, opts)
+		if problem != nil {
+			problems = append(problems, problem)
+			continue
+		}
+		if fc != nil {
+			fc.fieldIndex = index
+			fc.pathPrefix = level.prefix
+			contexts = append(contexts, fc)
+		}
+	}
+
+	return contexts, problems
+}
+
+// Register Register eagerly parses the tags of the given structs (or struct pointers) and of the structs nested
+// within them, populating the cache of this instance.
+//
+// All problems found are returned as a joined error. Registering the same struct again has no effect. Instances
+// returned by WithoutCache only check the structs.
+func (v *Validator) Register(structs ...interface{}) error {
+	var errs []error
+	types, invalid := structTypes(structs)
+	for _, s := range invalid {
+		errs = append(errs, newValidationError("cannot register "+fmt.Sprintf("%T", s)+": expected struct or struct pointer"))
+	}
+
+	opts := v.currentOptions()
+	generation := v.generation.Load()
+	walkStructTypes(types, func(t reflect.Type) []*fieldContext {
+		contexts, ok := v.cache.Get(t, &opts, generation)
+		if !ok {
+			var problems []*RuleProblem
+			contexts, problems = v.parseStruct(t, &opts)
+			if len(problems) > 0 {
+				for _, problem := range problems {
+					errs = append(errs, problem)
+				}
+			} else if !v.noCache {
+				v.cache.Store(t, &opts, generation, contexts)
+			}
+		}
+		return contexts
+	})
+
+	return errors.Join(errs...)
+}
+
+// structTypes resolves the struct types of the given structs or struct pointers, returning the values that are neither
+func structTypes(structs []interface{}) (types []reflect.Type, invalid []interface{}) {
+	for _, s := range structs {
+		t := reflect.TypeOf(s)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			invalid = append(invalid, s)
+			continue
+		}
+		types = append(types, t)
+	}
+	return
+}
+
+// walkStructTypes calls parse once for each of the given struct types and for each struct type nested within the
+// fields returned by parse
+func walkStructTypes(types []reflect.Type, parse func(t reflect.Type) []*fieldContext) {
+	visited := make(map[reflect.Type]bool)
+	for len(types) > 0 {
+		t := types[0]
+		types = types[1:]
+		if visited[t] {
+			continue
+		}
+		visited[t] = true
+
+		for _, fc := range parse(t) {
+			// struct types stored in interface fields are only known at runtime
+			if fc.nested && fc.fieldKind != reflect.Interface {
+				types = append(types, innerStructType(fc.fieldType))
+			}
+		}
+	}
+}
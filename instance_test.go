@@ -0,0 +1,600 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndependentInstances(t *testing.T) {
+	type Form struct {
+		Code string `validator:"code"`
+	}
+
+	upper := New()
+	upper.AddValidator("code", func(ctx *ValidationContext) bool {
+		return ctx.GetValue().String() == "ABC"
+	})
+
+	lower := New(func(opts *ValidationOptions) {
+		opts.ExposeValidatorNames = true
+	})
+	lower.AddValidator("code", func(ctx *ValidationContext) bool {
+		return ctx.GetValue().String() == "abc"
+	})
+
+	form := Form{Code: "ABC"}
+	assertTrue(t, upper.Validate(&form).IsValid(), "expected upper case code to pass")
+
+	res := lower.Validate(&form)
+	assertFalse(t, res.IsValid(), "expected upper case code to fail")
+	assertEqual(t, "Code: field validation failed using function code", res.FieldErrors[0].Message)
+
+	form.Code = "abc"
+	assertFalse(t, upper.Validate(&form).IsValid(), "expected lower case code to fail")
+	assertTrue(t, lower.Validate(&form).IsValid(), "expected lower case code to pass")
+}
+
+func TestConcurrentRegistration(t *testing.T) {
+	type Form struct {
+		Name string `validator:"length(1,_)" filter:"trim"`
+	}
+
+	v := New()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			name := "custom" + strconv.Itoa(i)
+			v.AddValidator(name, func(ctx *ValidationContext) bool { return true })
+			v.AddFilter(name, func(ctx *ValidationContext) reflect.Value { return ctx.GetValue() })
+		}(i)
+		go func() {
+			defer wg.Done()
+			form := Form{Name: " name "}
+			assertTrue(t, v.Validate(&form).IsValid(), "validation failed")
+		}()
+	}
+	wg.Wait()
+
+	// registering the same name concurrently must panic exactly once
+	var panics int32
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if recover() != nil {
+					atomic.AddInt32(&panics, 1)
+				}
+			}()
+			v.AddValidator("duplicate", func(ctx *ValidationContext) bool { return true })
+		}()
+	}
+	wg.Wait()
+	assertEqual(t, int32(1), panics)
+}
+
+func TestRecoverFromPanics(t *testing.T) {
+	type Form struct {
+		Name  int    `validator:"alphanum"`
+		Age   int    `validator:"at_least(abc)"`
+		Code  string `validator:"explode"`
+		Label string `validator:"length(1,_)"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.RecoverFromPanics = true
+	})
+	v.AddValidator("explode", func(ctx *ValidationContext) bool {
+		panic("boom")
+	})
+	v.AddValidator("at_least", func(ctx *ValidationContext) bool {
+		return ctx.GetValue().Int() >= ctx.MustGetIntArg(0)
+	})
+
+	res := v.Validate(&Form{Label: "label"})
+	assertFalse(t, res.IsValid(), "expected validation to fail")
+	assertEqual(t, 2, len(res.FieldErrors))
+	assertEqual(t, FieldError{Field: "Name", Message: "validator alphanum failed: unexpected type found: int", Validator: "alphanum"}, res.FieldErrors[0])
+	assertEqual(t, "Age", res.FieldErrors[1].Field)
+	assertTrue(t, strings.HasPrefix(res.FieldErrors[1].Message, "validator at_least failed: error getting integer parmeter value"), res.FieldErrors[1].Message)
+	assertEqual(t, "Code: validator explode panicked: boom", res.Error.Error())
+
+	v.SetupOptions(func(opts *ValidationOptions) {
+		opts.RecoverFromPanics = false
+	})
+	assert.Panics(t, func() {
+		v.Validate(&Form{})
+	})
+}
+
+func TestRegister(t *testing.T) {
+	type Item struct {
+		Sku string `validator:"lenght(3,_)"`
+	}
+	type CreateUserRequest struct {
+		Name  string `validator:"requird"`
+		Age   int    `validator:"min(1,2)"`
+		Email string `filter:"trim"`
+		Count int    `filter:"trim"`
+		Items []Item
+	}
+	type UpdateUserRequest struct {
+		Name string `validator:"required|length(1,80)"`
+	}
+
+	v := New()
+	err := v.Register(&CreateUserRequest{}, UpdateUserRequest{})
+	assert.Error(t, err)
+
+	message := err.Error()
+	assert.Contains(t, message, "struct validator.CreateUserRequest, field Name, rule `requird`: validator `requird` not found")
+	assert.Contains(t, message, "struct validator.CreateUserRequest, field Age, rule `min(1,2)`: validator `min` has invalid arguments")
+	assert.Contains(t, message, "struct validator.CreateUserRequest, field Count, rule `trim`: filter `trim` does not support int values")
+	assert.Contains(t, message, "struct validator.Item, field Sku, rule `lenght(3,_)`: validator `lenght` not found")
+	assert.NotContains(t, message, "UpdateUserRequest")
+
+	assert.NoError(t, v.Register(&UpdateUserRequest{}))
+	assert.NoError(t, v.Register(&UpdateUserRequest{}))
+	assert.Error(t, v.Register(10))
+}
+
+func TestFunctionDecisionTable(t *testing.T) {
+	type Inactive struct {
+		Value string `validator:"probe" filter:"probe" trigger:"update"`
+	}
+	type OmitEmpty struct {
+		Value string `validator:"probe" filter:"probe" flags:"omitempty"`
+	}
+	type OmitEmptyPointer struct {
+		Value *string `validator:"probe" filter:"probe" flags:"omitempty"`
+	}
+	type AllowZero struct {
+		Value string `validator:"probe" filter:"probe" flags:"allow_zero"`
+	}
+	type Pointer struct {
+		Value *string `validator:"probe" filter:"probe"`
+	}
+	type Plain struct {
+		Value string `validator:"probe" filter:"probe"`
+	}
+	type SkipFilters struct {
+		Value string `validator:"probe" filter:"probe" flags:"skip_filters"`
+	}
+	type SkipFiltersAllowZero struct {
+		Value *string `validator:"probe" filter:"probe" flags:"allow_zero|skip_filters" trigger:"update"`
+	}
+
+	var validators, filters int
+	var nullSeen bool
+
+	v := New()
+	v.AddValidator("probe", func(ctx *ValidationContext) bool {
+		validators++
+		nullSeen = ctx.IsNull
+		return true
+	})
+	v.AddFilter("probe", func(ctx *ValidationContext) reflect.Value {
+		filters++
+		return ctx.value
+	})
+
+	empty := ""
+	value := "value"
+
+	cases := []struct {
+		name       string
+		structPtr  interface{}
+		trigger    string
+		validators int
+		filters    int
+		null       bool
+	}{
+		{"trigger not active", &Inactive{Value: value}, "create", 0, 0, false},
+		{"omitempty and empty value", &OmitEmpty{}, "all", 0, 0, false},
+		{"omitempty and empty pointer value", &OmitEmptyPointer{Value: &empty}, "all", 0, 0, false},
+		{"omitempty and nil pointer", &OmitEmptyPointer{}, "all", 0, 0, false},
+		{"omitempty and non-empty value", &OmitEmpty{Value: value}, "all", 1, 1, false},
+		{"allow_zero and empty value", &AllowZero{}, "all", 0, 0, false},
+		{"allow_zero and non-empty value", &AllowZero{Value: value}, "all", 1, 1, false},
+		{"nil pointer", &Pointer{}, "all", 1, 1, true},
+		{"otherwise", &Plain{}, "all", 1, 1, false},
+		{"otherwise (pointer)", &Pointer{Value: &value}, "all", 1, 1, false},
+		{"skip_filters", &SkipFilters{Value: value}, "all", 1, 0, false},
+		{"skip_filters and trigger not active", &SkipFiltersAllowZero{Value: &value}, "create", 0, 0, false},
+		{"skip_filters, allow_zero and nil pointer", &SkipFiltersAllowZero{}, "update", 0, 0, false},
+		{"skip_filters, allow_zero and non-zero value", &SkipFiltersAllowZero{Value: &value}, "update", 1, 0, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			validators, filters, nullSeen = 0, 0, false
+			assertTrue(t, v.Validate(c.structPtr, c.trigger).IsValid(), "validation failed")
+			assertEqual(t, c.validators, validators, "validators")
+			assertEqual(t, c.filters, filters, "filters")
+			assertEqual(t, c.null, nullSeen, "IsNull")
+		})
+	}
+}
+
+func TestSetupOptionsCheck(t *testing.T) {
+	v := New()
+
+	err := v.SetupOptions(func(opts *ValidationOptions) {
+		opts.ValidatorTagName = " "
+		opts.StopOnFirstError = true
+	})
+	assertEqual(t, "invalid options: ValidatorTagName must not be empty", err.Error())
+
+	err = v.SetupOptions(func(opts *ValidationOptions) {
+		opts.LabelTagName = "validator"
+	})
+	assertEqual(t, "invalid options: LabelTagName and ValidatorTagName both use the tag name validator", err.Error())
+
+	err = v.SetupOptions(func(opts *ValidationOptions) {
+		opts.ValidatorTagName = "validator,validate"
+		opts.LabelTagName = "label,validate"
+	})
+	assertEqual(t, "invalid options: LabelTagName and ValidatorTagName both use the tag name validate", err.Error())
+
+	err = v.SetupOptions(func(opts *ValidationOptions) {
+		opts.ValidatorTagName = "validator,"
+	})
+	assertEqual(t, "invalid options: ValidatorTagName lists an empty tag name", err.Error())
+
+	err = v.SetupOptions(func(opts *ValidationOptions) {
+		opts.CaseInsensitiveTagNames = true
+		opts.ValidatorTagName = "validator,Validator"
+	})
+	assertEqual(t, "invalid options: ValidatorTagName lists the tag name Validator twice", err.Error())
+
+	err = v.SetupOptions(func(opts *ValidationOptions) {
+		opts.MaxDepth = -1
+	})
+	assertEqual(t, "invalid options: MaxDepth must not be negative", err.Error())
+
+	err = v.SetupOptions(func(opts *ValidationOptions) {
+		opts.ValidatorTimeout = -time.Second
+	})
+	assertEqual(t, "invalid options: ValidatorTimeout must not be negative", err.Error())
+
+	err = v.SetupOptions(func(opts *ValidationOptions) {
+		opts.FunctionSeparator = ""
+	})
+	assertEqual(t, "invalid options: FunctionSeparator must not be empty", err.Error())
+
+	err = v.SetupOptions(func(opts *ValidationOptions) {
+		opts.FunctionSeparator = ","
+	})
+	assertEqual(t, "invalid options: FunctionSeparator must not contain parentheses, commas or quotes", err.Error())
+
+	// rejected options are not applied, not even partially
+	opts := ValidationOptions{}
+	v.CopyOptions(&opts)
+	assertEqual(t, "validator", opts.ValidatorTagName)
+	assertFalse(t, opts.StopOnFirstError, "expected rejected options to be discarded")
+
+	opts.FlagTagName = ""
+	res := v.ValidateWithOptions(&struct{}{}, opts)
+	assertFalse(t, res.IsValid(), "expected invalid options to fail validation")
+	assertEqual(t, "invalid options: FlagTagName must not be empty", res.Error.Error())
+
+	invalid := New(func(opts *ValidationOptions) {
+		opts.SliceSample = -2
+	})
+	assertEqual(t, "invalid options: SliceSample must not be negative", invalid.Validate(&struct{}{}).Error.Error())
+}
+
+func TestTagNameFallbacks(t *testing.T) {
+	type Legacy struct {
+		Name string `validate:"length(3,_)" filters:"trim"`
+	}
+	type Current struct {
+		Name  string `validator:"length(3,_)" filter:"trim"`
+		Email string `validate:"email" label:"E-mail"`
+	}
+	type Ambiguous struct {
+		Name string `validator:"required" validate:"length(3,_)"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.ValidatorTagName = "validator,validate"
+		opts.FilterTagName = "filter,filters"
+	})
+
+	legacy := Legacy{Name: " ab "}
+	res := v.Validate(&legacy)
+	assertEqual(t, []FieldError{{Field: "Name", Message: "length (2) must be at least 3", Validator: "length"}}, res.FieldErrors)
+	assertEqual(t, "ab", legacy.Name)
+
+	current := Current{Name: " abc ", Email: "jane"}
+	res = v.Validate(&current)
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "E-mail", res.FieldErrors[0].Field)
+	assertEqual(t, "abc", current.Name)
+
+	err := v.Register(Ambiguous{})
+	assert.EqualError(t, err, "struct validator.Ambiguous, field Name: ambiguous tags: found both `validator` and `validate`")
+
+	// rules are rendered using the first name
+	canonical, err := v.CanonicalizeTag(`validate:"length(3,_)" filters:"trim" json:"name"`)
+	assert.NoError(t, err)
+	assertEqual(t, `validator:"length(3,_)" filter:"trim" json:"name"`, canonical)
+
+	// case insensitive tag names
+	type Capitalized struct {
+		Name string `Validator:"length(3,_)" LABEL:"Full name"`
+	}
+	insensitive := New(func(opts *ValidationOptions) {
+		opts.CaseInsensitiveTagNames = true
+	})
+	res = insensitive.Validate(&Capitalized{Name: "ab"})
+	assertEqual(t, []FieldError{{Field: "Full name", Message: "length (2) must be at least 3", Validator: "length"}}, res.FieldErrors)
+	assertTrue(t, New().Validate(&Capitalized{Name: "ab"}).IsValid(), "expected tag names to be case sensitive by default")
+
+	type CaseAmbiguous struct {
+		Name string `validator:"required" Validator:"length(3,_)"`
+	}
+	assert.ErrorContains(t, insensitive.Register(CaseAmbiguous{}), "ambiguous tags: found both `validator` and `Validator`")
+}
+
+func TestReconfigureMidRun(t *testing.T) {
+	type Form struct {
+		Name string `validator:"length(1,_)" validate:"length(5,_)"`
+	}
+
+	v := New()
+	form := Form{Name: "abc"}
+	assertTrue(t, v.Validate(&form).IsValid(), "expected the validator tag to apply")
+
+	assert.NoError(t, v.SetupOptions(func(opts *ValidationOptions) {
+		opts.ValidatorTagName = "validate"
+	}))
+	res := v.Validate(&form)
+	assertFalse(t, res.IsValid(), "expected the validate tag to apply after reconfiguration")
+	assertEqual(t, "length (3) must be at least 5", res.FieldErrors[0].Message)
+
+	assert.Error(t, v.SetupOptions(func(opts *ValidationOptions) {
+		opts.ValidatorTagName = ""
+	}))
+	assertFalse(t, v.Validate(&form).IsValid(), "expected a rejected reconfiguration to keep the validate tag")
+
+	// reconfiguring concurrently with validation is safe
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			v.Validate(&Form{Name: "abcdef"})
+		}()
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, v.SetupOptions(func(opts *ValidationOptions) {
+				opts.StopOnFirstError = i%2 == 0
+			}))
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestOptionsSnapshot(t *testing.T) {
+	type Order struct {
+		Status   string `validator:"enum(open,closed)"`
+		Priority string `validator:"enum(low,high)"`
+		Channel  string `validator:"enum(web,phone)"`
+	}
+
+	v := New()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			assert.NoError(t, v.SetupOptions(func(opts *ValidationOptions) {
+				opts.ExposeEnumValues = i%2 == 0
+			}))
+		}
+	}()
+
+	// options changing mid-flight never affect a validation in progress: either all fields expose their values or none
+	for i := 0; i < 500; i++ {
+		res := v.Validate(&Order{Status: "x", Priority: "x", Channel: "x"})
+		assertEqual(t, 3, len(res.FieldErrors))
+		exposed := strings.Contains(res.FieldErrors[0].Message, "expected any of")
+		for _, fieldError := range res.FieldErrors[1:] {
+			assertEqual(t, exposed, strings.Contains(fieldError.Message, "expected any of"), "expected consistent options within a result: "+fieldError.Message)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestCacheGeneration(t *testing.T) {
+	type Booking struct {
+		Guests int    `validator:"range"`
+		Note   string `filter:"shout"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.PanicOnTagError = false
+		opts.NoPanicOnFunctionConflict = true
+	})
+
+	res := v.Validate(&Booking{Guests: 12})
+	assertEqual(t, "struct validator.Booking, field Guests, rule `range`: validator `range` not found", res.Error.Error())
+
+	// registering a validator after first use takes effect
+	v.AddValidator("range", func(ctx *ValidationContext) bool {
+		return ctx.GetValue().Int() <= 10
+	})
+	v.AddFilter("shout", func(ctx *ValidationContext) reflect.Value {
+		ctx.GetValue().SetString(strings.ToUpper(ctx.GetValue().String()))
+		return ctx.GetValue()
+	})
+
+	booking := Booking{Guests: 12, Note: "late arrival"}
+	res = v.Validate(&booking)
+	assertNull(t, res.Error)
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "LATE ARRIVAL", booking.Note)
+
+	// replacing functions of cached structs takes effect
+	v.AddValidator("range", func(ctx *ValidationContext) bool {
+		return ctx.GetValue().Int() <= 20
+	})
+	v.AddFilter("shout", func(ctx *ValidationContext) reflect.Value {
+		ctx.GetValue().SetString(ctx.GetValue().String() + "!")
+		return ctx.GetValue()
+	})
+
+	res = v.Validate(&booking)
+	assertTrue(t, res.IsValid(), "Validation failed")
+	assertEqual(t, "LATE ARRIVAL!", booking.Note)
+
+	// registered structs are parsed again as well
+	assert.NoError(t, v.Register(&Booking{}))
+	v.AddValidator("range", func(ctx *ValidationContext) bool {
+		return false
+	})
+	assertFalse(t, v.Validate(&booking).IsValid(), "expected the replaced validator to be used")
+}
+
+func TestFieldErrorOrder(t *testing.T) {
+	type Inner struct {
+		C string `validator:"nonzero"`
+		D string `validator:"nonzero"`
+	}
+	type Middle struct {
+		B string `validator:"nonzero"`
+		Inner
+		E string `validator:"nonzero"`
+	}
+	type Outer struct {
+		A string `validator:"nonzero"`
+		Middle
+		Nested struct {
+			F string `validator:"nonzero"`
+		}
+		G string `validator:"nonzero"`
+	}
+
+	// fields are reported in declaration order, embedded and nested fields at the position of the embedding field
+	expected := []string{"A", "B", "C", "D", "E", "Nested.F", "G"}
+	for i := 0; i < 3; i++ {
+		res := New().Validate(&Outer{})
+		fields := make([]string, 0, len(res.FieldErrors))
+		for _, fieldError := range res.FieldErrors {
+			fields = append(fields, fieldError.Field)
+		}
+		assertEqual(t, expected, fields)
+	}
+}
+
+func TestWithoutCache(t *testing.T) {
+	// struct types built per tenant, sharing their shape but not their tags
+	tenantType := func(tag string) reflect.Type {
+		return reflect.StructOf([]reflect.StructField{
+			{Name: "Code", Type: reflect.TypeOf(""), Tag: reflect.StructTag(tag)},
+		})
+	}
+	strict := tenantType(`validator:"length(5,_)"`)
+	lenient := tenantType(`validator:"length(1,_)"`)
+
+	payload := func(structType reflect.Type, code string) interface{} {
+		value := reflect.New(structType)
+		value.Elem().Field(0).SetString(code)
+		return value.Interface()
+	}
+	cached := func(v *Validator) (count int) {
+		v.cache.backend.Range(func(_, _ any) bool {
+			count++
+			return true
+		})
+		return
+	}
+
+	v := New()
+	uncached := v.WithoutCache()
+	assertFalse(t, uncached.Validate(payload(strict, "abc")).IsValid(), "expected the strict tags to apply")
+	assertTrue(t, uncached.Validate(payload(lenient, "abc")).IsValid(), "expected the lenient tags to apply")
+	assertEqual(t, 0, cached(v))
+
+	// registration only checks the structs
+	assert.NoError(t, uncached.Register(payload(strict, "")))
+	assertEqual(t, 0, cached(v))
+
+	// the copy keeps the default triggers of the instance it was made from
+	type Account struct {
+		Name string `validator:"length(3,_)" trigger:"create"`
+	}
+	assertFalse(t, v.WithTrigger("create").WithoutCache().Validate(&Account{}).IsValid(), "expected the trigger to apply")
+	assertTrue(t, v.WithoutCache().WithTrigger("update").Validate(&Account{}).IsValid(), "expected the field to be inactive")
+	assertEqual(t, 0, cached(v))
+
+	// the instance itself still caches
+	assertFalse(t, v.Validate(payload(strict, "abc")).IsValid(), "expected the strict tags to apply")
+	assertEqual(t, 1, cached(v))
+}
+
+func TestReplaceAndRemoveFunctions(t *testing.T) {
+	type Contact struct {
+		Email string `validator:"email" filter:"shout"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.PanicOnTagError = false
+	})
+	v.AddFilter("shout", func(ctx *ValidationContext) reflect.Value {
+		return reflect.ValueOf(strings.ToUpper(ctx.GetValue().String()))
+	})
+	contact := Contact{Email: "not an email"}
+	assertFalse(t, v.Validate(&contact).IsValid(), "expected the packaged email validator to fail")
+	assertEqual(t, "NOT AN EMAIL", contact.Email)
+
+	// the cached struct picks up the replacements
+	v.ReplaceValidator("email", func(ctx *ValidationContext) bool { return true })
+	v.ReplaceFilter("shout", func(ctx *ValidationContext) reflect.Value {
+		return reflect.ValueOf(ctx.GetValue().String() + "!")
+	})
+	contact = Contact{Email: "not an email"}
+	assertTrue(t, v.Validate(&contact).IsValid(), "expected the stub to be used")
+	assertEqual(t, "not an email!", contact.Email)
+
+	// replacing unknown functions registers them
+	v.ReplaceValidator("stub", IsRequired)
+	_, ok := v.lookupValidator("stub")
+	assertTrue(t, ok, "expected the validator to be registered")
+
+	// removed functions are reported as unknown
+	v.RemoveFilter("shout")
+	res := v.Validate(&contact)
+	assertEqual(t, "struct validator.Contact, field Email, rule `shout`: filter `shout` not found", res.Error.Error())
+	v.RemoveValidator("email")
+	type Account struct {
+		Email string `validator:"email"`
+	}
+	res = v.Validate(&Account{})
+	assertEqual(t, "struct validator.Account, field Email, rule `email`: validator `email` not found", res.Error.Error())
+
+	// replacing an alias
+	assert.NoError(t, v.RegisterAlias("email", "required"))
+	v.ReplaceValidator("email", IsEmail)
+	assertFalse(t, v.Validate(&Account{Email: "jane"}).IsValid(), "expected the validator to replace the alias")
+
+	// other instances are unaffected
+	assertFalse(t, New().Validate(&Account{Email: "not an email"}).IsValid(), "expected the packaged email validator to be used")
+}
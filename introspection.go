@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"sort"
+)
+
+// FunctionInfo describes a registered validator or filter, e.g. for operational tooling listing the available rules
+type FunctionInfo struct {
+	// Name the name of the function in struct tags
+	Name string `json:"name"`
+	// Description a short description of the function, if known
+	Description string `json:"description,omitempty"`
+	// Kinds the names of the kinds of values supported, e.g. "string" (element kinds for pointers, slices, arrays and
+	// maps). All kinds are supported if empty
+	Kinds []string `json:"kinds,omitempty"`
+	// MinArgs the minimum number of arguments
+	MinArgs int `json:"minArgs"`
+	// MaxArgs the maximum number of arguments, -1 if unlimited or unknown
+	MaxArgs int `json:"maxArgs"`
+	// Packaged whether the function is provided by this package and has not been replaced
+	Packaged bool `json:"packaged"`
+}
+
+// ValidatorInfo describes a registered validator, see Validator.Validators
+type ValidatorInfo = FunctionInfo
+
+// FilterInfo describes a registered filter, see Validator.Filters
+type FilterInfo = FunctionInfo
+
+// Validators Validators lists the validators registered with this instance, sorted by name. Packaged validators are
+// described by their metadata, custom ones by the information passed to AddValidatorWithInfo, if any, or by their
+// name only, accepting any number of arguments.
+//
+// The returned slice is a copy. The function is safe to call concurrently with registration.
+func (v *Validator) Validators() []ValidatorInfo {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	infos := make([]ValidatorInfo, 0, len(v.validators))
+	for name, fn := range v.validators {
+		infos = append(infos, functionInfo(name, v.validatorInfo, validatorMetadata, sameFunction(fn, validatorFunctions[name])))
+	}
+	sortFunctionInfos(infos)
+	return infos
+}
+
+// Filters Filters lists the filters registered with this instance, sorted by name, like Validators.
+//
+// The returned slice is a copy. The function is safe to call concurrently with registration.
+func (v *Validator) Filters() []FilterInfo {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	infos := make([]FilterInfo, 0, len(v.filters))
+	for name, fn := range v.filters {
+		infos = append(infos, functionInfo(name, v.filterInfo, filterMetadata, sameFunction(fn, filterFunctions[name])))
+	}
+	sortFunctionInfos(infos)
+	return infos
+}
+
+// AddValidatorWithInfo AddValidatorWithInfo adds the given validator function like AddValidator, along with the
+// information listed by Validators, e.g. its description. The name of the information is ignored.
+func (v *Validator) AddValidatorWithInfo(name string, fn ValidationFunction, info ValidatorInfo) {
+	v.addValidator(name, fn, &info)
+}
+
+// AddFilterWithInfo AddFilterWithInfo adds the given filter function like AddFilter, along with the information
+// listed by Filters, e.g. its description. The name of the information is ignored.
+func (v *Validator) AddFilterWithInfo(name string, fn FilterFunction, info FilterInfo) {
+	v.addFilter(name, fn, &info)
+}
+
+// Validators Validators lists the validators registered with the default instance, sorted by name.
+//
+// See Validator.Validators for details.
+func Validators() []ValidatorInfo {
+	return defaultValidator.Validators()
+}
+
+// Filters Filters lists the filters registered with the default instance, sorted by name.
+//
+// See Validator.Filters for details.
+func Filters() []FilterInfo {
+	return defaultValidator.Filters()
+}
+
+// AddValidatorWithInfo AddValidatorWithInfo adds the given validator function to the default instance along with
+// the information listed by Validators.
+func AddValidatorWithInfo(name string, fn ValidationFunction, info ValidatorInfo) {
+	defaultValidator.AddValidatorWithInfo(name, fn, info)
+}
+
+// AddFilterWithInfo AddFilterWithInfo adds the given filter function to the default instance along with the
+// information listed by Filters.
+func AddFilterWithInfo(name string, fn FilterFunction, info FilterInfo) {
+	defaultValidator.AddFilterWithInfo(name, fn, info)
+}
+
+// functionInfo describes the function registered under the given name, using the given custom information or,
+// for packaged functions, the given metadata
+func functionInfo(name string, custom map[string]FunctionInfo, metadata map[string]functionMetadata, packaged bool) FunctionInfo {
+	if info, ok := custom[name]; ok {
+		info.Kinds = append([]string(nil), info.Kinds...)
+		return info
+	}
+	meta, ok := metadata[name]
+	if !packaged {
+		return FunctionInfo{Name: name, MaxArgs: -1}
+	}
+	if !ok {
+		return FunctionInfo{Name: name, MaxArgs: -1, Packaged: true}
+	}
+
+	info := FunctionInfo{Name: name, Description: meta.description, MinArgs: meta.minArgs, MaxArgs: meta.maxArgs, Packaged: true}
+	for _, kind := range meta.kinds {
+		info.Kinds = append(info.Kinds, kind.String())
+	}
+	return info
+}
+
+// storeFunctionInfo stores a copy of the given information under the given name in the given map, which is created
+// if nil, or removes the information stored under the name if there is none
+func storeFunctionInfo(infos map[string]FunctionInfo, name string, source *FunctionInfo) map[string]FunctionInfo {
+	if source == nil {
+		delete(infos, name)
+		return infos
+	}
+	info := *source
+	if infos == nil {
+		infos = make(map[string]FunctionInfo)
+	}
+	info.Name = name
+	info.Kinds = append([]string(nil), info.Kinds...)
+	info.Packaged = false
+	infos[name] = info
+	return infos
+}
+
+// sortFunctionInfos sorts the given information by name
+func sortFunctionInfos(infos []FunctionInfo) {
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+}
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestIntrospection(t *testing.T) {
+	v := New(func(opts *ValidationOptions) {
+		opts.NoPanicOnFunctionConflict = true
+	})
+
+	validators := v.Validators()
+	assertEqual(t, len(validatorFunctions), len(validators))
+	assertTrue(t, sort.SliceIsSorted(validators, func(i, j int) bool { return validators[i].Name < validators[j].Name }), "expected validators sorted by name")
+	for _, info := range validators {
+		assertTrue(t, info.Packaged, "expected packaged validator", info.Name)
+		assertTrue(t, info.Description != "", "expected a description", info.Name)
+	}
+	assertEqual(t, ValidatorInfo{
+		Name:        "email",
+		Description: validatorMetadata["email"].description,
+		Kinds:       []string{"string"},
+		MaxArgs:     0,
+		Packaged:    true,
+	}, findFunctionInfo(validators, "email"))
+	length := findFunctionInfo(validators, "length")
+	assertEqual(t, 2, length.MinArgs)
+	assertEqual(t, 3, length.MaxArgs)
+	assertNull(t, length.Kinds)
+
+	filters := v.Filters()
+	assertEqual(t, len(filterFunctions), len(filters))
+	assertEqual(t, []string{"string"}, findFunctionInfo(filters, "trim").Kinds)
+
+	// custom functions, with and without information
+	v.AddValidator("is_sku", func(ctx *ValidationContext) bool { return true })
+	v.AddValidatorWithInfo("is_ean", func(ctx *ValidationContext) bool { return true }, ValidatorInfo{
+		Name:        "ignored",
+		Description: "string must be an EAN-13 code",
+		Kinds:       []string{"string"},
+		MaxArgs:     0,
+		Packaged:    true,
+	})
+	v.AddFilterWithInfo("slugify", func(ctx *ValidationContext) reflect.Value { return ctx.GetValue() }, FilterInfo{Description: "converts to a slug", MaxArgs: 0})
+	validators = v.Validators()
+	assertEqual(t, ValidatorInfo{Name: "is_sku", MaxArgs: -1}, findFunctionInfo(validators, "is_sku"))
+	assertEqual(t, ValidatorInfo{Name: "is_ean", Description: "string must be an EAN-13 code", Kinds: []string{"string"}}, findFunctionInfo(validators, "is_ean"))
+	assertEqual(t, FilterInfo{Name: "slugify", Description: "converts to a slug"}, findFunctionInfo(v.Filters(), "slugify"))
+
+	// the output is a copy
+	validators[0].Name = "changed"
+	findFunctionInfo(validators, "is_ean").Kinds[0] = "int"
+	assertEqual(t, []string{"string"}, findFunctionInfo(v.Validators(), "is_ean").Kinds)
+	assertTrue(t, v.Validators()[0].Name != "changed", "expected a copy")
+
+	// replaced functions are no longer described by the packaged metadata or the information added before
+	v.ReplaceValidator("email", func(ctx *ValidationContext) bool { return true })
+	v.ReplaceValidator("is_ean", func(ctx *ValidationContext) bool { return true })
+	validators = v.Validators()
+	assertEqual(t, ValidatorInfo{Name: "email", MaxArgs: -1}, findFunctionInfo(validators, "email"))
+	assertEqual(t, ValidatorInfo{Name: "is_ean", MaxArgs: -1}, findFunctionInfo(validators, "is_ean"))
+
+	// removed functions are no longer listed
+	v.RemoveFilter("slugify")
+	assertEqual(t, FilterInfo{}, findFunctionInfo(v.Filters(), "slugify"))
+
+	// listing is safe concurrently with registration
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			v.AddValidatorWithInfo("concurrent", IsRequired, ValidatorInfo{Description: "concurrent"})
+		}()
+		go func() {
+			defer wg.Done()
+			v.Validators()
+		}()
+	}
+	wg.Wait()
+	assertEqual(t, "concurrent", findFunctionInfo(v.Validators(), "concurrent").Description)
+}
+
+func findFunctionInfo(infos []FunctionInfo, name string) FunctionInfo {
+	for _, info := range infos {
+		if info.Name == name {
+			return info
+		}
+	}
+	return FunctionInfo{}
+}
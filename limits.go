@@ -0,0 +1,107 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// TagComplexityLimits bounds the complexity of struct tags, which is checked when structs are parsed, e.g. when
+// struct types are declared by untrusted plugins. A tag exceeding a limit is reported as an invalid struct tag naming
+// the limit, the field and the measured value. A limit of zero disables the check.
+type TagComplexityLimits struct {
+	// MaxTagLength the maximum length of the struct tag of a field in bytes, including tags of other packages
+	//
+	// default: 4096
+	MaxTagLength int
+
+	// MaxFunctions the maximum number of validators of a field, once rule sets and aliases are expanded, and the
+	// maximum number of filters of a field
+	//
+	// default: 64
+	MaxFunctions int
+
+	// MaxArgs the maximum number of arguments of a validator or filter
+	//
+	// default: 32
+	MaxArgs int
+
+	// MaxArgLength the maximum length of an argument in bytes
+	//
+	// default: 1024
+	MaxArgLength int
+
+	// MaxNesting the maximum number of rule sets and aliases nested within each other
+	//
+	// default: 8
+	MaxNesting int
+}
+
+// defaultTagComplexityLimits returns the default limits, see ValidationOptions.TagLimits
+func defaultTagComplexityLimits() TagComplexityLimits {
+	return TagComplexityLimits{
+		MaxTagLength: 4096,
+		MaxFunctions: 64,
+		MaxArgs:      32,
+		MaxArgLength: 1024,
+		MaxNesting:   maxRuleSetDepth,
+	}
+}
+
+// check verifies that none of the limits is negative
+func (l TagComplexityLimits) check() error {
+	limits := []struct {
+		option string
+		value  int
+	}{
+		{"MaxTagLength", l.MaxTagLength},
+		{"MaxFunctions", l.MaxFunctions},
+		{"MaxArgs", l.MaxArgs},
+		{"MaxArgLength", l.MaxArgLength},
+		{"MaxNesting", l.MaxNesting},
+	}
+	for _, limit := range limits {
+		if limit.value < 0 {
+			return newValidationError("invalid options: TagLimits." + limit.option + " must not be negative")
+		}
+	}
+	return nil
+}
+
+// exceeds tests whether the given measured value exceeds the given limit, zero disabling the limit
+func exceeds(measured int, limit int) bool {
+	return limit > 0 && measured > limit
+}
+
+// limitMessage describes the given measured value exceeding the named limit
+func limitMessage(measured string, limit string, value int) string {
+	return measured + ", exceeding TagLimits." + limit + " (" + strconv.Itoa(value) + ")"
+}
+
+// checkTag verifies the length of the tag of the given field, before any of it is parsed
+func (l TagComplexityLimits) checkTag(structType reflect.Type, field reflect.StructField) *RuleProblem {
+	if exceeds(len(field.Tag), l.MaxTagLength) {
+		return newTagError(structType, field, "", limitMessage("tag is "+strconv.Itoa(len(field.Tag))+" bytes long", "MaxTagLength", l.MaxTagLength))
+	}
+	return nil
+}
+
+// checkFunctions verifies the number of functions listed by the given tag of the given field
+func (l TagComplexityLimits) checkFunctions(structType reflect.Type, field reflect.StructField, kind string, tag string, functions []string) *RuleProblem {
+	if exceeds(len(functions), l.MaxFunctions) {
+		return newTagError(structType, field, tag, limitMessage(kind+" tag lists "+strconv.Itoa(len(functions))+" functions", "MaxFunctions", l.MaxFunctions))
+	}
+	return nil
+}
+
+// checkArguments verifies the number and the length of the arguments of the given function of the given field
+func (l TagComplexityLimits) checkArguments(structType reflect.Type, field reflect.StructField, function string, name string, args []string) *RuleProblem {
+	if exceeds(len(args), l.MaxArgs) {
+		return newTagError(structType, field, function, limitMessage("`"+name+"` has "+strconv.Itoa(len(args))+" arguments", "MaxArgs", l.MaxArgs))
+	}
+	for i, arg := range args {
+		if exceeds(len(arg), l.MaxArgLength) {
+			return newTagError(structType, field, function, limitMessage("argument "+strconv.Itoa(i+1)+" of `"+name+"` is "+strconv.Itoa(len(arg))+" bytes long", "MaxArgLength", l.MaxArgLength))
+		}
+	}
+	return nil
+}
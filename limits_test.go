@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagLimits(t *testing.T) {
+	v := New(func(opts *ValidationOptions) {
+		opts.PanicOnTagError = false
+		opts.TagLimits = TagComplexityLimits{MaxTagLength: 120, MaxFunctions: 3, MaxArgs: 2, MaxArgLength: 8, MaxNesting: 2}
+	})
+	v.AddValidator("any", func(ctx *ValidationContext) bool { return true })
+	v.AddFilter("same", func(ctx *ValidationContext) reflect.Value { return ctx.GetValue() })
+
+	assert.NoError(t, v.RegisterRuleSet("pair", "required|nonzero"))
+	assert.NoError(t, v.RegisterRuleSet("nested", "$pair"))
+	assert.NoError(t, v.RegisterRuleSet("deep", "$nested"))
+
+	tests := []struct {
+		tag string
+		err string
+	}{
+		{tag: `validator:"required|length(1,8)|enum(a,b)"`},
+		{tag: `validator:"any|any|any|any"`, err: "validator tag lists 4 functions, exceeding TagLimits.MaxFunctions (3)"},
+		{tag: `filter:"same|same|same|same"`, err: "filter tag lists 4 functions, exceeding TagLimits.MaxFunctions (3)"},
+		{tag: `validator:"enum(a,b,c)"`, err: "`enum` has 3 arguments, exceeding TagLimits.MaxArgs (2)"},
+		{tag: `filter:"same(a,b,c)"`, err: "`same` has 3 arguments, exceeding TagLimits.MaxArgs (2)"},
+		{tag: `validator:"enum(a,'123456789')"`, err: "argument 2 of `enum` is 9 bytes long, exceeding TagLimits.MaxArgLength (8)"},
+		{tag: `validator:"any" json:"` + strings.Repeat("x", 120) + `"`, err: "tag is 143 bytes long, exceeding TagLimits.MaxTagLength (120)"},
+		// rule sets count once expanded
+		{tag: `validator:"$pair|any"`},
+		{tag: `validator:"$pair|$pair"`, err: "rule sets and aliases expand to more than 3 validators, exceeding TagLimits.MaxFunctions (3)"},
+		{tag: `validator:"$nested"`},
+		{tag: `validator:"$deep"`, err: "rule set `$pair` exceeds the maximum nesting depth of rule sets and aliases: 3 levels, exceeding TagLimits.MaxNesting (2)"},
+	}
+
+	for _, test := range tests {
+		structType := reflect.StructOf([]reflect.StructField{{Name: "Value", Type: reflect.TypeOf(""), Tag: reflect.StructTag(test.tag)}})
+		res := v.Validate(reflect.New(structType).Interface())
+		problems := v.AnalyzeType(structType)
+		if test.err == "" {
+			assertNull(t, res.Error, test.tag)
+			assertEqual(t, 0, len(problems), test.tag)
+			continue
+		}
+		assert.ErrorContains(t, res.Error, "field Value", test.tag)
+		assert.ErrorContains(t, res.Error, test.err, test.tag)
+		if assert.Equal(t, 1, len(problems), test.tag) {
+			assertEqual(t, test.err, problems[0].Message, test.tag)
+		}
+	}
+
+	// the default limits apply unless disabled
+	type Long struct {
+		Value string `validator:"enum(a,b,c,d,e,f,g,h,i,j,k,l,m,n,o,p,q,r,s,t,u,v,w,x,y,z,aa,bb,cc,dd,ee,ff,gg)"`
+	}
+	res := New(func(opts *ValidationOptions) { opts.PanicOnTagError = false }).Validate(&Long{Value: "a"})
+	assert.ErrorContains(t, res.Error, "`enum` has 33 arguments, exceeding TagLimits.MaxArgs (32)")
+	unlimited := New(func(opts *ValidationOptions) { opts.TagLimits = TagComplexityLimits{} })
+	assertTrue(t, unlimited.Validate(&Long{Value: "a"}).IsValid(), "expected disabled limits")
+
+	// structs parsed with other limits are cached separately
+	opts := unlimited.currentOptions()
+	opts.TagLimits.MaxArgs = 2
+	opts.PanicOnTagError = false
+	assert.ErrorContains(t, unlimited.ValidateWithOptions(&Long{}, opts).Error, "exceeding TagLimits.MaxArgs (2)")
+
+	opts.TagLimits.MaxArgLength = -1
+	assert.EqualError(t, opts.Check(), "invalid options: TagLimits.MaxArgLength must not be negative")
+}
+
+func FuzzTagLimits(f *testing.F) {
+	f.Add(`required|length(1,8)|enum(a,b)`, `trim`)
+	f.Add(`$a|$a|$a`, `trim|trim`)
+	f.Add(`enum(`+strings.Repeat("a,", 40)+`a)`, ``)
+	f.Add(`enum('`+strings.Repeat("(", 64)+`')`, `trim(`+strings.Repeat("x", 64)+`)`)
+
+	limits := TagComplexityLimits{MaxTagLength: 256, MaxFunctions: 4, MaxArgs: 4, MaxArgLength: 16, MaxNesting: 3}
+	v := New(func(opts *ValidationOptions) {
+		opts.PanicOnTagError = false
+		opts.TagLimits = limits
+	})
+	// rule sets referencing each other several times, multiplying without limits
+	_ = v.RegisterRuleSet("a", "$b|$b|$b")
+	_ = v.RegisterRuleSet("b", "$c|$c|$c")
+	_ = v.RegisterRuleSet("c", "required|required|required")
+
+	f.Fuzz(func(t *testing.T, validators string, filters string) {
+		if strings.ContainsAny(validators+filters, "\"\\`") {
+			t.Skip()
+		}
+		tag := reflect.StructTag(`validator:"` + validators + `" filter:"` + filters + `"`)
+		structType := reflect.StructOf([]reflect.StructField{{Name: "Value", Type: reflect.TypeOf(""), Tag: tag}})
+		opts := v.currentOptions()
+		contexts, problems := v.parseStruct(structType, &opts)
+		if len(problems) > 0 {
+			return
+		}
+		if len(tag) > limits.MaxTagLength {
+			t.Fatalf("tag of %d bytes parsed", len(tag))
+		}
+		for _, fc := range contexts {
+			if len(fc.validators) > limits.MaxFunctions || len(fc.filters) > limits.MaxFunctions {
+				t.Fatalf("%d validators and %d filters parsed from %q", len(fc.validators), len(fc.filters), tag)
+			}
+			for _, validator := range fc.validators {
+				checkArgs(t, limits, validator.args)
+			}
+			for _, filter := range fc.filters {
+				checkArgs(t, limits, filter.args)
+			}
+		}
+	})
+}
+
+func checkArgs(t *testing.T, limits TagComplexityLimits, args []string) {
+	if len(args) > limits.MaxArgs {
+		t.Fatalf("%d arguments parsed", len(args))
+	}
+	for _, arg := range args {
+		if len(arg) > limits.MaxArgLength {
+			t.Fatalf("argument of %d bytes parsed", len(arg))
+		}
+	}
+}
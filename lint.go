@@ -0,0 +1,212 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+
+	"golang.org/x/exp/slices"
+)
+
+// RuleProblem describes a problem found in the struct tags of a field
+type RuleProblem struct {
+	// Struct the name of the struct type declaring the field
+	Struct string `json:"struct"`
+	// Field the name of the field
+	Field string `json:"field"`
+	// Rule the text of the offending rule, e.g. min(abc)
+	Rule string `json:"rule"`
+	// Message the description of the problem
+	Message string `json:"message"`
+	// Cause the underlying error, such as an argument parse error, if any
+	Cause error `json:"-"`
+}
+
+func (p RuleProblem) Error() string {
+	return p.validationError().Error()
+}
+
+// Unwrap Unwrap returns the underlying error of the problem
+func (p RuleProblem) Unwrap() error {
+	return p.Cause
+}
+
+// validationError converts the problem into the error reported when validating structs with invalid tags
+func (p RuleProblem) validationError() *ValidationError {
+	msg := "struct " + p.Struct
+	if p.Field != "" {
+		msg += ", field " + p.Field
+	}
+	if p.Rule != "" {
+		msg += ", rule `" + p.Rule + "`"
+	}
+	return newValidationError(msg+": "+p.Message, p.Cause)
+}
+
+// CheckStruct CheckStruct statically checks the tags of the given struct (or struct pointer) and of the structs
+// nested within it, returning all problems found without validating any value.
+//
+// Besides the problems reported by Register, CheckStruct reports packaged validators used on fields whose kind they do
+// not support, such as email on an integer field, and references to unknown fields, such as eqfield(Pasword) or
+// max(${Stok}). Validators and filters that have been replaced are only checked for existence. The cache is left
+// untouched.
+//
+// Use it in a unit test over all validated types to catch invalid tags before they ship.
+func (v *Validator) CheckStruct(s interface{}) []RuleProblem {
+	var problems []RuleProblem
+	opts := v.currentOptions()
+
+	types, invalid := structTypes([]interface{}{s})
+	for _, s := range invalid {
+		problems = append(problems, RuleProblem{Struct: fmt.Sprintf("%T", s), Message: "expected struct or struct pointer"})
+	}
+
+	return append(problems, v.checkTypes(types, &opts, false)...)
+}
+
+// TagProblem TagProblem describes a problem found in the struct tags of a field by AnalyzeType
+type TagProblem = RuleProblem
+
+// AnalyzeType AnalyzeType statically checks the tags of the given struct type (or struct pointer type) and of the
+// struct types nested within it, like CheckStruct, without a value of the type.
+//
+// Tags are parsed as with ValidationOptions.StrictTagParsing, reporting unknown flags and malformed entries, and
+// validators listed more than once for a field are reported as well. Struct types built with reflect.StructOf, such
+// as those built by cmd/vet from source code, can be analyzed too.
+func (v *Validator) AnalyzeType(t reflect.Type) []TagProblem {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return []TagProblem{{Struct: fmt.Sprint(t), Message: "expected struct or struct pointer"}}
+	}
+
+	opts := v.currentOptions()
+	opts.StrictTagParsing = true
+	return v.checkTypes([]reflect.Type{t}, &opts, true)
+}
+
+// checkTypes statically checks the tags of the given struct types and of the struct types nested within them,
+// optionally reporting duplicate validators
+func (v *Validator) checkTypes(types []reflect.Type, opts *ValidationOptions, duplicates bool) (problems []RuleProblem) {
+	walkStructTypes(types, func(t reflect.Type) []*fieldContext {
+		contexts, parseProblems := v.parseStruct(t, opts)
+		for _, problem := range parseProblems {
+			problems = append(problems, *problem)
+		}
+		for _, fc := range contexts {
+			problems = append(problems, v.checkField(fc, opts)...)
+			problems = append(problems, v.checkPlaceholderFields(fc)...)
+			if duplicates {
+				problems = append(problems, checkDuplicateValidators(fc)...)
+			}
+		}
+		return contexts
+	})
+	return
+}
+
+// checkDuplicateValidators reports validators listed more than once for the given field, including through rule sets
+func checkDuplicateValidators(fc *fieldContext) (problems []RuleProblem) {
+	seen := make(map[string]bool, len(fc.validators))
+	for _, validator := range fc.validators {
+		if seen[validator.name] {
+			msg := "duplicate validator `" + validator.name + "`"
+			problems = append(problems, RuleProblem{Struct: fc.structType.String(), Field: fc.fieldName, Rule: validator.rule, Message: msg})
+		}
+		seen[validator.name] = true
+	}
+	return
+}
+
+// checkField reports packaged validators of the given field that do not support the kind of its values or that
+// reference unknown fields, or fields of the wrong type
+func (v *Validator) checkField(fc *fieldContext, opts *ValidationOptions) (problems []RuleProblem) {
+	for _, validator := range fc.validators {
+		meta, ok := validatorMetadata[validator.name]
+		if !ok || !sameFunction(validator.fn, validatorFunctions[validator.name]) {
+			continue
+		}
+
+		msg := ""
+		// the kind of interface values is only known at runtime
+		if isNumericValidator(validator.name) && v.isNumericType(fc.fieldType) {
+			// types with a numeric adapter are supported whatever their kind, see RegisterNumericAdapter
+		} else if fc.fieldKind != reflect.Interface && !meta.supportsKind(fc.fieldKind) {
+			msg = "validator `" + validator.name + "` does not support " + fc.fieldKind.String() + " values"
+		} else if (validator.name == "min" || validator.name == "max") && fc.fieldKind == reflect.String && !opts.LegacyMinMaxStringLength {
+			msg = "validator `" + validator.name + "` does not support string values unless LegacyMinMaxStringLength is set, use length instead"
+		} else if meta.fieldArgument && !hasField(fc.structType, validator.args[0]) {
+			msg = "validator `" + validator.name + "` references unknown field " + validator.args[0]
+		} else if slices.Contains(fieldComparisonValidators, validator.name) && !comparableTypes(fc.fieldType, siblingType(fc.structType, validator.args[0])) {
+			msg = "validator `" + validator.name + "` cannot compare " + fc.fieldType.String() + " values with field " + validator.args[0] + " of type " + siblingType(fc.structType, validator.args[0]).String()
+		} else if validator.name == "enum_field" && !isListField(fc.structType, validator.args[0]) {
+			msg = "validator `" + validator.name + "` references field " + validator.args[0] + ", which is not a slice or array of strings or integers"
+		}
+
+		if msg != "" {
+			problems = append(problems, RuleProblem{Struct: fc.structType.String(), Field: fc.fieldName, Rule: validator.rule, Message: msg})
+		}
+	}
+	return
+}
+
+// checkPlaceholderFields reports ${Field} arguments of the given field referencing unknown fields
+func (v *Validator) checkPlaceholderFields(fc *fieldContext) (problems []RuleProblem) {
+	report := func(kind string, name string, rule string, args []string) {
+		for _, arg := range args {
+			if field, ok := placeholderField(arg); ok && !hasField(fc.structType, field) {
+				msg := kind + " `" + name + "` references unknown field " + field
+				problems = append(problems, RuleProblem{Struct: fc.structType.String(), Field: fc.fieldName, Rule: rule, Message: msg})
+			}
+		}
+	}
+	for _, validator := range fc.validators {
+		report("validator", validator.name, validator.rule, validator.args)
+	}
+	for _, filter := range fc.filters {
+		report("filter", filter.name, filter.rule, filter.args)
+	}
+	return
+}
+
+// hasField tests whether the given struct type has a field with the given name
+func hasField(structType reflect.Type, name string) bool {
+	_, ok := structType.FieldByName(name)
+	return ok
+}
+
+// siblingType returns the type of the field of the given struct type with the given name, which must exist
+func siblingType(structType reflect.Type, name string) reflect.Type {
+	field, _ := structType.FieldByName(name)
+	return field.Type
+}
+
+// isListField tests whether the field of the given struct type with the given name holds the elements read by
+// ValidationContext.SiblingValues: strings or integers in a slice or array, possibly behind pointers. Interface fields
+// are only known at runtime and pass.
+func isListField(structType reflect.Type, name string) bool {
+	t := siblingType(structType, name)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Interface {
+		return true
+	}
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return false
+	}
+	kind := t.Elem().Kind()
+	return kind == reflect.String || slices.Contains(integerKinds, kind)
+}
+
+// CheckStruct CheckStruct statically checks the tags of the given struct using the default instance.
+// See Validator.CheckStruct.
+func CheckStruct(s interface{}) []RuleProblem {
+	return defaultValidator.CheckStruct(s)
+}
+
+// AnalyzeType AnalyzeType statically checks the tags of the given struct type using the default instance.
+// See Validator.AnalyzeType.
+func AnalyzeType(t reflect.Type) []TagProblem {
+	return defaultValidator.AnalyzeType(t)
+}
@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStruct(t *testing.T) {
+	type Address struct {
+		Street string `validator:"alphanum(x)"`
+	}
+	type Form struct {
+		Name      string   `validator:"required|requird"`
+		Age       int      `validator:"min"`
+		Score     int      `validator:"max(abc)"`
+		Kind      string   `validator:"enum"`
+		Phone     int      `validator:"email"`
+		Id        uint     `validator:"uuid4"`
+		Born      []string `validator:"dob(rfc3339)"`
+		Count     int      `filter:"trim"`
+		Addresses []Address
+	}
+
+	problems := New().CheckStruct(&Form{})
+
+	expected := []RuleProblem{
+		{Struct: "validator.Form", Field: "Name", Rule: "requird", Message: "validator `requird` not found"},
+		{Struct: "validator.Form", Field: "Age", Rule: "min", Message: "validator `min` has invalid arguments"},
+		{Struct: "validator.Form", Field: "Score", Rule: "max(abc)", Message: "validator `max` has invalid arguments"},
+		{Struct: "validator.Form", Field: "Kind", Rule: "enum", Message: "validator `enum` has invalid arguments"},
+		{Struct: "validator.Form", Field: "Count", Rule: "trim", Message: "filter `trim` does not support int values"},
+		{Struct: "validator.Form", Field: "Phone", Rule: "email", Message: "validator `email` does not support int values"},
+		{Struct: "validator.Form", Field: "Id", Rule: "uuid4", Message: "validator `uuid4` does not support uint values"},
+		{Struct: "validator.Form", Field: "Born", Rule: "dob(rfc3339)", Message: "validator `dob` does not support slice values"},
+		{Struct: "validator.Address", Field: "Street", Rule: "alphanum(x)", Message: "validator `alphanum` has invalid arguments"},
+	}
+
+	if assert.Equal(t, len(expected), len(problems), problems) {
+		for i, problem := range problems {
+			problem.Cause = nil
+			assertEqual(t, expected[i], problem)
+		}
+	}
+
+	assertEqual(t, "struct validator.Form, field Age, rule `min`: validator `min` has invalid arguments: expected exactly 1 argument(s) but found 0", problems[1].Error())
+	assertEqual(t, "expected an integer argument but found abc", errors.Unwrap(problems[2]).Error())
+}
+
+func TestCheckStructValid(t *testing.T) {
+	type Form struct {
+		Name  string  `validator:"required|length(1,80)" filter:"trim"`
+		Age   int     `validator:"min(18)|max(130)"`
+		Role  *string `validator:"enum(admin,user)"`
+		Born  string  `validator:"dob(date)"`
+		Stamp int64   `validator:"before_today(unix)"`
+	}
+
+	assert.Empty(t, New().CheckStruct(Form{}))
+	assert.Empty(t, CheckStruct(&Form{}))
+
+	problems := CheckStruct(10)
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "struct int: expected struct or struct pointer", problems[0].Error())
+}
+
+func TestCheckStructMinMaxStrings(t *testing.T) {
+	type Form struct {
+		Name string `validator:"min(1)"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.LegacyMinMaxStringLength = false
+	})
+	problems := v.CheckStruct(&Form{})
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "validator `min` does not support string values unless LegacyMinMaxStringLength is set, use length instead", problems[0].Message)
+}
+
+func TestAnalyzeType(t *testing.T) {
+	type Inner struct {
+		Zip int `validator:"email"`
+	}
+	type Form struct {
+		Age      int    `validator:"min(18)|min(21)"`
+		Nickname string `validator:"length(1,_)" flags:"allowzero"`
+		Contact  string `validator:"$contact|email"`
+		Inner    *Inner
+	}
+
+	v := New()
+	assert.NoError(t, v.RegisterRuleSet("contact", "required|email"))
+	problems := v.AnalyzeType(reflect.TypeOf(&Form{}))
+	assertEqual(t, []TagProblem{
+		{Struct: "validator.Form", Field: "Nickname", Rule: "allowzero", Message: "unknown flag `allowzero`"},
+		{Struct: "validator.Form", Field: "Age", Rule: "min(21)", Message: "duplicate validator `min`"},
+		{Struct: "validator.Form", Field: "Contact", Rule: "email", Message: "duplicate validator `email`"},
+		{Struct: "validator.Inner", Field: "Zip", Rule: "email", Message: "validator `email` does not support int values"},
+	}, problems)
+
+	// neither reported by CheckStruct
+	assertEqual(t, 1, len(v.CheckStruct(&Form{})))
+
+	// struct types built at runtime
+	built := reflect.StructOf([]reflect.StructField{{Name: "Email", Type: reflect.TypeOf(""), Tag: `validator:"emial"`}})
+	problems = AnalyzeType(built)
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "validator `emial` not found", problems[0].Message)
+
+	assertEqual(t, "expected struct or struct pointer", AnalyzeType(reflect.TypeOf(1))[0].Message)
+}
@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ValidateMap ValidateMap validates the values of a loosely typed map, such as a decoded webhook payload or
+// configuration, using this instance. Rules map keys to validators in the syntax of validator tags, e.g.
+//
+//	res := v.ValidateMap(payload, map[string]string{
+//		"email":          "required|email",
+//		"retries":        "min(1)|max(10)",
+//		"address.city":   "required|length(2,_)",
+//	})
+//
+// Dotted keys address values of nested maps with string keys. Missing keys, including keys below missing or non-map
+// values, are treated as nil values: required fails and most other validators pass, as for nil pointer fields.
+// Values are validated against their dynamic type, so values of a kind a validator does not support, such as a
+// string checked by min, fail with a field error. Numbers decoded from JSON as float64 or json.Number are validated
+// as int64 if they are whole numbers.
+//
+// Field errors name the key of the failing value, e.g. address.city, and are reported in key order. Keys of the
+// map without rules are ignored. Filters are not supported and triggers are passed to validators as for Validate.
+// Invalid rules set ValidationResult.Error.
+func (v *Validator) ValidateMap(data map[string]interface{}, rules map[string]string, trigger ...string) *ValidationResult {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	res := &ValidationResult{}
+	for _, key := range keys {
+		value := normalizeMapValue(lookupMapValue(data, key))
+		keyResult := v.validateVar(value, emptyInterfaceType, rules[key], key, trigger)
+		if keyResult.Error != nil {
+			if res.Error == nil {
+				res.Error = keyResult.Error
+			}
+			continue
+		}
+		res.FieldErrors = append(res.FieldErrors, keyResult.FieldErrors...)
+	}
+	res.updateValidity()
+	return res
+}
+
+// ValidateMap ValidateMap validates the values of a loosely typed map using the default instance.
+//
+// See Validator.ValidateMap for details.
+func ValidateMap(data map[string]interface{}, rules map[string]string, trigger ...string) *ValidationResult {
+	return defaultValidator.ValidateMap(data, rules, trigger...)
+}
+
+// lookupMapValue returns the value found at the given dotted key of the given map, descending into nested maps with
+// string keys, or nil if there is none
+func lookupMapValue(data map[string]interface{}, key string) interface{} {
+	if value, ok := data[key]; ok {
+		return value
+	}
+
+	var current interface{} = data
+	for _, segment := range strings.Split(key, ".") {
+		m := reflect.ValueOf(current)
+		if m.Kind() != reflect.Map || m.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		element := m.MapIndex(reflect.ValueOf(segment).Convert(m.Type().Key()))
+		if !element.IsValid() {
+			return nil
+		}
+		current = element.Interface()
+	}
+	return current
+}
+
+// normalizeMapValue converts whole numbers decoded from JSON, as float64 or json.Number values, to int64, so that
+// integer validators such as min apply to them
+func normalizeMapValue(value interface{}) interface{} {
+	switch number := value.(type) {
+	case float64:
+		if number == math.Trunc(number) && number >= math.MinInt64 && number < math.MaxInt64 {
+			return int64(number)
+		}
+	case json.Number:
+		if i, err := number.Int64(); err == nil {
+			return i
+		}
+		if f, err := number.Float64(); err == nil {
+			return f
+		}
+	}
+	return value
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateMap(t *testing.T) {
+	var payload map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"email": "not an email",
+		"retries": 12,
+		"name": "Jo",
+		"address": {"city": "B", "zip": 12345},
+		"tags": ["a"]
+	}`), &payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := New().ValidateMap(payload, map[string]string{
+		"email":          "required|email",
+		"retries":        "min(1)|max(10)",
+		"name":           "required|length(2,10)",
+		"address.city":   "required|length(2,_)",
+		"address.zip":    "min(10000)",
+		"address.street": "required",
+		"token":          "required",
+		"optional":       "length(3,_)",
+		"name.first":     "required",
+		"tags":           "min(2)",
+	})
+	assertFalse(t, res.IsValid(), "expected errors")
+	assertNull(t, res.Error)
+	assertEqual(t, []FieldError{
+		{Field: "address.city", Message: "length (1) must be at least 2", Validator: "length"},
+		{Field: "address.street", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "email", Message: "email: field validation failed", Validator: "email"},
+		{Field: "name.first", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "retries", Message: "value (12) must not exceed 10", Validator: "max"},
+		{Field: "tags", Message: "must contain at least 2 items", Validator: "min"},
+		{Field: "token", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+	}, res.FieldErrors)
+
+	// nested maps of other types, and dotted keys stored as is
+	res = ValidateMap(map[string]interface{}{
+		"limits":  map[string]int{"max": 0},
+		"a.b":     "x",
+		"decoded": json.Number("7"),
+	}, map[string]string{
+		"limits.max": "min(1)",
+		"a.b":        "length(2,_)",
+		"decoded":    "max(5)",
+	})
+	assertEqual(t, []FieldError{
+		{Field: "a.b", Message: "length (1) must be at least 2", Validator: "length"},
+		{Field: "decoded", Message: "value (7) must not exceed 5", Validator: "max"},
+		{Field: "limits.max", Message: "value (0) must be at least 1", Validator: "min"},
+	}, res.FieldErrors)
+
+	assertTrue(t, New().ValidateMap(nil, nil).IsValid(), "expected no rules to pass")
+
+	res = New().ValidateMap(payload, map[string]string{"email": "required|emial"})
+	assertEqual(t, "invalid rules `required|emial`: validator `emial` not found", res.Error.Error())
+}
@@ -0,0 +1,137 @@
+package validator
+
+// Default messages of packaged validators. Their text is stable: changing it is a breaking change, allowing
+// integrators to key translations on them.
+//
+// Messages containing verbs are fmt formats, documented with their arguments.
+const (
+	// MsgFieldValidationFailed is used when a failing validator provides no message. Arguments: field label
+	MsgFieldValidationFailed = "%s: field validation failed"
+	// MsgFilterFailed is used when a failing filter provides no message. Arguments: field label
+	MsgFilterFailed = "%s: field filtering failed"
+	// MsgRequired is reported by required, required_if and required_unless for missing values, see CodeMissing
+	MsgRequired = "this field is required"
+	// MsgEmpty is reported by required, required_if and required_unless for empty values, see CodeEmpty
+	MsgEmpty = "this field must not be empty"
+	// MsgNonZero is reported by nonzero
+	MsgNonZero = "value must not be zero"
+	// MsgAlphaNumeric is reported by alphanum
+	MsgAlphaNumeric = "must be alphanumeric"
+	// MsgUUIDFormat is reported by uuid1 to uuid4 for values which are not UUIDs
+	MsgUUIDFormat = "invalid uuid format"
+	// MsgUUIDVersionMismatch is reported by uuid1 to uuid4. Arguments: expected version, actual version
+	MsgUUIDVersionMismatch = "expected UUIDv%d but found UUIDv%d"
+	// MsgUUIDCanonical is reported by uuid_canonical for UUIDs which are not lowercase and hyphenated
+	MsgUUIDCanonical = "UUID must be lowercase canonical form"
+	// MsgNilUUID is reported by uuid_bytes(nonzero)
+	MsgNilUUID = "uuid must not be the nil uuid"
+	// MsgMin is reported by min. Arguments: measured property (value or length), actual value, minimum
+	MsgMin = "%s (%v) must be at least %v"
+	// MsgMax is reported by max. Arguments: measured property (value or length), actual value, maximum
+	MsgMax = "%s (%v) must not exceed %v"
+	// MsgMinItems is reported by min for slices, arrays and maps. Arguments: minimum number of items
+	MsgMinItems = "must contain at least %v items"
+	// MsgMaxItems is reported by max for slices, arrays and maps. Arguments: maximum number of items
+	MsgMaxItems = "must contain at most %v items"
+	// MsgLengthTooShort is reported by length. Arguments: actual length, minimum length
+	MsgLengthTooShort = "length (%d) must be at least %d"
+	// MsgLengthTooLong is reported by length. Arguments: actual length, maximum length
+	MsgLengthTooLong = "length (%d) must not exceed %d"
+	// MsgEnum is reported by enum
+	MsgEnum = "invalid value specified"
+	// MsgEnumValues is appended to MsgEnum when ValidationOptions.ExposeEnumValues is set. Arguments: comma separated values
+	MsgEnumValues = ". expected any of %s"
+	// MsgEnumField is reported by enum_field for values which are not among the options
+	MsgEnumField = "value not among provided options"
+	// MsgEnumFieldEmpty is reported by enum_field for non-empty values when no options are provided. Arguments: field name
+	MsgEnumFieldEmpty = "no options provided in %s"
+	// MsgPattern is reported by pattern. Arguments: pattern name
+	MsgPattern = "value does not match pattern %s"
+	// MsgPatternExpression is appended to MsgPattern when ValidationOptions.ExposePatterns is set. Arguments: regular expression
+	MsgPatternExpression = " (%s)"
+	// MsgDateFormat is reported by date validators for unparsable values. Arguments: layout
+	MsgDateFormat = "invalid date format. expected format is %s"
+	// MsgDateComparison is reported by at_least_today, at_most_today, today, before_today and after_today.
+	// Arguments: date, comparison, today
+	MsgDateComparison = "%s must be %s %s"
+	// MsgDateRange is reported by between_dates. Arguments: date, "between" or "strictly between", from, to
+	MsgDateRange = "%s must be %s %s and %s"
+	// MsgDateOfBirth is reported by dob for future dates
+	MsgDateOfBirth = "date of birth must be in the past"
+	// MsgAgeRange is reported by age_between and dob. Arguments: minimum age, maximum age
+	MsgAgeRange = "age must be between %d and %d"
+	// MsgAgeRangeWithValue replaces MsgAgeRange when ValidationOptions.IncludeFieldValues is set.
+	// Arguments: age, minimum age, maximum age
+	MsgAgeRangeWithValue = "age (%d) must be between %d and %d"
+	// MsgHostFormat is reported by resolvable for values which are neither URLs nor host names
+	MsgHostFormat = "invalid host"
+	// MsgHostUnresolvable is reported by resolvable. Arguments: host name
+	MsgHostUnresolvable = "host %s cannot be resolved"
+	// MsgWebhookURLFormat is reported by webhook_url for values which are not absolute URLs with a host
+	MsgWebhookURLFormat = "invalid webhook URL"
+	// MsgWebhookScheme is reported by webhook_url for URLs not using https, or http with allow_http
+	MsgWebhookScheme = "webhook URL must use https"
+	// MsgWebhookUserInfo is reported by webhook_url for URLs containing credentials
+	MsgWebhookUserInfo = "webhook URL must not contain credentials"
+	// MsgWebhookPort is reported by webhook_url for URLs using a port other than 443 or 8443. Arguments: port
+	MsgWebhookPort = "webhook URL port %s is not allowed, expected 443 or 8443"
+	// MsgWebhookPrivateHost is reported by webhook_url for literal IP hosts which are not public addresses
+	MsgWebhookPrivateHost = "webhook URL must not target a private, loopback or link-local address"
+	// MsgWebhookNumericHost is reported by webhook_url for numeric hosts which are not canonical IP addresses, e.g. 127.1
+	MsgWebhookNumericHost = "webhook URL host must be a canonical IP address or a host name"
+	// MsgFieldNotFound is reported by validators referencing unknown fields. Arguments: field name
+	MsgFieldNotFound = "field %s not found"
+	// MsgArgumentUnresolved is reported when a ${Field} argument cannot be resolved. Arguments: placeholder, reason
+	MsgArgumentUnresolved = "cannot resolve argument %s: %s"
+	// MsgEqualToField is reported by eqfield. Arguments: field name
+	MsgEqualToField = "must be equal to %s"
+	// MsgNotEqualToField is reported by nefield. Arguments: field name
+	MsgNotEqualToField = "must not be equal to %s"
+	// MsgGreaterThanField is reported by gtfield. Arguments: field name
+	MsgGreaterThanField = "must be greater than %s"
+	// MsgGreaterThanOrEqualToField is reported by gtefield. Arguments: field name
+	MsgGreaterThanOrEqualToField = "must be greater than or equal to %s"
+	// MsgLessThanField is reported by ltfield. Arguments: field name
+	MsgLessThanField = "must be less than %s"
+	// MsgLessThanOrEqualToField is reported by ltefield. Arguments: field name
+	MsgLessThanOrEqualToField = "must be less than or equal to %s"
+	// MsgFieldNotComparable is reported by eqfield, nefield, gtfield, gtefield, ltfield and ltefield for values that
+	// cannot be compared with the referenced field, such as a string with an integer. Arguments: field name
+	MsgFieldNotComparable = "cannot be compared with %s"
+	// MsgAllOrNone is reported by PresenceMatrix for AllOrNone groups. Arguments: comma separated field names
+	MsgAllOrNone = "%s must be provided together or not at all"
+	// MsgExactlyOne is reported by PresenceMatrix for ExactlyOne groups. Arguments: comma separated field names
+	MsgExactlyOne = "exactly one of %s must be provided"
+	// MsgAtMostOne is reported by PresenceMatrix for AtMostOne groups. Arguments: comma separated field names
+	MsgAtMostOne = "at most one of %s may be provided"
+	// MsgInvalidType is reported by ValidateInto for values of another type. Arguments: expected type, found type
+	MsgInvalidType = "expected %s but found %s"
+	// MsgInvalidValue is reported by ValidateInto for values that cannot be converted, such as fractions for
+	// integers. Arguments: expected type
+	MsgInvalidValue = "invalid %s value"
+	// MsgUnknownField is reported by ValidateInto for keys without a field, see ValidationOptions.RejectUnknownKeys
+	MsgUnknownField = "unknown field"
+)
+
+// MsgValidatorTimeout is reported for validators abandoned after ValidationOptions.ValidatorTimeout or the timeout
+// flag. Arguments: timeout
+const MsgValidatorTimeout = "validation did not complete within %s"
+
+// RedactedValue replaces the values of sensitive fields in error messages, see ValidationContext.Redact
+const RedactedValue = "[redacted]"
+
+// Messages reported instead of their current counterparts when ValidationOptions.LegacyMessages is set
+const (
+	// MsgRequiredLegacy replaces MsgRequired and MsgEmpty for the required validator
+	MsgRequiredLegacy = "this field is requiredd"
+	// MsgUUIDVersionMismatchLegacy replaces MsgUUIDVersionMismatch
+	MsgUUIDVersionMismatchLegacy = "expectedd UUIDv%d but found UUIDv%d"
+)
+
+// legacyMessage returns the legacy message if ValidationOptions.LegacyMessages is set, the current message otherwise
+func legacyMessage(opts *ValidationOptions, current string, legacy string) string {
+	if opts.LegacyMessages {
+		return legacy
+	}
+	return current
+}
@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type messageForm struct {
+	Required     *string   `validator:"required"`
+	NonZero      int       `validator:"nonzero"`
+	AlphaNumeric string    `validator:"alphanum"`
+	UUIDFormat   string    `validator:"uuid4"`
+	UUIDVersion  string    `validator:"uuid4"`
+	NilUUID      uuid.UUID `validator:"uuid_bytes(nonzero)"`
+	Min          int       `validator:"min(5)"`
+	Max          string    `validator:"max(2)"`
+	TooShort     string    `validator:"length(3,_)"`
+	TooLong      string    `validator:"length(_,1)"`
+	Enum         string    `validator:"enum(a,b)"`
+	DateFormat   string    `validator:"before_today"`
+	Comparison   string    `validator:"before_today"`
+	Range        string    `validator:"between_dates(2020-01-01,2020-12-31)"`
+	Birth        string    `validator:"dob"`
+	Age          string    `validator:"age_between(18,65)"`
+	Missing      string    `validator:"eqfield(Unknown)"`
+	Equal        string    `validator:"eqfield(Max)"`
+	RequiredIf   string    `validator:"required_if(Enum,c)"`
+	Absent       *string   `validator:"required_if(Enum,c)"`
+	Custom       string    `validator:"failing"`
+	Token        [4]byte   `validator:"required"`
+}
+
+func TestMessageConstants(t *testing.T) {
+	for _, legacy := range []bool{false, true} {
+		v := New(func(opts *ValidationOptions) {
+			opts.LegacyMessages = legacy
+			opts.Clock = func() time.Time {
+				return time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+			}
+		})
+		v.AddValidator("failing", func(ctx *ValidationContext) bool { return false })
+
+		form := messageForm{
+			AlphaNumeric: "$",
+			UUIDFormat:   "invalid",
+			UUIDVersion:  uuid.NewMD5(uuid.NameSpaceDNS, []byte("example.com")).String(),
+			Min:          1,
+			Max:          "abc",
+			TooShort:     "ab",
+			TooLong:      "ab",
+			Enum:         "c",
+			DateFormat:   "10/05/2023",
+			Comparison:   "2023-06-01",
+			Range:        "2021-01-01",
+			Birth:        "2024-01-01",
+			Age:          "2010-01-01",
+		}
+
+		required, empty, uuidVersion := MsgRequired, MsgEmpty, MsgUUIDVersionMismatch
+		if legacy {
+			required, empty, uuidVersion = MsgRequiredLegacy, MsgRequiredLegacy, MsgUUIDVersionMismatchLegacy
+		}
+
+		expected := []string{
+			required,
+			MsgNonZero,
+			MsgAlphaNumeric,
+			MsgUUIDFormat,
+			fmt.Sprintf(uuidVersion, 4, 3),
+			MsgNilUUID,
+			fmt.Sprintf(MsgMin, "value", 1, 5),
+			fmt.Sprintf(MsgMax, "length", "abc", 2),
+			fmt.Sprintf(MsgLengthTooShort, 2, 3),
+			fmt.Sprintf(MsgLengthTooLong, 2, 1),
+			MsgEnum,
+			fmt.Sprintf(MsgDateFormat, defaultLayout),
+			fmt.Sprintf(MsgDateComparison, "2023-06-01", LESS_THAN.TemporalDescription(), "2023-05-10"),
+			fmt.Sprintf(MsgDateRange, "2021-01-01", "between", "2020-01-01", "2020-12-31"),
+			MsgDateOfBirth,
+			fmt.Sprintf(MsgAgeRange, 18, 65),
+			fmt.Sprintf(MsgFieldNotFound, "Unknown"),
+			fmt.Sprintf(MsgEqualToField, "Max"),
+			MsgEmpty,
+			MsgRequired,
+			fmt.Sprintf(MsgFieldValidationFailed, "Custom"),
+			empty,
+		}
+
+		res := v.Validate(&form)
+		messages := make([]string, len(res.FieldErrors))
+		for i, fe := range res.FieldErrors {
+			messages[i] = fe.Message
+		}
+		assertEqual(t, expected, messages)
+
+		// the same message applies to blank values rejected by required
+		type Blank struct {
+			Name string `validator:"required"`
+		}
+		v.SetupOptions(func(opts *ValidationOptions) {
+			opts.RequiredRejectsZeroValues = true
+		})
+		assertEqual(t, empty, v.Validate(&Blank{}).FieldErrors[0].Message)
+	}
+}
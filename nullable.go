@@ -0,0 +1,158 @@
+package validator
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Nullable is implemented by types holding an optional value. Fields of such types are validated as the value Value
+// returns rather than traversed as structs, null values being treated like nil pointers: required fails with
+// CodeMissing while other validators pass, unless they reject nil pointers too.
+//
+// The type of the values is taken from the value Value returns for the zero value of the type, e.g. string for a
+// Nullable[string] type. Filters are not supported, as the value cannot be stored back.
+type Nullable interface {
+	IsNull() bool
+	Value() any
+}
+
+var (
+	nullableInterface = reflect.TypeOf((*Nullable)(nil)).Elem()
+	valuerInterface   = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	stringerInterface = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// nullableType describes how the values of a nullable type are unwrapped, see nullableOf
+type nullableType struct {
+	// valueType the type of the wrapped values
+	valueType reflect.Type
+	// valid and value are the indexes of the Valid and wrapped fields of sql null types, e.g. sql.NullString
+	valid, value int
+	// nullable indicates a type implementing Nullable, whose values are read through the interface
+	nullable bool
+}
+
+// implements tests whether values of the given type or pointers to them implement the given interface
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// nullableOf returns how to unwrap the values of the given type, pointers resolved, or nil if the type is not
+// nullable. Nullable types are either types implementing Nullable, or struct types implementing driver.Valuer laid out
+// like the null types of database/sql: a `Valid bool` field next to a single exported field holding the value, such
+// as sql.NullString, sql.NullInt64, sql.NullTime or sql.Null[T]. Other driver.Valuer types are validated as usual,
+// as many of them are structs stored as JSON whose fields carry their own rules.
+func nullableOf(t reflect.Type) *nullableType {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// interface fields, including Nullable fields, hold values of any type and are resolved at runtime
+	if t.Kind() == reflect.Interface {
+		return nil
+	}
+
+	if implements(t, nullableInterface) {
+		nt := &nullableType{nullable: true}
+		if value := reflect.New(t).Interface().(Nullable).Value(); value != nil {
+			nt.valueType = reflect.TypeOf(value)
+		} else {
+			nt.valueType = reflect.TypeOf((*any)(nil)).Elem()
+		}
+		return nt
+	}
+
+	if t.Kind() != reflect.Struct || t.NumField() != 2 || !implements(t, valuerInterface) {
+		return nil
+	}
+	for valid := 0; valid < 2; valid++ {
+		validField, valueField := t.Field(valid), t.Field(1-valid)
+		if validField.Name == "Valid" && validField.Type.Kind() == reflect.Bool && valueField.IsExported() {
+			return &nullableType{valueType: valueField.Type, valid: valid, value: 1 - valid}
+		}
+	}
+	return nil
+}
+
+// isValueType tests whether struct values of the given type are validated as values rather than traversed, because
+// they are time.Time values or of types defined as time.Time, have a numeric adapter or a type resolver, or are
+// nullable. Pointers are resolved.
+func (e *engine) isValueType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && t.ConvertibleTo(timeType) {
+		return true
+	}
+	if _, ok := e.lookupTypeResolver(t); ok {
+		return true
+	}
+	return e.isNumericType(t) || nullableOf(t) != nil
+}
+
+// unwrap returns the value held by the given field value of the nullable type, or a nil pointer to the type of the
+// wrapped values if the field value is null. Values of sql null types are returned as fields of the field value,
+// allowing filters to modify them.
+func (nt *nullableType) unwrap(value reflect.Value) reflect.Value {
+	null := reflect.Zero(reflect.PointerTo(nt.valueType))
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return null
+		}
+		value = value.Elem()
+	}
+
+	if !nt.nullable {
+		if !value.Field(nt.valid).Bool() {
+			return null
+		}
+		return value.Field(nt.value)
+	}
+
+	// pointer receivers require an addressable value, e.g. when validating a struct passed by value
+	if !value.Type().Implements(nullableInterface) {
+		if !value.CanAddr() {
+			copied := reflect.New(value.Type()).Elem()
+			copied.Set(value)
+			value = copied
+		}
+		value = value.Addr()
+	}
+	n := value.Interface().(Nullable)
+	wrapped := n.Value()
+	if n.IsNull() || wrapped == nil {
+		return null
+	}
+	if !reflect.TypeOf(wrapped).AssignableTo(nt.valueType) {
+		panic(newValidationError("nullable " + value.Type().String() + " holds a " + reflect.TypeOf(wrapped).String() +
+			" value but a " + nt.valueType.String() + " value was expected"))
+	}
+	unwrapped := reflect.New(nt.valueType).Elem()
+	unwrapped.Set(reflect.ValueOf(wrapped))
+	return unwrapped
+}
+
+// isValueField tests whether the given struct field of struct or struct pointer type is validated as a value rather
+// than traversed: fields of value types, see isValueType, and fields of types implementing fmt.Stringer carrying
+// validator, filter or rules tags, the tags being meant for the value itself.
+func (e *engine) isValueField(field reflect.StructField, opts *ValidationOptions) bool {
+	if e.isValueType(field.Type) {
+		return true
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || !implements(t, stringerInterface) {
+		return false
+	}
+	for _, option := range []string{opts.ValidatorTagName, opts.FilterTagName, opts.RulesTagName} {
+		if value, ok, _ := lookupTag(field.Tag, option, opts.CaseInsensitiveTagNames); ok && strings.TrimSpace(value) != "" && !isSkipMarker(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package validator
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// optional is a Nullable holding a value of any type
+type optional[T any] struct {
+	value T
+	set   bool
+}
+
+func (o optional[T]) IsNull() bool { return !o.set }
+func (o optional[T]) Value() any   { return o.value }
+
+func some[T any](value T) optional[T] {
+	return optional[T]{value: value, set: true}
+}
+
+// mixed is a Nullable whose values change type, holding a string when zero and an int otherwise
+type mixed struct {
+	n int
+}
+
+func (m mixed) IsNull() bool { return false }
+func (m mixed) Value() any {
+	if m.n == 0 {
+		return ""
+	}
+	return m.n
+}
+
+func TestNullableTypes(t *testing.T) {
+	type Customer struct {
+		Name     sql.NullString   `validator:"required|min(3)|max(10)" filter:"trim"`
+		Nickname sql.NullString   `validator:"min(3)"`
+		Age      sql.NullInt64    `validator:"min(18)|max(130)"`
+		Rating   *sql.NullInt32   `validator:"max(5)"`
+		Active   sql.NullBool     `validator:"required"`
+		Since    sql.NullTime     `validator:"required"`
+		Digit    sql.NullByte     `validator:"max(9)"`
+		Country  optional[string] `validator:"required|enum(MW,ZA)"`
+		Tier     optional[int]    `validator:"max(3)"`
+	}
+
+	v := New()
+	customer := Customer{
+		Name:    sql.NullString{String: "  Jane  ", Valid: true},
+		Age:     sql.NullInt64{Int64: 30, Valid: true},
+		Rating:  &sql.NullInt32{Int32: 5, Valid: true},
+		Active:  sql.NullBool{Valid: true},
+		Since:   sql.NullTime{Time: time.Now(), Valid: true},
+		Digit:   sql.NullByte{Byte: 9, Valid: true},
+		Country: some("MW"),
+	}
+	res := v.Validate(&customer)
+	assertTrue(t, res.IsValid(), "expected valid values to pass")
+	assertEqual(t, "Jane", customer.Name.String, "expected filters to apply to the wrapped value")
+
+	// null values fail required and pass other validators
+	res = v.Validate(&Customer{})
+	assertEqual(t, []FieldError{
+		{Field: "Name", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "Active", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "Since", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "Country", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+	}, res.FieldErrors)
+
+	// invalid values are checked like the wrapped values, null values being skipped by filters
+	customer = Customer{
+		Name:     sql.NullString{String: "Jo"},
+		Nickname: sql.NullString{String: "J", Valid: true},
+		Age:      sql.NullInt64{Int64: 12, Valid: true},
+		Rating:   &sql.NullInt32{Int32: 6, Valid: true},
+		Active:   sql.NullBool{Valid: true},
+		Since:    sql.NullTime{Valid: true},
+		Digit:    sql.NullByte{Byte: 10, Valid: true},
+		Country:  some("US"),
+		Tier:     some(4),
+	}
+	res = v.Validate(&customer)
+	fields := make([]string, 0, len(res.FieldErrors))
+	for _, fe := range res.FieldErrors {
+		fields = append(fields, fe.Field+" "+fe.Validator)
+	}
+	assertEqual(t, []string{"Name required", "Nickname min", "Age min", "Rating max", "Digit max", "Country enum", "Tier max"}, fields)
+	assertEqual(t, "value (12) must be at least 18", res.FieldErrors[2].Message)
+	assertEqual(t, "Jo", customer.Name.String)
+
+	// structs passed by value are unwrapped as well
+	assertTrue(t, v.Validate(struct {
+		Name sql.NullString `validator:"min(3)"`
+	}{}).IsValid(), "expected null values to pass in read-only mode")
+
+	// static checks see the kinds of the wrapped values
+	type Invalid struct {
+		Name sql.NullString `validator:"uuid4"`
+		Age  sql.NullInt64  `validator:"alphanum"`
+	}
+	problems := v.AnalyzeType(reflect.TypeOf(Invalid{}))
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "Age", problems[0].Field)
+
+	// Nullable values cannot be filtered
+	type Filtered struct {
+		Country optional[string] `filter:"trim"`
+	}
+	assert.PanicsWithError(t, "struct validator.Filtered, field Country, rule `trim`: filters cannot modify the values of Nullable type validator.optional[string]", func() {
+		v.Validate(&Filtered{})
+	})
+
+	// interface fields, including Nullable fields, are resolved at runtime
+	type Wrapper struct {
+		Plain  Nullable
+		Tagged Nullable `validator:"required"`
+	}
+	res = v.Validate(&Wrapper{Tagged: some("x")})
+	assertTrue(t, res.IsValid(), "expected interface fields of Nullable type to be validated")
+	res = v.Validate(&Wrapper{})
+	assertEqual(t, []FieldError{{Field: "Tagged", Message: MsgRequired, Validator: "required", Code: CodeMissing}}, res.FieldErrors)
+
+	// values of unexpected types are reported like panics of validators
+	type Mixed struct {
+		Value mixed `validator:"required"`
+	}
+	recovering := New(func(opts *ValidationOptions) {
+		opts.RecoverFromPanics = true
+	})
+	res = recovering.Validate(&Mixed{Value: mixed{n: 1}})
+	assertEqual(t, []FieldError{{Field: "Value", Message: "reading the value failed: nullable validator.mixed holds a int value but a string value was expected"}}, res.FieldErrors)
+}
@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// NumericAdapter compares values of a numeric type the packaged validators cannot compare by kind, such as
+// math/big.Int or decimal types, with the operands of min and max.
+//
+// Compare receives a pointer to the value, e.g. a *big.Int for big.Int and *big.Int fields, and returns a negative
+// number, zero or a positive number if the value is less than, equal to or greater than the operand. An error is
+// returned if the operand cannot be parsed.
+type NumericAdapter interface {
+	Compare(value reflect.Value, operand string) (int, error)
+}
+
+// numericAdapters lists the adapters registered with new instances
+var numericAdapters = map[reflect.Type]NumericAdapter{
+	reflect.TypeOf(big.Int{}):   bigIntAdapter{},
+	reflect.TypeOf(big.Float{}): bigFloatAdapter{},
+}
+
+// RegisterNumericAdapter RegisterNumericAdapter registers the given adapter for values of the given type, which min
+// and max consult before their kind based logic, e.g.
+//
+//	v.RegisterNumericAdapter(reflect.TypeOf(decimal.Decimal{}), decimalAdapter{})
+//
+// lets tags say `validator:"min(0.01)|max(1000000)"` on decimal.Decimal and *decimal.Decimal fields. Pointer types
+// are registered for the type they point to. Struct fields of registered types are validated as values instead of
+// being traversed as nested structs. Adapters for math/big.Int and math/big.Float are registered by default,
+// comparing values with operands exactly, e.g. min(0.5) or max(1e30). A nil adapter removes the registration.
+//
+// The function is safe to call concurrently with validation. Structs declaring fields of the given type are parsed
+// again upon their next validation.
+func (v *Validator) RegisterNumericAdapter(t reflect.Type, adapter NumericAdapter) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if adapter == nil {
+		delete(v.numericAdapters, t)
+	} else {
+		v.numericAdapters[t] = adapter
+	}
+	v.generation.Add(1)
+}
+
+// RegisterNumericAdapter RegisterNumericAdapter registers the given adapter for values of the given type with the
+// default instance.
+//
+// See Validator.RegisterNumericAdapter for details.
+func RegisterNumericAdapter(t reflect.Type, adapter NumericAdapter) {
+	defaultValidator.RegisterNumericAdapter(t, adapter)
+}
+
+// lookupNumericAdapter returns the adapter registered for the given type, pointers being resolved
+func (e *engine) lookupNumericAdapter(t reflect.Type) (adapter NumericAdapter, ok bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	adapter, ok = e.numericAdapters[t]
+	return
+}
+
+// isNumericType tests whether an adapter is registered for the given type, pointers being resolved
+func (e *engine) isNumericType(t reflect.Type) bool {
+	_, ok := e.lookupNumericAdapter(t)
+	return ok
+}
+
+// isNumericValidator tests whether the named validator consults numeric adapters
+func isNumericValidator(name string) bool {
+	return name == "min" || name == "max"
+}
+
+// checkNumericBound compares the input value with the bound given as argument using the adapter registered for its
+// type, if any, reporting the given message unless the result of the comparison is within the bound. Nil values
+// pass. adapted is false if there is no such adapter.
+func checkNumericBound(ctx *ValidationContext, message string, within func(result int) bool) (match bool, adapted bool) {
+	// contexts created outside of a validation call use the default instance
+	e := ctx.engine
+	if e == nil {
+		e = defaultValidator.engine
+	}
+	if ctx.ValueType == nil {
+		return false, false
+	}
+	adapter, ok := e.lookupNumericAdapter(ctx.ValueType)
+	if !ok {
+		return false, false
+	}
+	if ctx.IsNull {
+		return true, true
+	}
+
+	// adapters receive a pointer to the value, copying values that are not addressable
+	pointer := ctx.GetValue()
+	if pointer.CanAddr() {
+		pointer = pointer.Addr()
+	} else {
+		copied := reflect.New(pointer.Type())
+		copied.Elem().Set(pointer)
+		pointer = copied
+	}
+	result, err := adapter.Compare(pointer, ctx.Args[0])
+	if err != nil {
+		panic(newValidationError("invalid operand "+ctx.Args[0]+" for "+ctx.ValueType.String()+" values", err))
+	}
+	if !within(result) {
+		ctx.ErrorMessage = fmt.Sprintf(message, "value", ctx.Redact(pointer.Interface()), ctx.Args[0])
+		return false, true
+	}
+	return true, true
+}
+
+// parseOperand parses the given operand as an exact rational number, e.g. 10, -0.25 or 1e30
+func parseOperand(operand string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(operand)
+	if !ok {
+		return nil, errors.New("expected a number but found " + operand)
+	}
+	return r, nil
+}
+
+// bigIntAdapter compares math/big.Int values
+type bigIntAdapter struct{}
+
+func (bigIntAdapter) Compare(value reflect.Value, operand string) (int, error) {
+	r, err := parseOperand(operand)
+	if err != nil {
+		return 0, err
+	}
+	x, ok := value.Interface().(*big.Int)
+	if !ok {
+		return 0, fmt.Errorf("expected *big.Int but found %s", value.Type())
+	}
+	return new(big.Rat).SetInt(x).Cmp(r), nil
+}
+
+// bigFloatAdapter compares math/big.Float values, infinite values being less or greater than any operand
+type bigFloatAdapter struct{}
+
+func (bigFloatAdapter) Compare(value reflect.Value, operand string) (int, error) {
+	r, err := parseOperand(operand)
+	if err != nil {
+		return 0, err
+	}
+	x, ok := value.Interface().(*big.Float)
+	if !ok {
+		return 0, fmt.Errorf("expected *big.Float but found %s", value.Type())
+	}
+	if x.IsInf() {
+		return x.Sign(), nil
+	}
+	exact, _ := x.Rat(nil)
+	return exact.Cmp(r), nil
+}
@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// centsAdapter compares amounts held as strings of cents, e.g. "1250" for 12.50, with operands in units
+type centsAdapter struct{}
+
+type cents struct {
+	Value string
+}
+
+func (centsAdapter) Compare(value reflect.Value, operand string) (int, error) {
+	amount, ok := new(big.Rat).SetString(value.Interface().(*cents).Value)
+	if !ok {
+		return 0, errors.New("invalid amount")
+	}
+	bound, ok := new(big.Rat).SetString(operand)
+	if !ok {
+		return 0, errors.New("invalid operand")
+	}
+	return amount.Cmp(bound.Mul(bound, big.NewRat(100, 1))), nil
+}
+
+func TestNumericAdapters(t *testing.T) {
+	type Transfer struct {
+		Amount  *big.Int   `validator:"min(1)|max(100000000000000000000)"`
+		Balance big.Int    `validator:"min(-0.5)"`
+		Rate    *big.Float `validator:"min(0.001)|max(1.5)"`
+	}
+
+	v := New()
+	big20, _ := new(big.Int).SetString("100000000000000000000", 10)
+	transfer := Transfer{Amount: big20, Rate: big.NewFloat(1.5)}
+	assertTrue(t, v.Validate(&transfer).IsValid(), "expected values at the bounds to pass")
+	assertTrue(t, v.Validate(&Transfer{}).IsValid(), "expected nil values to pass")
+
+	transfer = Transfer{Amount: new(big.Int).Add(big20, big.NewInt(1)), Balance: *big.NewInt(-1), Rate: big.NewFloat(0.0001)}
+	res := v.Validate(&transfer)
+	assertEqual(t, []FieldError{
+		{Field: "Amount", Message: "value (100000000000000000001) must not exceed 100000000000000000000", Validator: "max"},
+		{Field: "Balance", Message: "value (-1) must be at least -0.5", Validator: "min"},
+		{Field: "Rate", Message: "value (0.0001) must be at least 0.001", Validator: "min"},
+	}, res.FieldErrors)
+
+	res = v.Validate(&Transfer{Amount: big.NewInt(0), Rate: new(big.Float).SetInf(true)})
+	assertEqual(t, 2, len(res.FieldErrors))
+	assertEqual(t, "min", res.FieldErrors[0].Validator)
+	assertEqual(t, "Rate", res.FieldErrors[1].Field)
+
+	// static checks accept the bounds and kinds of adapted types
+	assertEqual(t, 0, len(v.AnalyzeType(reflect.TypeOf(Transfer{}))))
+
+	// operands the adapter cannot parse panic when validating, like invalid integer bounds
+	type Invalid struct {
+		Amount *big.Int `validator:"min(one)"`
+	}
+	assert.PanicsWithError(t, "invalid operand one for big.Int values: expected a number but found one", func() {
+		v.Validate(&Invalid{Amount: big.NewInt(1)})
+	})
+
+	// adapters for custom types, registered by value or pointer type
+	type Invoice struct {
+		Total    cents  `validator:"min(0.01)|max(1000)"`
+		Discount *cents `validator:"max(10)"`
+	}
+	type Unadapted struct {
+		Total cents `validator:"min(1)"`
+	}
+	custom := New()
+	assertTrue(t, custom.Validate(&Unadapted{}).IsValid(), "expected unadapted struct fields to be traversed")
+
+	custom.RegisterNumericAdapter(reflect.TypeOf(&cents{}), centsAdapter{})
+	assertTrue(t, custom.Validate(&Invoice{Total: cents{"1"}, Discount: &cents{"1000"}}).IsValid(), "expected the custom adapter to apply")
+	res = custom.Validate(&Invoice{Total: cents{"0"}, Discount: &cents{"1001"}})
+	assertEqual(t, []FieldError{
+		{Field: "Total", Message: "value (&{0}) must be at least 0.01", Validator: "min"},
+		{Field: "Discount", Message: "value (&{1001}) must not exceed 10", Validator: "max"},
+	}, res.FieldErrors)
+
+	// adapters are registered per instance, and removed by registering nil
+	assertTrue(t, v.Validate(&Unadapted{}).IsValid(), "expected adapters to be registered per instance")
+	custom.RegisterNumericAdapter(reflect.TypeOf(big.Int{}), nil)
+	assert.Panics(t, func() { custom.Validate(&Transfer{Amount: big.NewInt(0)}) }, "expected big.Int values to be unsupported without an adapter")
+}
@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"math"
+	"strconv"
+)
+
+// SchemaExtension the name of the OpenAPI vendor extension written by AttachToSchema
+const SchemaExtension = "x-validation"
+
+// Extensions Extensions returns a JSON marshalable representation of the rule, meant for vendor extensions of API
+// schemas such as OpenAPI's x-validation, e.g.
+//
+//	{
+//	  "field": "Name",
+//	  "validators": [{"name": "required"}, {"name": "length", "args": [3, 80]}],
+//	  "filters": [{"name": "trim"}],
+//	  "flags": ["omitempty"],
+//	  "triggers": ["create", "update"],
+//	  "message": "name is required"
+//	}
+//
+// Validators and filters keep their order of execution, flags and triggers are sorted. Arguments are converted to
+// numbers or booleans where they parse as such, and kept as strings otherwise, e.g. _ or ${Min}. Empty entries are
+// omitted, except for validators. The representation only uses maps, slices, strings, numbers and booleans, so that
+// it marshals the same way every time.
+func (r FieldRule) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{
+		"validators": functionExtensions(r.Validators),
+	}
+	if r.Field != "" {
+		extensions["field"] = r.Field
+	}
+	if len(r.Filters) > 0 {
+		extensions["filters"] = functionExtensions(r.Filters)
+	}
+	if len(r.Flags) > 0 {
+		flags := make([]string, len(r.Flags))
+		for i, flag := range r.Flags {
+			flags[i] = string(flag)
+		}
+		extensions["flags"] = toInterfaces(sortedUnique(flags))
+	}
+	if len(r.Triggers) > 0 {
+		extensions["triggers"] = toInterfaces(sortedUnique(r.Triggers))
+	}
+	if r.Message != "" {
+		extensions["message"] = r.Message
+	}
+	return extensions
+}
+
+// AttachToSchema AttachToSchema writes the extensions of the given rules, see FieldRule.Extensions, into the given
+// schema node as an array named x-validation, replacing any previous value. The entry is removed if there are no
+// rules. Schema nodes are typically the properties of an object schema, e.g.
+//
+//	rules, _ := validator.Rules(&Person{})
+//	for _, rule := range rules {
+//		if property, ok := properties[rule.Field].(map[string]interface{}); ok {
+//			validator.AttachToSchema(property, []validator.FieldRule{rule})
+//		}
+//	}
+func AttachToSchema(schema map[string]interface{}, rules []FieldRule) {
+	if len(rules) == 0 {
+		delete(schema, SchemaExtension)
+		return
+	}
+	extensions := make([]interface{}, len(rules))
+	for i, rule := range rules {
+		extensions[i] = rule.Extensions()
+	}
+	schema[SchemaExtension] = extensions
+}
+
+// functionExtensions returns the representation of the given validators or filters, see FieldRule.Extensions
+func functionExtensions(functions []FunctionRule) []interface{} {
+	extensions := make([]interface{}, len(functions))
+	for i, function := range functions {
+		extension := map[string]interface{}{"name": function.Name}
+		if len(function.Args) > 0 {
+			args := make([]interface{}, len(function.Args))
+			for j, arg := range function.Args {
+				args[j] = typedArgument(arg)
+			}
+			extension["args"] = args
+		}
+		extensions[i] = extension
+	}
+	return extensions
+}
+
+// typedArgument converts the given argument to an int64, a float64 or a bool if it parses as such. Other arguments,
+// including infinite and NaN values which JSON cannot represent, are returned as they are.
+func typedArgument(arg string) interface{} {
+	if i, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(arg, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f
+	}
+	if arg == "true" || arg == "false" {
+		return arg == "true"
+	}
+	return arg
+}
+
+// toInterfaces converts the given strings to a slice of interface values
+func toInterfaces(values []string) []interface{} {
+	converted := make([]interface{}, len(values))
+	for i, value := range values {
+		converted[i] = value
+	}
+	return converted
+}
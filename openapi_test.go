@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+type openAPIAddress struct {
+	Street string `validator:"length(1,120)" filter:"trim"`
+	Zip    string `validator:"length(5,5)|enum('0,0',99999)"`
+}
+
+type openAPIAccount struct {
+	Name      string   `validator:"required|length(3,80)" filter:"trim" flags:"omitempty" trigger:"update,create" message:"name must have 3 to 80 characters"`
+	Age       int      `validator:"min(18)|max(130)"`
+	Ratio     float64  `validator:"max(${Age})"`
+	Role      string   `validator:"enum(admin,user,true,0.5)"`
+	Nickname  *string  `validator:"length(_,20)" flags:"sensitive|omitempty" trigger:"!import"`
+	Tags      []string `filter:"trim"`
+	Address   openAPIAddress
+	Addresses []openAPIAddress
+}
+
+func TestSchemaExtensionsGolden(t *testing.T) {
+	rules, err := New().Rules(&openAPIAccount{})
+	assert.NoError(t, err)
+
+	schema := map[string]interface{}{"type": "object"}
+	properties := map[string]interface{}{}
+	for _, rule := range rules {
+		property := map[string]interface{}{}
+		AttachToSchema(property, []FieldRule{rule})
+		properties[rule.Struct+"."+rule.Field] = property
+	}
+	schema["properties"] = properties
+
+	actual, err := json.MarshalIndent(schema, "", "  ")
+	assert.NoError(t, err)
+	actual = append(actual, '\n')
+
+	golden := filepath.Join("testdata", "x-validation.golden.json")
+	if *updateGolden {
+		assert.NoError(t, os.WriteFile(golden, actual, 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	assert.NoError(t, err)
+	assertEqual(t, string(expected), string(actual))
+
+	// the representation is stable
+	again, _ := json.MarshalIndent(schema, "", "  ")
+	assertEqual(t, string(actual[:len(actual)-1]), string(again))
+}
+
+func TestSchemaExtensions(t *testing.T) {
+	rule := FieldRule{
+		Validators: []FunctionRule{{Name: "between", Args: []string{"-3", "1e3", "_", "Inf", "True"}}},
+		Flags:      []ValidationFlag{Sensitive, OmitEmpty, Sensitive},
+	}
+	assertEqual(t, map[string]interface{}{
+		"validators": []interface{}{
+			map[string]interface{}{"name": "between", "args": []interface{}{int64(-3), float64(1000), "_", "Inf", "True"}},
+		},
+		"flags": []interface{}{"omitempty", "sensitive"},
+	}, rule.Extensions())
+	assertEqual(t, map[string]interface{}{"validators": []interface{}{}}, FieldRule{}.Extensions())
+
+	schema := map[string]interface{}{"type": "string", SchemaExtension: "stale"}
+	AttachToSchema(schema, []FieldRule{rule, {Validators: []FunctionRule{{Name: "required"}}}})
+	assertEqual(t, 2, len(schema[SchemaExtension].([]interface{})))
+
+	AttachToSchema(schema, nil)
+	assertEqual(t, map[string]interface{}{"type": "string"}, schema)
+}
@@ -0,0 +1,46 @@
+package validator
+
+import "context"
+
+// validatorOverridesKey is the context key of the validator overrides, see WithValidatorOverride
+type validatorOverridesKey struct{}
+
+// WithValidatorOverride WithValidatorOverride returns a copy of the given context in which validations started with
+// ValidateContext call the given function instead of the validator registered under the given name, e.g. to stub a
+// validator performing I/O in a test:
+//
+//	ctx := validator.WithValidatorOverride(context.Background(), "resolvable", func(*validator.ValidationContext) bool {
+//		return true
+//	})
+//	res := v.ValidateContext(ctx, &webhook)
+//
+// The validator must still be registered, as tags are checked against the registered validators, and only the
+// function called is replaced: arguments, messages and flags are unchanged. Overrides are looked up upon each call and
+// never cached, so validations running concurrently with different contexts each see their own overrides. Overrides
+// accumulate across derived contexts, the latest taking precedence, and a nil function restores the registered
+// validator.
+func WithValidatorOverride(ctx context.Context, name string, fn ValidationFunction) context.Context {
+	parent, _ := ctx.Value(validatorOverridesKey{}).(map[string]ValidationFunction)
+	// contexts are immutable, the map of the parent context must not be modified
+	overrides := make(map[string]ValidationFunction, len(parent)+1)
+	for key, override := range parent {
+		overrides[key] = override
+	}
+	overrides[name] = fn
+	return context.WithValue(ctx, validatorOverridesKey{}, overrides)
+}
+
+// validatorOverrides returns the validator overrides of the given context, nil if it has none
+func validatorOverrides(ctx context.Context) map[string]ValidationFunction {
+	overrides, _ := ctx.Value(validatorOverridesKey{}).(map[string]ValidationFunction)
+	return overrides
+}
+
+// validatorFunction returns the function to call for the given validator, its override if the validation context has
+// one, see WithValidatorOverride
+func (state *validationState) validatorFunction(validator *fieldValueValidator) ValidationFunction {
+	if override := state.overrides[validator.name]; override != nil {
+		return override
+	}
+	return validator.fn
+}
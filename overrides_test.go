@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithValidatorOverride(t *testing.T) {
+	type Account struct {
+		Handle string `validator:"available"`
+	}
+
+	v := New()
+	v.AddValidator("available", func(ctx *ValidationContext) bool {
+		return ctx.GetValue().String() != "admin"
+	})
+	account := Account{Handle: "jane"}
+
+	t.Run("rejecting", func(t *testing.T) {
+		t.Parallel()
+		ctx := WithValidatorOverride(context.Background(), "available", func(*ValidationContext) bool { return false })
+		for i := 0; i < 100; i++ {
+			res := v.ValidateContext(ctx, &account)
+			assertEqual(t, 1, len(res.FieldErrors))
+			assertEqual(t, "available", res.FieldErrors[0].Validator)
+		}
+	})
+
+	t.Run("accepting", func(t *testing.T) {
+		t.Parallel()
+		ctx := WithValidatorOverride(context.Background(), "available", func(*ValidationContext) bool { return true })
+		for i := 0; i < 100; i++ {
+			assertTrue(t, v.ValidateContext(ctx, &Account{Handle: "admin"}).IsValid())
+		}
+	})
+
+	t.Run("unaffected", func(t *testing.T) {
+		t.Parallel()
+		for i := 0; i < 100; i++ {
+			assertTrue(t, v.Validate(&account).IsValid(), "expected the registered validator without override")
+			assertFalse(t, v.ValidateContext(context.Background(), &Account{Handle: "admin"}).IsValid())
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		rejecting := WithValidatorOverride(context.Background(), "available", func(*ValidationContext) bool { return false })
+		restored := WithValidatorOverride(rejecting, "available", nil)
+		assertTrue(t, v.ValidateContext(restored, &account).IsValid(), "expected nil to restore the registered validator")
+		assertFalse(t, v.ValidateContext(rejecting, &account).IsValid(), "expected the parent context to be unchanged")
+	})
+}
@@ -0,0 +1,115 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// fieldOutcome holds the outcome of applying a field context concurrently, see applyConcurrently
+type fieldOutcome struct {
+	applied  bool
+	errors   []FieldError
+	err      *ValidationError
+	restores []func()
+}
+
+// merge records the top level error and the restore functions of the outcome into the given state, returning the
+// field errors of the outcome
+func (o fieldOutcome) merge(state *validationState) []FieldError {
+	if state.res.Error == nil && o.err != nil {
+		state.res.Error = o.err
+	}
+	state.restores = append(state.restores, o.restores...)
+	return o.errors
+}
+
+// independent tests whether applying the field only reads and modifies the value of the field itself: none of its
+// validators and filters reference other fields, through field arguments of packaged validators such as
+// eqfield(Password) or through ${Field} placeholders. Validators replaced by custom functions, including the overrides
+// of the validation context, are custom functions, which must not read other fields.
+func (fc *fieldContext) independent(state *validationState) bool {
+	for _, validator := range fc.validators {
+		if hasPlaceholders(validator.args) {
+			return false
+		}
+		if meta, ok := validatorMetadata[validator.name]; ok && meta.fieldArgument && sameFunction(state.validatorFunction(validator), validatorFunctions[validator.name]) {
+			return false
+		}
+	}
+	for _, filter := range fc.filters {
+		if hasPlaceholders(filter.args) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyConcurrently applies the activated, independent field contexts of the given struct value using
+// ValidationOptions.Parallelism workers. Outcomes are returned by position in fieldContexts, fields which were not
+// applied being left to the caller.
+//
+// Each field is applied with a copy of the state collecting its top level error and restore functions, which the
+// caller merges in declaration order, keeping results deterministic. Once a field failed, workers stop picking up
+// fields if ValidationOptions.StopOnFirstError is set. A panic raised by a worker is raised again once all workers
+// are done.
+func (v *Validator) applyConcurrently(state *validationState, structValue reflect.Value, path string, fieldContexts []*fieldContext) []fieldOutcome {
+	outcomes := make([]fieldOutcome, len(fieldContexts))
+
+	var pending []int
+	for i, fc := range fieldContexts {
+		if fc.activate(state.triggers) && fc.independent(state) && state.selection.applies(fc.containerPath(path)) {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) < 2 {
+		return outcomes
+	}
+
+	workers := state.opts.Parallelism
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+
+	var next atomic.Int64
+	var failed atomic.Bool
+	var panicOnce sync.Once
+	var panicked interface{}
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() {
+						panicked = r
+					})
+					failed.Store(true)
+				}
+			}()
+			for {
+				n := int(next.Add(1)) - 1
+				if n >= len(pending) || state.ctx.Err() != nil || (failed.Load() && state.opts.StopOnFirstError) {
+					return
+				}
+				i := pending[n]
+				local := *state
+				local.res = &ValidationResult{}
+				local.restores = nil
+				errorList := fieldContexts[i].apply(&local, structValue, path)
+				outcomes[i] = fieldOutcome{applied: true, errors: errorList, err: local.res.Error, restores: local.restores}
+				if len(errorList) > 0 {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if panicked != nil {
+		panic(panicked)
+	}
+	return outcomes
+}
@@ -0,0 +1,213 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type parallelAccount struct {
+	Name     string `validator:"length(3,_)" filter:"trim"`
+	Email    string `validator:"email" filter:"trim"`
+	Password string `validator:"length(8,_)"`
+	Confirm  string `validator:"eqfield(Password)"`
+	Min      int    `validator:"min(1)"`
+	Max      int    `validator:"max(${Min})"`
+	Country  string `validator:"enum(MW,ZA)" filter:"trim"`
+	Nickname string `validator:"slow|length(_,10)" filter:"trim"`
+	Profile  parallelProfile
+	Aliases  []parallelProfile
+}
+
+type parallelProfile struct {
+	Bio     string `validator:"slow|length(_,20)" filter:"trim"`
+	Website string `validator:"slow|length(_,30)"`
+}
+
+// TestParallelism compares concurrent validation with sequential validation. Run with -race to check that fields
+// validated concurrently do not share state.
+func TestParallelism(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	slow := func(ctx *ValidationContext) bool {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			max := maxRunning.Load()
+			if n <= max || maxRunning.CompareAndSwap(max, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		return true
+	}
+	newValidator := func(parallelism int) *Validator {
+		v := New(func(opts *ValidationOptions) {
+			opts.Parallelism = parallelism
+		})
+		v.AddValidator("slow", slow)
+		return v
+	}
+
+	newAccount := func() parallelAccount {
+		return parallelAccount{
+			Name:     " Jo ",
+			Email:    " jo@example.com ",
+			Password: "secret",
+			Confirm:  "secrets",
+			Min:      5,
+			Max:      3,
+			Country:  " mw ",
+			Nickname: " a nickname too long ",
+			Profile:  parallelProfile{Bio: " bio ", Website: strings.Repeat("w", 31)},
+			Aliases:  []parallelProfile{{Bio: strings.Repeat("b", 21)}, {Bio: " ok "}},
+		}
+	}
+
+	sequential := newAccount()
+	expected := newValidator(0).Validate(&sequential)
+	assertFalse(t, expected.IsValid(), "expected errors")
+	assertEqual(t, int32(1), maxRunning.Load())
+
+	for _, parallelism := range []int{2, 4, 16} {
+		maxRunning.Store(0)
+		account := newAccount()
+		res := newValidator(parallelism).Validate(&account)
+		assertEqual(t, expected.FieldErrors, res.FieldErrors, fmt.Sprint(parallelism))
+		assertEqual(t, sequential, account, fmt.Sprint(parallelism))
+		assertTrue(t, maxRunning.Load() > 1, "expected fields to be validated concurrently")
+	}
+}
+
+func TestParallelismStopOnFirstError(t *testing.T) {
+	type Form struct {
+		A string `validator:"length(3,_)"`
+		B string `validator:"length(3,_)"`
+		C string `validator:"length(3,_)"`
+		D string `validator:"length(3,_)"`
+	}
+	v := New(func(opts *ValidationOptions) {
+		opts.Parallelism = 4
+		opts.StopOnFirstError = true
+	})
+	res := v.Validate(&Form{A: "abc", B: "b", C: "c", D: "d"})
+	assertEqual(t, []FieldError{{Field: "B", Message: "length (1) must be at least 3", Validator: "length"}}, res.FieldErrors)
+}
+
+func TestParallelismPanics(t *testing.T) {
+	type Form struct {
+		A string `validator:"boom"`
+		B string `validator:"boom"`
+		C string `validator:"length(3,_)"`
+	}
+	newValidator := func(recover bool) *Validator {
+		v := New(func(opts *ValidationOptions) {
+			opts.Parallelism = 2
+			opts.RecoverFromPanics = recover
+		})
+		v.AddValidator("boom", func(ctx *ValidationContext) bool {
+			panic("boom")
+		})
+		return v
+	}
+
+	// panics of workers are raised by the validation call
+	assert.PanicsWithValue(t, "boom", func() {
+		newValidator(false).Validate(&Form{})
+	})
+
+	res := newValidator(true).Validate(&Form{})
+	assertEqual(t, "A: validator boom panicked: boom", res.Error.Error())
+	assertEqual(t, []FieldError{{Field: "C", Message: "length (0) must be at least 3", Validator: "length"}}, res.FieldErrors)
+
+	invalid := New(func(opts *ValidationOptions) {
+		opts.Parallelism = -1
+	})
+	assertEqual(t, "invalid options: Parallelism must not be negative", invalid.Validate(&Form{}).Error.Error())
+}
+
+type benchmarkConfig struct {
+	F1  string `validator:"pattern"`
+	F2  string `validator:"pattern"`
+	F3  string `validator:"pattern"`
+	F4  string `validator:"pattern"`
+	F5  string `validator:"pattern"`
+	F6  string `validator:"pattern"`
+	F7  string `validator:"pattern"`
+	F8  string `validator:"pattern"`
+	F9  string `validator:"pattern"`
+	F10 string `validator:"pattern"`
+	F11 string `validator:"pattern"`
+	F12 string `validator:"pattern"`
+	F13 string `validator:"pattern"`
+	F14 string `validator:"pattern"`
+	F15 string `validator:"pattern"`
+	F16 string `validator:"pattern"`
+}
+
+// BenchmarkParallelism validates a struct whose fields use an expensive custom validator, compiling a regular
+// expression on every call, sequentially and concurrently
+func BenchmarkParallelism(b *testing.B) {
+	pattern := func(ctx *ValidationContext) bool {
+		expr := regexp.MustCompile(`^(?:[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}|\+?[0-9]{7,15}|[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30})$`)
+		return expr.MatchString(ctx.GetValue().String())
+	}
+	config := benchmarkConfig{}
+	for i := 0; i < 16; i++ {
+		reflect.ValueOf(&config).Elem().Field(i).SetString(fmt.Sprintf("user%d@example.com", i))
+	}
+
+	for _, parallelism := range []int{0, 4, 16} {
+		v := New(func(opts *ValidationOptions) {
+			opts.Parallelism = parallelism
+		})
+		v.AddValidator("pattern", pattern)
+		b.Run(fmt.Sprintf("parallelism=%d", parallelism), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !v.Validate(&config).IsValid() {
+					b.Fatal("validation failed")
+				}
+			}
+		})
+	}
+}
+
+func TestIndependentFields(t *testing.T) {
+	type Form struct {
+		Password string
+		Confirm  string `validator:"eqfield(Password)"`
+		Limit    int    `validator:"max(${Max})"`
+		Max      int
+	}
+
+	independent := func(v *Validator, overrides map[string]ValidationFunction) []bool {
+		opts := v.currentOptions()
+		contexts, err := v.getStructFields(reflect.TypeOf(Form{}), &opts)
+		assertNull(t, err)
+		state := &validationState{opts: &opts, overrides: overrides}
+		result := make([]bool, len(contexts))
+		for i, fc := range contexts {
+			result[i] = fc.independent(state)
+		}
+		return result
+	}
+	stub := func(*ValidationContext) bool { return true }
+
+	// packaged validators referencing fields and placeholders depend on other fields
+	assertEqual(t, []bool{false, false}, independent(New(), nil))
+
+	// replaced validators are custom functions
+	replaced := New(func(opts *ValidationOptions) {
+		opts.NoPanicOnFunctionConflict = true
+	})
+	replaced.AddValidator("eqfield", stub)
+	assertEqual(t, []bool{true, false}, independent(replaced, nil))
+	assertEqual(t, []bool{true, false}, independent(New(), map[string]ValidationFunction{"eqfield": stub}))
+	assertEqual(t, []bool{false, false}, independent(replaced, map[string]ValidationFunction{"eqfield": IsEqualToField}))
+}
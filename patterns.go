@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// RegisterPattern RegisterPattern compiles the given regular expression and registers it under the given name for
+// the pattern validator, e.g.
+//
+//	v.RegisterPattern("sku", `^[A-Z]{3}-\d{6}$`)
+//
+// lets tags say `validator:"pattern(sku)"` instead of embedding the expression, whose commas and parentheses would
+// need quoting. Tags referencing unknown patterns are reported when structs are parsed, like unknown validators.
+//
+// The function is safe to call concurrently with validation. Registering a pattern again replaces it, and structs
+// referencing it are parsed again upon their next validation. An error is returned if the name is not made of
+// letters, digits, '_', '-' and '.', or if the expression does not compile.
+func (v *Validator) RegisterPattern(name string, expr string) error {
+	if !isRuleSetName(name) {
+		return newValidationError("invalid pattern name `" + name + "`")
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return newValidationError("invalid pattern `"+name+"`", err)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.patterns == nil {
+		v.patterns = make(map[string]*regexp.Regexp)
+	}
+	v.patterns[name] = re
+	v.generation.Add(1)
+	return nil
+}
+
+// RegisterPattern RegisterPattern compiles the given regular expression and registers it under the given name with
+// the default instance.
+//
+// See Validator.RegisterPattern for details.
+func RegisterPattern(name string, expr string) error {
+	return defaultValidator.RegisterPattern(name, expr)
+}
+
+// lookupPattern returns the regular expression registered under the given name
+func (e *engine) lookupPattern(name string) (re *regexp.Regexp, ok bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	re, ok = e.patterns[name]
+	return
+}
+
+// IsPattern tests if the input string matches the regular expression registered under the name given as argument,
+// see Validator.RegisterPattern. Nil values pass.
+//
+// The error message names the pattern. The expression is appended if ValidationOptions.ExposePatterns is set.
+func IsPattern(ctx *ValidationContext) bool {
+	if ctx.ArgCount() != 1 {
+		panic(newValidationError("pattern: expected the name of a registered pattern"))
+	}
+	name := ctx.Args[0]
+
+	// contexts created outside of a validation call use the default instance
+	e := ctx.engine
+	if e == nil {
+		e = defaultValidator.engine
+	}
+	re, ok := e.lookupPattern(name)
+	if !ok {
+		panic(newValidationError("pattern: pattern `" + name + "` not found"))
+	}
+
+	if ctx.IsNull {
+		return true
+	}
+	if !ctx.IsValueOfKind(reflect.String) {
+		panic(newValidationError("pattern: unsupported type " + ctx.valueKind.String()))
+	}
+
+	if !re.MatchString(ctx.GetValue().String()) {
+		ctx.ErrorMessage = fmt.Sprintf(MsgPattern, name)
+		if ctx.Options.ExposePatterns {
+			ctx.ErrorMessage += fmt.Sprintf(MsgPatternExpression, re.String())
+		}
+		return false
+	}
+	return true
+}
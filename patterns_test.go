@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestPatterns(t *testing.T) {
+	type Product struct {
+		Sku    string  `validator:"pattern(sku)"`
+		Ticket *string `validator:"pattern(ticket)"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.PanicOnTagError = false
+	})
+
+	// unknown patterns are reported when parsing
+	res := v.Validate(&Product{})
+	assertEqual(t, "struct validator.Product, field Sku, rule `pattern(sku)`: pattern `sku` not found", res.Error.Error())
+
+	assertNull(t, v.RegisterPattern("sku", `^[A-Z]{3}-\d{6}$`))
+	assertNull(t, v.RegisterPattern("ticket", `^T-\d+$`))
+
+	// structs are parsed again once the patterns are registered, nil values pass
+	res = v.Validate(&Product{Sku: "ABC-123456"})
+	assertTrue(t, res.IsValid(), "expected the pattern to match")
+
+	ticket := "t-1"
+	res = v.Validate(&Product{Sku: "abc-123456", Ticket: &ticket})
+	assertEqual(t, []FieldError{
+		{Field: "Sku", Message: "value does not match pattern sku", Validator: "pattern"},
+		{Field: "Ticket", Message: "value does not match pattern ticket", Validator: "pattern"},
+	}, res.FieldErrors)
+
+	// the expression is only exposed on request
+	var opts ValidationOptions
+	v.CopyOptions(&opts)
+	opts.ExposePatterns = true
+	res = v.ValidateWithOptions(&Product{Sku: "ABC"}, opts)
+	assertEqual(t, "value does not match pattern sku (^[A-Z]{3}-\\d{6}$)", res.FieldErrors[0].Message)
+
+	// registering a pattern again replaces it
+	assertNull(t, v.RegisterPattern("sku", `^[a-z]{3}-\d{6}$`))
+	assertTrue(t, v.Validate(&Product{Sku: "abc-123456"}).IsValid(), "expected the replaced pattern to apply")
+
+	// invalid names and expressions
+	assertEqual(t, "invalid pattern name `a b`", v.RegisterPattern("a b", ".").Error())
+	assertEqual(t, "invalid pattern `broken`: error parsing regexp: missing closing ): `(`", v.RegisterPattern("broken", "(").Error())
+
+	// other kinds are reported by static checks
+	type Invalid struct {
+		Count int `validator:"pattern(sku)"`
+	}
+	problems := v.AnalyzeType(reflect.TypeOf(Invalid{}))
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "validator `pattern` does not support int values", problems[0].Message)
+
+	// patterns are registered per instance
+	res = New(func(opts *ValidationOptions) { opts.PanicOnTagError = false }).Validate(&Product{})
+	assertEqual(t, "struct validator.Product, field Sku, rule `pattern(sku)`: pattern `sku` not found", res.Error.Error())
+
+	// registration is safe concurrently with validation
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = v.RegisterPattern("sku", `^[a-z]{3}-\d{6}$`)
+		}()
+		go func() {
+			defer wg.Done()
+			v.Validate(&Product{Sku: "abc-123456"})
+		}()
+	}
+	wg.Wait()
+}
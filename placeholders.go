@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// placeholderField returns the name of the field referenced by the given argument if it is a placeholder of the
+// form ${Field}.
+//
+// Field names are plain for now. Dotted paths such as ${Limits.Max} are reserved for referencing nested fields.
+func placeholderField(arg string) (name string, ok bool) {
+	if strings.HasPrefix(arg, "${") && strings.HasSuffix(arg, "}") {
+		return arg[2 : len(arg)-1], true
+	}
+	return "", false
+}
+
+// hasPlaceholders tests whether any of the given arguments is a placeholder
+func hasPlaceholders(args []string) bool {
+	for _, arg := range args {
+		if _, ok := placeholderField(arg); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPlaceholders verifies the placeholders among the given arguments when struct tags are parsed
+func checkPlaceholders(args []string) error {
+	for _, arg := range args {
+		name, ok := placeholderField(arg)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			return errors.New("empty field reference " + arg)
+		}
+		if strings.Contains(name, ".") {
+			return errors.New("nested field reference " + arg + " is not supported")
+		}
+	}
+	return nil
+}
+
+// resolveArguments substitutes the placeholders among the given arguments with the current value of the referenced
+// fields of the given struct, see siblingRepresentation. The arguments are returned as is if there are no placeholders.
+// Resolved arguments are verified with the given function, if any, as arguments without placeholders are when parsing
+// tags, see functionMetadata.checkArguments.
+//
+// The returned error describes the first placeholder which could not be resolved, or the placeholders whose values are
+// invalid arguments, see MsgArgumentUnresolved.
+func resolveArguments(args []string, parent reflect.Value, check func([]string) error) ([]string, error) {
+	if !hasPlaceholders(args) {
+		return args, nil
+	}
+
+	resolved := make([]string, len(args))
+	for i, arg := range args {
+		name, ok := placeholderField(arg)
+		if !ok {
+			resolved[i] = arg
+			continue
+		}
+
+		field := parent.FieldByName(name)
+		if !field.IsValid() {
+			return nil, fmt.Errorf(MsgArgumentUnresolved, arg, "field "+name+" not found")
+		}
+		representation, present, err := siblingRepresentation(field)
+		if err != nil {
+			return nil, fmt.Errorf(MsgArgumentUnresolved, arg, err.Error())
+		}
+		if !present {
+			return nil, fmt.Errorf(MsgArgumentUnresolved, arg, "field "+name+" is nil")
+		}
+		resolved[i] = representation
+	}
+
+	if check != nil {
+		if err := check(resolved); err != nil {
+			var placeholders []string
+			for _, arg := range args {
+				if _, ok := placeholderField(arg); ok {
+					placeholders = append(placeholders, arg)
+				}
+			}
+			return nil, fmt.Errorf(MsgArgumentUnresolved, strings.Join(placeholders, ", "), err.Error())
+		}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestFieldReferenceArguments(t *testing.T) {
+	type Order struct {
+		AvailableStock int
+		MinimumOrder   *uint
+		Quantity       int    `validator:"min(${MinimumOrder})|max(${AvailableStock})"`
+		Currency       string `validator:"enum(${HomeCurrency},USD)"`
+		HomeCurrency   string
+	}
+
+	minimum := uint(2)
+	order := Order{AvailableStock: 10, MinimumOrder: &minimum, Quantity: 5, Currency: "EUR", HomeCurrency: "EUR"}
+	assertTrue(t, Validate(&order).IsValid(), "expected a quantity within the stock to pass")
+
+	order.Quantity = 11
+	order.Currency = "GBP"
+	res := Validate(&order)
+	assertEqual(t, []FieldError{
+		{Field: "Quantity", Message: "value (11) must not exceed 10", Validator: "max"},
+		{Field: "Currency", Message: "invalid value specified. expected any of EUR,USD", Validator: "enum"},
+	}, res.FieldErrors)
+
+	order.Quantity = 1
+	order.Currency = "USD"
+	res = Validate(&order)
+	assertEqual(t, []FieldError{{Field: "Quantity", Message: "value (1) must be at least 2", Validator: "min"}}, res.FieldErrors)
+
+	order.MinimumOrder = nil
+	res = Validate(&order)
+	assertEqual(t, []FieldError{{Field: "Quantity", Message: "cannot resolve argument ${MinimumOrder}: field MinimumOrder is nil", Validator: "min"}}, res.FieldErrors)
+}
+
+func TestFieldReferenceErrors(t *testing.T) {
+	type Form struct {
+		Limits   []int
+		Quantity int `validator:"max(${Limit})"`
+		Count    int `validator:"max(${Limits})"`
+	}
+
+	res := Validate(&Form{})
+	assertEqual(t, []FieldError{
+		{Field: "Quantity", Message: "cannot resolve argument ${Limit}: field Limit not found", Validator: "max"},
+		{Field: "Count", Message: "cannot resolve argument ${Limits}: unsupported field type []int", Validator: "max"},
+	}, res.FieldErrors)
+
+	// resolved arguments are verified like those written in the tag
+	type Order struct {
+		Ratio    float64
+		Label    string
+		Quantity int    `validator:"min(${Ratio})"`
+		Code     string `validator:"length(${Label},8)"`
+	}
+	res = Validate(&Order{Ratio: 1.5, Label: "x"})
+	assertFalse(t, res.IsValid())
+	assertNull(t, res.Error)
+	assertEqual(t, []FieldError{
+		{Field: "Quantity", Message: "cannot resolve argument ${Ratio}: expected an integer argument but found 1.5", Validator: "min"},
+		{Field: "Code", Message: "cannot resolve argument ${Label}: expected an integer or '_' but found x", Validator: "length"},
+	}, res.FieldErrors)
+	assertTrue(t, Validate(&Order{Ratio: 2, Label: "2", Quantity: 3, Code: "abc"}).IsValid())
+
+	problems := CheckStruct(Form{})
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "validator `max` references unknown field Limit", problems[0].Message)
+
+	type Nested struct {
+		Quantity int `validator:"max(${Limits.Max})"`
+	}
+	problems = CheckStruct(Nested{})
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "struct validator.Nested, field Quantity, rule `max(${Limits.Max})`: validator `max` has invalid arguments: nested field reference ${Limits.Max} is not supported", problems[0].Error())
+}
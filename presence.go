@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PresenceRule the constraint a PresenceGroup places on the number of its fields which are present
+type PresenceRule int
+
+const (
+	// AllOrNone requires either all fields of the group or none of them to be present, e.g. Page and PageSize
+	AllOrNone PresenceRule = iota + 1
+	// ExactlyOne requires exactly one field of the group to be present, e.g. Email or Phone
+	ExactlyOne
+	// AtMostOne allows at most one field of the group to be present, e.g. Cursor or Page
+	AtMostOne
+)
+
+func (r PresenceRule) String() string {
+	switch r {
+	case AllOrNone:
+		return "all_or_none"
+	case ExactlyOne:
+		return "exactly_one"
+	case AtMostOne:
+		return "at_most_one"
+	}
+	return fmt.Sprintf("PresenceRule(%d)", int(r))
+}
+
+// PresenceGroup PresenceGroup constrains the presence of a group of fields of a struct, see PresenceMatrix.
+type PresenceGroup struct {
+	// Rule the constraint placed on the fields
+	Rule PresenceRule
+	// Fields the names of the fields of the group, as declared in the struct. Fields promoted from embedded structs
+	// may be named as well.
+	Fields []string
+	// Field the field the error of the group is reported for. It need not belong to the group, e.g. "Pagination".
+	// default: the first field of the group
+	Field string
+	// Message the message reported when the rule is violated.
+	// default: MsgAllOrNone, MsgExactlyOne or MsgAtMostOne
+	Message string
+}
+
+// AllOrNoneOf AllOrNoneOf returns a group requiring either all of the given fields or none of them to be present
+func AllOrNoneOf(fields ...string) PresenceGroup {
+	return PresenceGroup{Rule: AllOrNone, Fields: fields}
+}
+
+// ExactlyOneOf ExactlyOneOf returns a group requiring exactly one of the given fields to be present
+func ExactlyOneOf(fields ...string) PresenceGroup {
+	return PresenceGroup{Rule: ExactlyOne, Fields: fields}
+}
+
+// AtMostOneOf AtMostOneOf returns a group allowing at most one of the given fields to be present
+func AtMostOneOf(fields ...string) PresenceGroup {
+	return PresenceGroup{Rule: AtMostOne, Fields: fields}
+}
+
+// ReportedAs ReportedAs returns a copy of the group reporting its error for the given field
+func (g PresenceGroup) ReportedAs(field string) PresenceGroup {
+	g.Field = field
+	return g
+}
+
+// WithMessage WithMessage returns a copy of the group reporting the given message
+func (g PresenceGroup) WithMessage(message string) PresenceGroup {
+	g.Message = message
+	return g
+}
+
+// PresenceMatrix PresenceMatrix returns a struct level validation function checking the presence of groups of fields,
+// meant to be called from Validatable.ValidateStruct:
+//
+//	var queryPresence = validator.PresenceMatrix(
+//		validator.AllOrNoneOf("Page", "PageSize"),
+//		validator.AtMostOneOf("Cursor", "Page").ReportedAs("Cursor"),
+//		validator.AtMostOneOf("Cursor", "PageSize").ReportedAs("Cursor"),
+//	)
+//
+//	func (q *Query) ValidateStruct(sl validator.StructLevel) {
+//		queryPresence(sl)
+//	}
+//
+// Nil pointers, interfaces, maps and slices are absent, as are empty strings and other zero values, while non-nil
+// pointers are present whatever they point to: use pointer fields to distinguish a page of 0 from no page. Each
+// violated group reports one field error.
+//
+// The function panics with a *ValidationError if a group is malformed: a group must list at least two fields, use a
+// known rule, and be checked against structs declaring its fields.
+func PresenceMatrix(groups ...PresenceGroup) func(sl StructLevel) {
+	groups = append([]PresenceGroup(nil), groups...)
+	for i, group := range groups {
+		if group.Rule < AllOrNone || group.Rule > AtMostOne {
+			panic(newValidationError("presence group " + strings.Join(group.Fields, ", ") + " has an unknown rule " + group.Rule.String()))
+		}
+		if len(group.Fields) < 2 {
+			panic(newValidationError("presence group " + strings.Join(group.Fields, ", ") + " must list at least two fields"))
+		}
+		if group.Field == "" {
+			groups[i].Field = group.Fields[0]
+		}
+	}
+
+	return func(sl StructLevel) {
+		for _, group := range groups {
+			if message, ok := group.check(sl.Value); !ok {
+				sl.AddFieldError(group.Field, message)
+			}
+		}
+	}
+}
+
+// check tests the presence of the fields of the group in the given struct value, returning the message to report if
+// the rule is violated
+func (g PresenceGroup) check(structValue reflect.Value) (string, bool) {
+	present := 0
+	for _, name := range g.Fields {
+		field := structValue.FieldByName(name)
+		if !field.IsValid() {
+			panic(newValidationError(fmt.Sprintf(MsgFieldNotFound, name) + " in " + structValue.Type().String()))
+		}
+		if isPresent(field) {
+			present++
+		}
+	}
+
+	var ok bool
+	var message string
+	switch g.Rule {
+	case AllOrNone:
+		ok, message = present == 0 || present == len(g.Fields), MsgAllOrNone
+	case ExactlyOne:
+		ok, message = present == 1, MsgExactlyOne
+	default:
+		ok, message = present <= 1, MsgAtMostOne
+	}
+	if g.Message != "" {
+		return g.Message, ok
+	}
+	return fmt.Sprintf(message, strings.Join(g.Fields, ", ")), ok
+}
+
+// isPresent tests whether the given field value is present in the sense of PresenceMatrix: non-nil pointers and
+// interfaces are present, other values if they are not empty in the sense of the omitempty flag
+func isPresent(value reflect.Value) bool {
+	if value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		return !value.IsNil()
+	}
+	return !isEmptyValue(value)
+}
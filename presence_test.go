@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pageQueryPresence = PresenceMatrix(
+	AllOrNoneOf("Page", "PageSize").ReportedAs("Pagination"),
+	AtMostOneOf("Cursor", "Page").ReportedAs("Cursor"),
+	AtMostOneOf("Cursor", "PageSize").ReportedAs("Cursor"),
+)
+
+type pageQuery struct {
+	Page     *int
+	PageSize *int `validator:"max(100)"`
+	Cursor   string
+}
+
+func (q *pageQuery) ValidateStruct(sl StructLevel) {
+	pageQueryPresence(sl)
+}
+
+type searchRequest struct {
+	Filter pageQuery
+	Items  []pageQuery
+}
+
+func TestPresenceMatrix(t *testing.T) {
+	zero, ten := 0, 10
+	tests := []struct {
+		name   string
+		query  pageQuery
+		errors []FieldError
+	}{
+		{name: "absent", query: pageQuery{}},
+		{name: "page", query: pageQuery{Page: &zero, PageSize: &ten}},
+		{name: "cursor", query: pageQuery{Cursor: "abc"}},
+		{
+			name:   "page only",
+			query:  pageQuery{Page: &zero},
+			errors: []FieldError{{Field: "Pagination", Message: "Page, PageSize must be provided together or not at all"}},
+		},
+		{
+			name:   "page size only",
+			query:  pageQuery{PageSize: &ten},
+			errors: []FieldError{{Field: "Pagination", Message: "Page, PageSize must be provided together or not at all"}},
+		},
+		{
+			name:  "cursor and page",
+			query: pageQuery{Cursor: "abc", Page: &zero, PageSize: &ten},
+			errors: []FieldError{
+				{Field: "Cursor", Message: "at most one of Cursor, Page may be provided"},
+				{Field: "Cursor", Message: "at most one of Cursor, PageSize may be provided"},
+			},
+		},
+		{
+			name:  "cursor and page size",
+			query: pageQuery{Cursor: "abc", PageSize: &ten},
+			errors: []FieldError{
+				{Field: "Pagination", Message: "Page, PageSize must be provided together or not at all"},
+				{Field: "Cursor", Message: "at most one of Cursor, PageSize may be provided"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		query := test.query
+		res := New().Validate(&query)
+		assertEqual(t, test.errors, res.FieldErrors, test.name)
+		assertEqual(t, len(test.errors) == 0, res.IsValid(), test.name)
+	}
+}
+
+func TestPresenceMatrixRules(t *testing.T) {
+	type contact struct {
+		Email string
+		Phone *string
+		Fax   []string
+	}
+	phone := ""
+
+	exactlyOne := PresenceMatrix(ExactlyOneOf("Email", "Phone", "Fax").WithMessage("one contact is required"))
+	check := func(c contact) []FieldError {
+		var errorList []FieldError
+		exactlyOne(StructLevel{Value: reflect.ValueOf(c), Options: &ValidationOptions{}, errors: &errorList})
+		return errorList
+	}
+
+	assertEqual(t, []FieldError{{Field: "Email", Message: "one contact is required"}}, check(contact{}))
+	assertEqual(t, 0, len(check(contact{Email: "a@b.c"})))
+	// non-nil pointers are present, even if they point to zero values
+	assertEqual(t, 0, len(check(contact{Phone: &phone})))
+	// empty slices are absent
+	assertEqual(t, 0, len(check(contact{Email: "a@b.c", Fax: []string{}})))
+	assertEqual(t, 1, len(check(contact{Email: "a@b.c", Fax: []string{"1"}})))
+	assertEqual(t, 1, len(check(contact{Email: "a@b.c", Phone: &phone, Fax: []string{"1"}})))
+
+	atMostOne := PresenceMatrix(AtMostOneOf("Email", "Phone", "Fax"))
+	var errorList []FieldError
+	atMostOne(StructLevel{Value: reflect.ValueOf(contact{Email: "a", Phone: &phone}), Options: &ValidationOptions{}, errors: &errorList})
+	assertEqual(t, []FieldError{{Field: "Email", Message: "at most one of Email, Phone, Fax may be provided"}}, errorList)
+
+	errorList = nil
+	atMostOne(StructLevel{Value: reflect.ValueOf(contact{}), Options: &ValidationOptions{}, errors: &errorList})
+	assertEqual(t, 0, len(errorList))
+}
+
+func TestPresenceMatrixNested(t *testing.T) {
+	zero := 0
+	req := searchRequest{
+		Filter: pageQuery{Page: &zero},
+		Items:  []pageQuery{{}, {Cursor: "abc", Page: &zero}},
+	}
+	res := New().Validate(&req)
+	// struct fields are checked along with the struct declaring them, after the structs nested in containers
+	assertEqual(t, []FieldError{
+		{Field: "Items[1].Pagination", Message: "Page, PageSize must be provided together or not at all"},
+		{Field: "Items[1].Cursor", Message: "at most one of Cursor, Page may be provided"},
+		{Field: "Filter.Pagination", Message: "Page, PageSize must be provided together or not at all"},
+	}, res.FieldErrors)
+}
+
+func TestPresenceMatrixMalformed(t *testing.T) {
+	assert.PanicsWithError(t, "presence group Page must list at least two fields", func() {
+		PresenceMatrix(AllOrNoneOf("Page"))
+	})
+	assert.PanicsWithError(t, "presence group Page, Cursor has an unknown rule PresenceRule(0)", func() {
+		PresenceMatrix(PresenceGroup{Fields: []string{"Page", "Cursor"}})
+	})
+
+	unknown := PresenceMatrix(AtMostOneOf("Page", "Offset"))
+	assert.PanicsWithError(t, "field Offset not found in validator.pageQuery", func() {
+		unknown(StructLevel{Value: reflect.ValueOf(pageQuery{}), Options: &ValidationOptions{}, errors: &[]FieldError{}})
+	})
+}
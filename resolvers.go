@@ -0,0 +1,184 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TypeResolver presents a value of a registered type to validators and filters as another value, returning that value
+// and its kind, e.g. a decimal as its string representation or a money amount as an int64 of cents. See
+// RegisterTypeResolver.
+type TypeResolver func(value reflect.Value) (reflect.Value, reflect.Kind)
+
+// TypeInverse converts a value returned by a filter back into the registered type, see RegisterTypeResolverWithInverse.
+// An error is reported as a field error, leaving the value unmodified.
+type TypeInverse func(value reflect.Value) (reflect.Value, error)
+
+// typeResolver holds a resolver registered for a type along with its inverse, nil if filters are rejected
+type typeResolver struct {
+	resolve TypeResolver
+	inverse TypeInverse
+}
+
+// RegisterTypeResolver RegisterTypeResolver registers the given resolver for values of the given type, presenting
+// them to validators and filters as the value the resolver returns, e.g.
+//
+//	v.RegisterTypeResolver(reflect.TypeOf(decimal.Decimal{}), func(value reflect.Value) (reflect.Value, reflect.Kind) {
+//		return reflect.ValueOf(value.Interface().(decimal.Decimal).String()), reflect.String
+//	})
+//
+// lets tags say `validator:"pattern(amount)"` on decimal.Decimal and *decimal.Decimal fields. Pointer types are
+// registered for the type they point to, the resolver receiving the value pointed to, and nil pointers are presented
+// as nil values. Struct fields of registered types are validated as values instead of being traversed as nested
+// structs. The type of the resolved values is taken from the resolver's result for the zero value of the type, and
+// must be the same for all values. ResolveUUID and ResolveTime are examples of resolvers. A nil resolver removes the
+// registration.
+//
+// Filters of fields of registered types are rejected, as their results cannot be stored back, unless the resolver is
+// registered with an inverse, see RegisterTypeResolverWithInverse.
+//
+// The function is safe to call concurrently with validation. Structs declaring fields of the given type are parsed
+// again upon their next validation.
+func (v *Validator) RegisterTypeResolver(t reflect.Type, resolver TypeResolver) {
+	v.RegisterTypeResolverWithInverse(t, resolver, nil)
+}
+
+// RegisterTypeResolver RegisterTypeResolver registers the given resolver for values of the given type with the
+// default instance.
+//
+// See Validator.RegisterTypeResolver for details.
+func RegisterTypeResolver(t reflect.Type, resolver TypeResolver) {
+	defaultValidator.RegisterTypeResolver(t, resolver)
+}
+
+// RegisterTypeResolverWithInverse RegisterTypeResolverWithInverse registers the given resolver for values of the given
+// type like RegisterTypeResolver, along with the inverse converting the values returned by filters back into the
+// type, e.g.
+//
+//	v.RegisterTypeResolverWithInverse(reflect.TypeOf(uuid.UUID{}), validator.ResolveUUID, validator.InvertUUID)
+//
+// lets filters apply to uuid.UUID fields, receiving and returning canonical strings. The inverse is applied after
+// each filter.
+func (v *Validator) RegisterTypeResolverWithInverse(t reflect.Type, resolver TypeResolver, inverse TypeInverse) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if resolver == nil {
+		delete(v.resolvers, t)
+	} else {
+		v.resolvers[t] = &typeResolver{resolve: resolver, inverse: inverse}
+	}
+	v.generation.Add(1)
+}
+
+// RegisterTypeResolverWithInverse RegisterTypeResolverWithInverse registers the given resolver and inverse for values
+// of the given type with the default instance.
+//
+// See Validator.RegisterTypeResolverWithInverse for details.
+func RegisterTypeResolverWithInverse(t reflect.Type, resolver TypeResolver, inverse TypeInverse) {
+	defaultValidator.RegisterTypeResolverWithInverse(t, resolver, inverse)
+}
+
+// lookupTypeResolver returns the resolver registered for the given type, pointers being resolved
+func (e *engine) lookupTypeResolver(t reflect.Type) (resolver *typeResolver, ok bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	resolver, ok = e.resolvers[t]
+	return
+}
+
+// resolvedField describes how the values of a field of a type with a resolver are presented, see
+// fieldContext.value
+type resolvedField struct {
+	*typeResolver
+	// valueType and kind the type and kind of the resolved values
+	valueType reflect.Type
+	kind      reflect.Kind
+}
+
+// newResolvedField resolves the zero value of the given type, pointers resolved, to find the type of the values
+// returned by the given resolver
+func newResolvedField(t reflect.Type, resolver *typeResolver) *resolvedField {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	value, kind := resolver.resolve(reflect.New(t).Elem())
+	return &resolvedField{typeResolver: resolver, valueType: value.Type(), kind: kind}
+}
+
+// unwrap returns the resolved value of the given field value, or a nil pointer to the type of the resolved values if
+// the field value is a nil pointer
+func (rf *resolvedField) unwrap(value reflect.Value) reflect.Value {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Zero(reflect.PointerTo(rf.valueType))
+		}
+		value = value.Elem()
+	}
+	resolved, _ := rf.resolve(value)
+	if resolved.Type() != rf.valueType {
+		panic(newValidationError("resolver of " + value.Type().String() + " returned a " + resolved.Type().String() +
+			" value but a " + rf.valueType.String() + " value was expected"))
+	}
+	// filters store their result into the value, see store
+	settable := reflect.New(rf.valueType).Elem()
+	settable.Set(resolved)
+	return settable
+}
+
+// store converts the given value returned by a filter using the inverse and stores it into the given non-nil field
+// value
+func (rf *resolvedField) store(field reflect.Value, value reflect.Value) error {
+	if field.Kind() == reflect.Ptr {
+		field = field.Elem()
+	}
+	converted, err := rf.inverse(value)
+	if err != nil {
+		return err
+	}
+	if !converted.Type().AssignableTo(field.Type()) {
+		return errors.New("inverse returned a " + converted.Type().String() + " value but a " + field.Type().String() + " value was expected")
+	}
+	field.Set(converted)
+	return nil
+}
+
+// ResolveUUID ResolveUUID is a TypeResolver presenting uuid.UUID values as their canonical string, e.g. for the
+// uuid4 validator. Register it with InvertUUID to allow filters.
+func ResolveUUID(value reflect.Value) (reflect.Value, reflect.Kind) {
+	return reflect.ValueOf(value.Interface().(uuid.UUID).String()), reflect.String
+}
+
+// InvertUUID InvertUUID is the TypeInverse of ResolveUUID, parsing strings into uuid.UUID values
+func InvertUUID(value reflect.Value) (reflect.Value, error) {
+	id, err := uuid.Parse(value.String())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(id), nil
+}
+
+// ResolveTime ResolveTime is a TypeResolver presenting time.Time values as RFC 3339 strings with nanoseconds, e.g.
+// for the pattern validator. Register it with InvertTime to allow filters.
+func ResolveTime(value reflect.Value) (reflect.Value, reflect.Kind) {
+	return reflect.ValueOf(value.Interface().(time.Time).Format(time.RFC3339Nano)), reflect.String
+}
+
+// InvertTime InvertTime is the TypeInverse of ResolveTime, parsing RFC 3339 strings into time.Time values
+func InvertTime(value reflect.Value) (reflect.Value, error) {
+	t, err := time.Parse(time.RFC3339Nano, value.String())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(t), nil
+}
@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// money is an amount of cents in a currency, presented to validators as the amount in cents
+type money struct {
+	Cents    int64
+	Currency string
+}
+
+func resolveMoney(value reflect.Value) (reflect.Value, reflect.Kind) {
+	return reflect.ValueOf(value.Interface().(money).Cents), reflect.Int64
+}
+
+func TestTypeResolvers(t *testing.T) {
+	type Order struct {
+		Total    money      `validator:"min(100)|max(100000)"`
+		Discount *money     `validator:"max(500)"`
+		Buyer    uuid.UUID  `validator:"uuid4"`
+		Seller   *uuid.UUID `validator:"required|uuid4"`
+	}
+
+	v := New()
+	v.RegisterTypeResolver(reflect.TypeOf(money{}), resolveMoney)
+	v.RegisterTypeResolver(reflect.TypeOf(&uuid.UUID{}), ResolveUUID)
+
+	seller := uuid.New()
+	order := Order{Total: money{Cents: 100, Currency: "MWK"}, Buyer: uuid.New(), Seller: &seller}
+	assertTrue(t, v.Validate(&order).IsValid(), "expected resolved values within bounds to pass")
+
+	res := v.Validate(&Order{Total: money{Cents: 99}, Discount: &money{Cents: 501}, Buyer: uuid.Must(uuid.NewUUID())})
+	assertEqual(t, []FieldError{
+		{Field: "Total", Message: "value (99) must be at least 100", Validator: "min"},
+		{Field: "Discount", Message: "value (501) must not exceed 500", Validator: "max"},
+		{Field: "Buyer", Message: "expected UUIDv4 but found UUIDv1", Validator: "uuid4"},
+		{Field: "Seller", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "Seller", Message: "Seller: field validation failed", Validator: "uuid4"},
+	}, res.FieldErrors)
+
+	// static checks see the kinds of the resolved values
+	type Invalid struct {
+		Total money `validator:"uuid4"`
+	}
+	problems := v.AnalyzeType(reflect.TypeOf(Invalid{}))
+	assertEqual(t, 1, len(problems))
+	assertEqual(t, "Total", problems[0].Field)
+
+	// resolvers are registered per instance, and removed by registering nil
+	assert.Panics(t, func() { New().Validate(&Order{}) }, "expected money values to be unsupported without a resolver")
+	v.RegisterTypeResolver(reflect.TypeOf(money{}), nil)
+	assert.Panics(t, func() { v.Validate(&Order{}) }, "expected the resolver to be removed")
+
+	// values of unexpected types are reported like panics of validators
+	type Price struct {
+		Amount money `validator:"required"`
+	}
+	recovering := New(func(opts *ValidationOptions) {
+		opts.RecoverFromPanics = true
+	})
+	recovering.RegisterTypeResolver(reflect.TypeOf(money{}), func(value reflect.Value) (reflect.Value, reflect.Kind) {
+		if cents := value.Interface().(money).Cents; cents != 0 {
+			return reflect.ValueOf(cents), reflect.Int64
+		}
+		return reflect.ValueOf(""), reflect.String
+	})
+	res = recovering.Validate(&Price{Amount: money{Cents: 1}})
+	assertEqual(t, []FieldError{{Field: "Amount", Message: "reading the value failed: resolver of validator.money returned a int64 value but a string value was expected"}}, res.FieldErrors)
+}
+
+func TestTypeResolverFilters(t *testing.T) {
+	type Event struct {
+		At time.Time `validator:"length(20,_)" filter:"truncate"`
+	}
+
+	v := New()
+	v.AddFilter("truncate", func(ctx *ValidationContext) reflect.Value {
+		at, _, _ := strings.Cut(ctx.GetValue().String(), ".")
+		if !strings.HasSuffix(at, "Z") {
+			at += "Z"
+		}
+		return reflect.ValueOf(at)
+	})
+
+	// filters are rejected without inverse
+	v.RegisterTypeResolver(reflect.TypeOf(time.Time{}), ResolveTime)
+	assert.PanicsWithError(t, "struct validator.Event, field At, rule `truncate`: filters cannot modify the values of time.Time, whose type resolver has no inverse, see RegisterTypeResolverWithInverse", func() {
+		v.Validate(&Event{})
+	})
+
+	// filter results are converted back by the inverse
+	v.RegisterTypeResolverWithInverse(reflect.TypeOf(time.Time{}), ResolveTime, InvertTime)
+	event := Event{At: time.Date(2024, 5, 1, 10, 30, 0, 500, time.UTC)}
+	assertTrue(t, v.Validate(&event).IsValid())
+	assertEqual(t, time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), event.At)
+
+	// inverse errors are reported as field errors, leaving the value unmodified
+	v.RegisterTypeResolverWithInverse(reflect.TypeOf(time.Time{}), ResolveTime, func(value reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, errors.New("read-only")
+	})
+	event = Event{At: time.Date(2024, 5, 1, 10, 30, 0, 500, time.UTC)}
+	res := v.Validate(&event)
+	assertEqual(t, []FieldError{{Field: "At", Message: "filter truncate failed: read-only", Cause: res.FieldErrors[0].Cause}}, res.FieldErrors)
+	assertEqual(t, 500, event.At.Nanosecond())
+
+	// UUIDs are converted back from their canonical string
+	type Account struct {
+		ID uuid.UUID `filter:"nil_uuid"`
+	}
+	v.AddFilter("nil_uuid", func(ctx *ValidationContext) reflect.Value {
+		return reflect.ValueOf(uuid.Nil.String())
+	})
+	v.RegisterTypeResolverWithInverse(reflect.TypeOf(uuid.UUID{}), ResolveUUID, InvertUUID)
+	account := Account{ID: uuid.New()}
+	assertTrue(t, v.Validate(&account).IsValid())
+	assertEqual(t, uuid.Nil, account.ID)
+}
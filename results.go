@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// NewValidationResult NewValidationResult creates a result holding the given field errors and top level error, e.g.
+// converted from the violations reported by another system. The result is valid if there are neither.
+func NewValidationResult(fieldErrors []FieldError, err *ValidationError) *ValidationResult {
+	r := &ValidationResult{FieldErrors: fieldErrors, Error: err}
+	r.updateValidity()
+	return r
+}
+
+// AddFieldError AddFieldError appends the given field error to the result, making it invalid.
+func (r *ValidationResult) AddFieldError(fe FieldError) {
+	r.FieldErrors = append(r.FieldErrors, fe)
+	r.updateValidity()
+}
+
+// UnmarshalJSON UnmarshalJSON decodes a result encoded with encoding/json, e.g. a recorded response, restoring its
+// validity from its errors. The underlying error of ValidationResult.Error is not restored, only its message.
+func (r *ValidationResult) UnmarshalJSON(data []byte) error {
+	type result ValidationResult
+	decoded := struct {
+		*result
+		Error *struct{ Message string }
+	}{result: (*result)(r)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	r.Error = nil
+	if decoded.Error != nil {
+		r.Error = newValidationError(decoded.Error.Message)
+	}
+	r.updateValidity()
+	return nil
+}
+
+// updateValidity marks the result valid if it holds neither field errors nor a top level error
+func (r *ValidationResult) updateValidity() {
+	r.valid = r.Error == nil && len(r.FieldErrors) == 0
+}
+
+// fieldErrorKey identifies duplicate field errors, see ValidationOptions.DeduplicateFieldErrors
+type fieldErrorKey struct {
+	field   string
+	code    string
+	message string
+}
+
+// deduplicateFieldErrors returns the given errors without those repeating the field, code and message of an earlier
+// error, keeping the order of first occurrences
+func deduplicateFieldErrors(fieldErrors []FieldError) []FieldError {
+	if len(fieldErrors) < 2 {
+		return fieldErrors
+	}
+	seen := make(map[fieldErrorKey]bool, len(fieldErrors))
+	unique := fieldErrors[:0]
+	for _, fe := range fieldErrors {
+		key := fieldErrorKey{field: fe.Field, code: fe.Code, message: fe.Message}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, fe)
+	}
+	return unique
+}
+
+// Coalesce Coalesce merges the field errors reported for the same field into a single FieldError, placed where the
+// first error of the field was. Its message joins the distinct messages of the field with "; ", in order of first
+// occurrence. Validator and Code are kept if all errors of the field share them and are empty otherwise. Cause joins
+// the underlying errors, see errors.Join.
+//
+// Fields with a single error are left as they are.
+func (r *ValidationResult) Coalesce() {
+	if len(r.FieldErrors) < 2 {
+		return
+	}
+
+	positions := make(map[string]int, len(r.FieldErrors))
+	merged := make([][]FieldError, 0, len(r.FieldErrors))
+	for _, fe := range r.FieldErrors {
+		i, ok := positions[fe.Field]
+		if !ok {
+			i = len(merged)
+			positions[fe.Field] = i
+			merged = append(merged, nil)
+		}
+		merged[i] = append(merged[i], fe)
+	}
+
+	coalesced := make([]FieldError, len(merged))
+	for i, fieldErrors := range merged {
+		coalesced[i] = coalesceFieldErrors(fieldErrors)
+	}
+	r.FieldErrors = coalesced
+}
+
+// coalesceFieldErrors merges the given errors of a single field, see ValidationResult.Coalesce
+func coalesceFieldErrors(fieldErrors []FieldError) FieldError {
+	fe := fieldErrors[0]
+	if len(fieldErrors) == 1 {
+		return fe
+	}
+
+	messages := []string{fe.Message}
+	var causes []error
+	for i, other := range fieldErrors {
+		if other.Cause != nil {
+			causes = append(causes, other.Cause)
+		}
+		if i == 0 {
+			continue
+		}
+		if other.Validator != fe.Validator {
+			fe.Validator = ""
+		}
+		if other.Code != fe.Code {
+			fe.Code = ""
+		}
+		if !slices.Contains(messages, other.Message) {
+			messages = append(messages, other.Message)
+		}
+	}
+	fe.Message = strings.Join(messages, "; ")
+
+	switch len(causes) {
+	case 0:
+		fe.Cause = nil
+	case 1:
+		fe.Cause = causes[0]
+	default:
+		fe.Cause = errors.Join(causes...)
+	}
+	return fe
+}
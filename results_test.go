@@ -0,0 +1,150 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicateFieldErrors(t *testing.T) {
+	type Account struct {
+		Name  string `validator:"length(5,_)|enum(alice,bob)|tooShort" message:"invalid name"`
+		Email string `validator:"email|tooShort"`
+		Code  string `validator:"length(5,_)|tooShort"`
+	}
+
+	setup := func(opts *ValidationOptions) {
+		opts.ExposeValidatorNames = false
+	}
+	plain := New(setup)
+	deduplicating := New(setup, func(opts *ValidationOptions) {
+		opts.DeduplicateFieldErrors = true
+	})
+	for _, v := range []*Validator{plain, deduplicating} {
+		v.AddValidator("tooShort", func(ctx *ValidationContext) bool {
+			ctx.ErrorMessage = "value is too short"
+			return ctx.GetValue().Len() >= 5
+		})
+	}
+
+	account := Account{Name: "eve", Email: "abc", Code: "abc"}
+
+	res := plain.Validate(&account)
+	assertFalse(t, res.IsValid())
+	assertEqual(t, 7, len(res.FieldErrors))
+
+	res = deduplicating.Validate(&account)
+	assertFalse(t, res.IsValid())
+	assert.Equal(t, []FieldError{
+		{Field: "Name", Message: "invalid name", Validator: "length"},
+		{Field: "Email", Message: "Email: field validation failed", Validator: "email"},
+		{Field: "Email", Message: "value is too short", Validator: "tooShort"},
+		{Field: "Code", Message: "length (3) must be at least 5", Validator: "length"},
+		{Field: "Code", Message: "value is too short", Validator: "tooShort"},
+	}, res.FieldErrors)
+
+	// errors of distinct fields or codes are kept
+	assert.Equal(t, []FieldError{
+		{Field: "A", Message: "m"},
+		{Field: "B", Message: "m"},
+		{Field: "A", Message: "m", Code: CodeTimeout},
+	}, deduplicateFieldErrors([]FieldError{
+		{Field: "A", Message: "m"},
+		{Field: "B", Message: "m"},
+		{Field: "A", Message: "m", Validator: "other"},
+		{Field: "A", Message: "m", Code: CodeTimeout},
+		{Field: "B", Message: "m"},
+	}))
+}
+
+func TestCoalesce(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+
+	res := &ValidationResult{FieldErrors: []FieldError{
+		{Field: "Name", Message: "too short", Validator: "length"},
+		{Field: "Email", Message: "invalid email", Validator: "email", Cause: first},
+		{Field: "Name", Message: "not allowed", Validator: "enum"},
+		{Field: "Age", Message: "too young", Validator: "min", Code: "range"},
+		{Field: "Name", Message: "too short", Validator: "custom"},
+		{Field: "Email", Message: "invalid email", Validator: "email", Cause: second},
+		{Field: "Age", Message: "too old", Validator: "min", Code: "range"},
+	}}
+	res.Coalesce()
+
+	assertEqual(t, 3, len(res.FieldErrors))
+	assert.Equal(t, FieldError{Field: "Name", Message: "too short; not allowed"}, res.FieldErrors[0])
+
+	email := res.FieldErrors[1]
+	assertEqual(t, "Email", email.Field)
+	assertEqual(t, "invalid email", email.Message)
+	assertEqual(t, "email", email.Validator)
+	assertTrue(t, errors.Is(email, first))
+	assertTrue(t, errors.Is(email, second))
+
+	assert.Equal(t, FieldError{Field: "Age", Message: "too young; too old", Validator: "min", Code: "range"}, res.FieldErrors[2])
+
+	// single errors are left as they are
+	res = &ValidationResult{FieldErrors: []FieldError{{Field: "Name", Message: "m", Cause: first}}}
+	res.Coalesce()
+	assert.Equal(t, []FieldError{{Field: "Name", Message: "m", Cause: first}}, res.FieldErrors)
+
+	res = &ValidationResult{}
+	res.Coalesce()
+	assertNull(t, res.FieldErrors)
+}
+
+func TestNewValidationResult(t *testing.T) {
+	res := NewValidationResult(nil, nil)
+	assertTrue(t, res.IsValid(), "expected an empty result to be valid")
+
+	res.AddFieldError(FieldError{Field: "email", Message: "invalid email", Code: "INVALID_ARGUMENT"})
+	assertFalse(t, res.IsValid(), "expected a field error to invalidate the result")
+	assertEqual(t, 1, len(res.FieldErrors))
+
+	res = NewValidationResult([]FieldError{{Field: "name", Message: "required"}}, nil)
+	assertFalse(t, res.IsValid(), "expected field errors to invalidate the result")
+	res = NewValidationResult(nil, newValidationError("unavailable"))
+	assertFalse(t, res.IsValid(), "expected an error to invalidate the result")
+
+	// merging results
+	merged := NewValidationResult(nil, nil)
+	for _, other := range []*ValidationResult{New().ValidateVar("jo", "length(3,_)"), res} {
+		for _, fe := range other.FieldErrors {
+			merged.AddFieldError(fe)
+		}
+	}
+	assertFalse(t, merged.IsValid(), "expected merged field errors to invalidate the result")
+}
+
+func TestValidationResultJSON(t *testing.T) {
+	type Account struct {
+		Name string `validator:"length(3,_)"`
+	}
+	for _, res := range []*ValidationResult{
+		New().Validate(&Account{Name: "jo"}),
+		New().Validate(&Account{Name: "jane"}),
+		New().Validate(nil),
+	} {
+		data, err := json.Marshal(res)
+		assert.NoError(t, err)
+
+		var decoded ValidationResult
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assertEqual(t, res.IsValid(), decoded.IsValid(), string(data))
+		assertEqual(t, res.FieldErrors, decoded.FieldErrors)
+		if res.Error != nil {
+			assertEqual(t, res.Error.Error(), decoded.Error.Error())
+		}
+	}
+
+	// the underlying error is not restored
+	data, err := json.Marshal(NewValidationResult(nil, newValidationError("timeout", errors.New("deadline exceeded"))))
+	assert.NoError(t, err)
+	var decoded ValidationResult
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assertFalse(t, decoded.IsValid(), "expected the error to invalidate the result")
+	assertEqual(t, "timeout", decoded.Error.Error())
+}
@@ -0,0 +1,490 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// FunctionRule describes a validator or filter applied to a field, e.g. length(3,80)
+type FunctionRule struct {
+	// Name the name of the validator or filter
+	Name string `json:"name"`
+	// Args the arguments, unquoted
+	Args []string `json:"args"`
+}
+
+// FieldRule FieldRule describes the validators, filters, flags and activation triggers a field is tagged with.
+//
+// See Validator.Rules and CanonicalizeTag.
+type FieldRule struct {
+	// Struct the name of the struct type declaring the field, if known
+	Struct string `json:"struct,omitempty"`
+	// Field the path of the field relative to the struct, e.g. Address.Street, if known
+	Field string `json:"field,omitempty"`
+	// Validators the validators, in order of execution
+	Validators []FunctionRule `json:"validators"`
+	// Filters the filters, in order of execution
+	Filters []FunctionRule `json:"filters"`
+	// Rules the filters and validators of the rules tag, in order of execution, see ValidationOptions.RulesTagName.
+	// Names keep their f: or v: prefix, if any. Set by Validator.Rules, Validators and Filters list them as well.
+	Rules []FunctionRule `json:"rules,omitempty"`
+	// Flags the flags, sorted
+	Flags []ValidationFlag `json:"flags"`
+	// Triggers the activation triggers, sorted, negated triggers being prefixed with '!'. Empty if the field has no
+	// trigger tag, i.e. is active for all triggers
+	Triggers []string `json:"triggers"`
+	// Message the message template of the field, see ValidationOptions.MessageTagName. Set by Validator.Rules, it is
+	// not rendered by TagString
+	Message string `json:"message,omitempty"`
+
+	syntax tagSyntax
+}
+
+// tagSyntax holds the options determining how rules are written in struct tags
+type tagSyntax struct {
+	validator string
+	filter    string
+	rules     string
+	flags     string
+	trigger   string
+	separator string
+	// caseInsensitive see ValidationOptions.CaseInsensitiveTagNames
+	caseInsensitive bool
+}
+
+// newTagSyntax returns the tag syntax of the given options
+func newTagSyntax(opts *ValidationOptions) tagSyntax {
+	return tagSyntax{
+		validator:       opts.ValidatorTagName,
+		filter:          opts.FilterTagName,
+		rules:           opts.RulesTagName,
+		flags:           opts.FlagTagName,
+		trigger:         opts.TriggerTagName,
+		separator:       opts.FunctionSeparator,
+		caseInsensitive: opts.CaseInsensitiveTagNames,
+	}
+}
+
+// isRuleKey tests whether the given struct tag key names the validator, filter, rules, flags or trigger component
+func (s tagSyntax) isRuleKey(key string) bool {
+	for _, option := range []string{s.validator, s.filter, s.rules, s.flags, s.trigger} {
+		if matchesTagName(key, option, s.caseInsensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// orDefault returns the syntax, or the syntax of the default options if unset
+func (s tagSyntax) orDefault() tagSyntax {
+	if s.validator == "" {
+		opts := defaultOptions()
+		return newTagSyntax(&opts)
+	}
+	return s
+}
+
+// TagString TagString renders the rule in canonical struct tag syntax, e.g.
+//
+//	validator:"required|length(3,80)" filter:"trim" flags:"omitempty" trigger:"create,update"
+//
+// Components appear in the order validator, filter, flags, trigger and are omitted if empty. Rules with a rules tag
+// render it instead of the validator and filter components. Validators, filters and rules keep their order, flags and
+// triggers are sorted, arguments are only quoted where required. Rules obtained from Validator.Rules use the tag names
+// and function separator of the instance, other rules those of the default options.
+func (r FieldRule) TagString() string {
+	syntax := r.syntax.orDefault()
+	var components []string
+
+	render := func(functions []FunctionRule) string {
+		rendered := make([]string, len(functions))
+		for i, function := range functions {
+			rendered[i] = renderFunction(function, syntax.separator)
+		}
+		return strings.Join(rendered, syntax.separator)
+	}
+
+	if len(r.Rules) > 0 {
+		components = append(components, tagNames(syntax.rules)[0]+":"+strconv.Quote(render(r.Rules)))
+	} else {
+		if len(r.Validators) > 0 {
+			components = append(components, tagNames(syntax.validator)[0]+":"+strconv.Quote(render(r.Validators)))
+		}
+		if len(r.Filters) > 0 {
+			components = append(components, tagNames(syntax.filter)[0]+":"+strconv.Quote(render(r.Filters)))
+		}
+	}
+	if len(r.Flags) > 0 {
+		flags := make([]string, len(r.Flags))
+		for i, flag := range r.Flags {
+			flags[i] = string(flag)
+		}
+		components = append(components, tagNames(syntax.flags)[0]+":"+strconv.Quote(strings.Join(sortedUnique(flags), syntax.separator)))
+	}
+	if len(r.Triggers) > 0 {
+		components = append(components, tagNames(syntax.trigger)[0]+":"+strconv.Quote(strings.Join(sortedUnique(r.Triggers), ",")))
+	}
+	return strings.Join(components, " ")
+}
+
+// renderFunction renders the given function, quoting arguments which would otherwise be read differently
+func renderFunction(function FunctionRule, separator string) string {
+	if len(function.Args) == 0 {
+		return function.Name
+	}
+	definition := function.Name + "(" + strings.Join(function.Args, ",") + ")"
+	if readsAs(definition, separator, function.Args) {
+		return definition
+	}
+	args := make([]string, len(function.Args))
+	for i, arg := range function.Args {
+		args[i] = arg
+		// a trailing backslash would escape the following comma
+		if strings.HasSuffix(arg, `\`) || !readsAs(function.Name+"("+arg+")", separator, []string{arg}) {
+			args[i] = quote(arg)
+		}
+	}
+	return function.Name + "(" + strings.Join(args, ",") + ")"
+}
+
+// quote encloses the given argument in single quotes, escaping the quotes it contains and doubling the backslashes
+// preceding them or ending the argument, see unescape
+func quote(arg string) string {
+	var sb strings.Builder
+	sb.WriteByte('\'')
+	for i := 0; i < len(arg); i++ {
+		switch {
+		case arg[i] == '\'':
+			sb.WriteString(`\'`)
+		case arg[i] == '\\':
+			n := backslashes(arg, i)
+			if i += n - 1; i+1 == len(arg) || arg[i+1] == '\'' {
+				n *= 2
+			}
+			sb.WriteString(strings.Repeat(`\`, n))
+		default:
+			sb.WriteByte(arg[i])
+		}
+	}
+	sb.WriteByte('\'')
+	return sb.String()
+}
+
+// readsAs tests whether the given function definition is read as a single function with the given arguments
+func readsAs(definition string, separator string, args []string) bool {
+	parts, err := splitTopLevel(definition, separator)
+	if err != nil || len(parts) != 1 {
+		return false
+	}
+	_, actual, err := extractFunctionInformation(definition)
+	return err == nil && slices.Equal(actual, args)
+}
+
+// sortedUnique returns the given values sorted and without duplicates
+func sortedUnique(values []string) []string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	unique := sorted[:0]
+	for i, value := range sorted {
+		if i == 0 || value != sorted[i-1] {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// parseRule parses the validator, filter, rules, flag and trigger components of the given tag. Only the syntax is
+// checked: functions are not looked up and their arguments are not verified. Spaces around function names, flags and
+// triggers are removed, while arguments are kept as is.
+func parseRule(tag reflect.StructTag, syntax tagSyntax) (FieldRule, error) {
+	rule := FieldRule{syntax: syntax}
+
+	functions := func(kind string, value string) ([]FunctionRule, error) {
+		parts, err := splitTopLevel(value, syntax.separator)
+		if err != nil {
+			return nil, errors.New("invalid " + kind + " tag: " + err.Error())
+		}
+		functions := make([]FunctionRule, 0, len(parts))
+		for _, part := range parts {
+			name, args, err := extractFunctionInformation(strings.TrimSpace(part))
+			if err != nil {
+				return nil, errors.New("invalid " + kind + ": " + err.Error())
+			}
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return nil, errors.New("invalid " + kind + " tag: empty " + kind + " in `" + value + "`")
+			}
+			functions = append(functions, FunctionRule{Name: name, Args: args})
+		}
+		return functions, nil
+	}
+
+	lookup := func(option string) (string, bool, error) {
+		return lookupTag(tag, option, syntax.caseInsensitive)
+	}
+
+	value, ok, err := lookup(syntax.validator)
+	if err != nil {
+		return FieldRule{}, err
+	}
+	if ok {
+		if rule.Validators, err = functions("validator", value); err != nil {
+			return FieldRule{}, err
+		}
+	}
+	if value, ok, err = lookup(syntax.filter); err != nil {
+		return FieldRule{}, err
+	}
+	if ok {
+		if rule.Filters, err = functions("filter", value); err != nil {
+			return FieldRule{}, err
+		}
+	}
+	if value, ok, err = lookup(syntax.rules); err != nil {
+		return FieldRule{}, err
+	}
+	if ok {
+		if rule.Rules, err = functions("rule", value); err != nil {
+			return FieldRule{}, err
+		}
+	}
+	if value, ok, err = lookup(syntax.flags); err != nil {
+		return FieldRule{}, err
+	}
+	if ok {
+		var flags []string
+		for _, flag := range strings.Split(value, syntax.separator) {
+			if flag = strings.TrimSpace(flag); flag != "" {
+				flags = append(flags, flag)
+			}
+		}
+		for _, flag := range sortedUnique(flags) {
+			rule.Flags = append(rule.Flags, ValidationFlag(flag))
+		}
+	}
+	if value, ok, err = lookup(syntax.trigger); err != nil {
+		return FieldRule{}, err
+	}
+	if ok {
+		var triggers []string
+		for _, trigger := range strings.Split(value, ",") {
+			trigger = strings.TrimSpace(trigger)
+			var set triggerSet
+			negated, isNegated := strings.CutPrefix(trigger, "!")
+			switch {
+			case trigger == "":
+				return FieldRule{}, errors.New("empty trigger in `" + value + "`")
+			case isNegated && (negated == "" || negated == "all"):
+				return FieldRule{}, errors.New("invalid negated trigger `" + trigger + "`")
+			}
+			if err := set.add(negated); err != nil {
+				return FieldRule{}, err
+			}
+			triggers = append(triggers, trigger)
+		}
+		rule.Triggers = sortedUnique(triggers)
+	}
+	return rule, nil
+}
+
+// tagEntry is a key:"value" pair of a struct tag
+type tagEntry struct {
+	key   string
+	value string
+}
+
+// splitStructTag splits the given struct tag into its key:"value" pairs, following the conventions of
+// reflect.StructTag
+func splitStructTag(tag string) ([]tagEntry, error) {
+	var entries []tagEntry
+	for {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			return entries, nil
+		}
+
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return nil, errors.New("malformed struct tag `" + tag + "`")
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return nil, errors.New("malformed struct tag value of `" + key + "`")
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			return nil, errors.New("malformed struct tag value of `" + key + "`")
+		}
+		entries = append(entries, tagEntry{key: key, value: value})
+		tag = tag[i+1:]
+	}
+}
+
+// Rules Rules returns the rules of the fields of the given struct (or struct pointer) and of the structs nested
+// within it, as parsed by this instance. Fields without validators, filters and flags are omitted.
+//
+// An error is returned if the struct has invalid tags, see Register.
+func (v *Validator) Rules(s interface{}) ([]FieldRule, error) {
+	types, invalid := structTypes([]interface{}{s})
+	if len(invalid) > 0 {
+		return nil, newValidationError("cannot describe " + fmt.Sprintf("%T", s) + ": expected struct or struct pointer")
+	}
+
+	opts := v.currentOptions()
+	return v.structRules(types, &opts)
+}
+
+// ParseStruct ParseStruct parses the tags of the given struct type (or struct pointer type) and of the structs nested
+// within it using the given options, or the options of this instance if nil, and returns the rules of their fields
+// like Rules does. The struct cache is neither read nor populated, which lets callers building struct types at
+// runtime manage parsed rules themselves, see WithoutCache.
+//
+// An error is returned if the type is not a struct, if the options are invalid or if the struct has invalid tags.
+func (v *Validator) ParseStruct(t reflect.Type, opts *ValidationOptions) ([]FieldRule, error) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, newValidationError("cannot parse " + fmt.Sprint(t) + ": expected struct or struct pointer type")
+	}
+
+	var parseOpts ValidationOptions
+	if opts == nil {
+		parseOpts = v.currentOptions()
+	} else {
+		parseOpts = *opts
+	}
+	if err := parseOpts.Check(); err != nil {
+		return nil, err
+	}
+	return v.structRules([]reflect.Type{t}, &parseOpts)
+}
+
+// ParseStruct ParseStruct parses the tags of the given struct type using the default instance.
+//
+// See Validator.ParseStruct for details.
+func ParseStruct(t reflect.Type, opts *ValidationOptions) ([]FieldRule, error) {
+	return defaultValidator.ParseStruct(t, opts)
+}
+
+// structRules parses the given struct types and the structs nested within them using the given options, returning
+// the rules of their fields, see Rules
+func (v *Validator) structRules(types []reflect.Type, opts *ValidationOptions) ([]FieldRule, error) {
+	syntax := newTagSyntax(opts)
+
+	var rules []FieldRule
+	var err error
+	walkStructTypes(types, func(t reflect.Type) []*fieldContext {
+		if err != nil {
+			return nil
+		}
+		contexts, problems := v.parseStruct(t, opts)
+		if len(problems) > 0 {
+			err = problems[0].validationError()
+			return nil
+		}
+		for _, fc := range contexts {
+			field, _ := fc.structType.FieldByName(fc.fieldName)
+			rule, parseErr := parseRule(field.Tag, syntax)
+			if parseErr != nil {
+				err = newTagError(fc.structType, field, "", parseErr.Error()).validationError()
+				return nil
+			}
+			if len(rule.Validators) == 0 && len(rule.Filters) == 0 && len(rule.Rules) == 0 && len(rule.Flags) == 0 {
+				continue
+			}
+			// the functions of the rules tag are classified by the instance
+			if rule.Rules != nil {
+				rule.Validators, rule.Filters = functionRules(fc)
+			}
+			rule.Struct = t.String()
+			rule.Field = fc.pathPrefix + fc.fieldName
+			rule.Message = fc.fieldMessageTemplate
+			rules = append(rules, rule)
+		}
+		return contexts
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// functionRules returns the validators and filters of the given field context
+func functionRules(fc *fieldContext) (validators []FunctionRule, filters []FunctionRule) {
+	for _, validator := range fc.validators {
+		validators = append(validators, FunctionRule{Name: validator.name, Args: validator.args})
+	}
+	for _, filter := range fc.filters {
+		filters = append(filters, FunctionRule{Name: filter.name, Args: filter.args})
+	}
+	return validators, filters
+}
+
+// Rules Rules returns the rules of the fields of the given struct using the default instance. See Validator.Rules.
+func Rules(s interface{}) ([]FieldRule, error) {
+	return defaultValidator.Rules(s)
+}
+
+// CanonicalizeTag CanonicalizeTag rewrites the validator, filter, flag and trigger components of the given struct
+// tag into canonical form, see FieldRule.TagString, using the tag names and function separator of this instance.
+// Canonical components come first, followed by the other components of the tag, e.g. json, as they are.
+//
+// Only the syntax is checked, validators and filters are not looked up. An error is returned if the tag is
+// malformed or cannot be rendered without changing its meaning.
+func (v *Validator) CanonicalizeTag(tag string) (string, error) {
+	entries, err := splitStructTag(tag)
+	if err != nil {
+		return "", err
+	}
+
+	opts := v.currentOptions()
+	syntax := newTagSyntax(&opts)
+	rule, err := parseRule(reflect.StructTag(tag), syntax)
+	if err != nil {
+		return "", err
+	}
+
+	canonical := rule.TagString()
+	if again, err := parseRule(reflect.StructTag(canonical), syntax); err != nil || !reflect.DeepEqual(rule, again) {
+		return "", errors.New("tag `" + tag + "` cannot be represented in canonical form")
+	}
+
+	components := []string{}
+	if canonical != "" {
+		components = append(components, canonical)
+	}
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		if seen[entry.key] || syntax.isRuleKey(entry.key) {
+			continue
+		}
+		seen[entry.key] = true
+		components = append(components, entry.key+":"+strconv.Quote(entry.value))
+	}
+	return strings.Join(components, " "), nil
+}
+
+// CanonicalizeTag CanonicalizeTag rewrites the given struct tag into canonical form using the default instance. See
+// Validator.CanonicalizeTag.
+func CanonicalizeTag(tag string) (string, error) {
+	return defaultValidator.CanonicalizeTag(tag)
+}
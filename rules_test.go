@@ -0,0 +1,280 @@
+package validator
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalizeTag(t *testing.T) {
+	tests := []struct {
+		tag       string
+		canonical string
+		err       string
+	}{
+		{
+			tag:       `json:"name" trigger:"update, create,update" flags:"sensitive|omitempty" filter:"trim" validator:" required | length(3,80)"`,
+			canonical: `validator:"required|length(3,80)" filter:"trim" flags:"omitempty|sensitive" trigger:"create,update" json:"name"`,
+		},
+		{
+			tag:       `validator:"enum('a',b,'c,d')|regex('^(a|b)$')|max(10)" label:"Choice"`,
+			canonical: `validator:"enum(a,b,'c,d')|regex(^(a|b)$)|max(10)" label:"Choice"`,
+		},
+		{
+			tag:       `validator:"length(1, 80)|trim()" trigger:"!update,admin.*"`,
+			canonical: `validator:"length(1, 80)|trim" trigger:"!update,admin.*"`,
+		},
+		{tag: `flags:"omitempty" rules:" trim | f:lower|v:length(8,_) "`, canonical: `rules:"trim|f:lower|v:length(8,_)" flags:"omitempty"`},
+		{tag: `validator:"enum('')"`, canonical: `validator:"enum('')"`},
+		{tag: `validator:"enum(,)"`, canonical: `validator:"enum(,)"`},
+		{tag: `flags:" | "`, canonical: ``},
+		{tag: `json:"name,omitempty" json:"other"`, canonical: `json:"name,omitempty"`},
+		{tag: ``, canonical: ``},
+		{tag: `validator:"required||min(3)"`, err: "invalid validator tag: empty validator in `required||min(3)`"},
+		{tag: `filter:"trim(1"`, err: "invalid filter tag: unbalanced parentheses in `trim(1`"},
+		{tag: `filter:"trim)("`, err: "invalid filter tag: unbalanced parentheses in `trim)(`"},
+		{tag: `filter:"trim(1)x"`, err: "invalid filter: expected `trim(1)x` to end with ')'"},
+		{tag: `validator:"min(1))"`, err: "invalid validator tag: unbalanced parentheses in `min(1))`"},
+		{tag: `trigger:"update,"`, err: "empty trigger in `update,`"},
+		{tag: `trigger:"!all"`, err: "invalid negated trigger `!all`"},
+		{tag: `trigger:"ad*min"`, err: "invalid trigger pattern `ad*min`: '*' must be a whole segment"},
+		{tag: `validator:"required`, err: "malformed struct tag value of `validator`"},
+		{tag: `validator`, err: "malformed struct tag `validator`"},
+	}
+
+	for _, test := range tests {
+		canonical, err := CanonicalizeTag(test.tag)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.tag)
+			continue
+		}
+		assert.NoError(t, err, test.tag)
+		assertEqual(t, test.canonical, canonical, test.tag)
+
+		// canonical tags are canonical
+		again, err := CanonicalizeTag(canonical)
+		assert.NoError(t, err, canonical)
+		assertEqual(t, canonical, again, canonical)
+	}
+}
+
+func TestCanonicalizeTagOptions(t *testing.T) {
+	v := New(func(opts *ValidationOptions) {
+		opts.ValidatorTagName = "validate"
+		opts.FunctionSeparator = ";"
+	})
+
+	canonical, err := v.CanonicalizeTag(`flags:"omitempty;skip_filters" validate:"regex('a;b') ; enum(';',')') ; min(1)" validator:"ignored"`)
+	assert.NoError(t, err)
+	assertEqual(t, `validate:"regex(a;b);enum(;,')');min(1)" flags:"omitempty;skip_filters" validator:"ignored"`, canonical)
+}
+
+func TestRules(t *testing.T) {
+	type Address struct {
+		Street string `validator:"length(1,_)" filter:"trim"`
+	}
+	type Phone struct {
+		Number string `validator:"length(5,_)"`
+	}
+	type Person struct {
+		Name    string  `validator:"required|length(3,80)" filter:"trim" flags:"omitempty" trigger:"update,create"`
+		Email   *string `validator:"email" json:"email"`
+		Note    string  `json:"note"`
+		Address Address
+		Phones  []*Phone
+		Friends []*Person
+	}
+
+	rules, err := New().Rules(&Person{})
+	assert.NoError(t, err)
+	assertEqual(t, 4, len(rules))
+
+	assertEqual(t, "validator.Person", rules[0].Struct)
+	assertEqual(t, "Name", rules[0].Field)
+	assertEqual(t, []FunctionRule{{Name: "required", Args: []string{}}, {Name: "length", Args: []string{"3", "80"}}}, rules[0].Validators)
+	assertEqual(t, []FunctionRule{{Name: "trim", Args: []string{}}}, rules[0].Filters)
+	assertEqual(t, []ValidationFlag{OmitEmpty}, rules[0].Flags)
+	assertEqual(t, []string{"create", "update"}, rules[0].Triggers)
+	assertEqual(t, `validator:"required|length(3,80)" filter:"trim" flags:"omitempty" trigger:"create,update"`, rules[0].TagString())
+
+	assertEqual(t, "Email", rules[1].Field)
+	assertEqual(t, `validator:"email"`, rules[1].TagString())
+	assertEqual(t, "Address.Street", rules[2].Field)
+	assertEqual(t, "validator.Person", rules[2].Struct)
+	// structs nested within containers are described separately, once
+	assertEqual(t, "validator.Phone", rules[3].Struct)
+	assertEqual(t, "Number", rules[3].Field)
+
+	type Invalid struct {
+		Name string `validator:"lenght(3,80)"`
+	}
+	_, err = New().Rules(Invalid{})
+	assert.ErrorContains(t, err, "validator `lenght` not found")
+
+	_, err = New().Rules(10)
+	assert.EqualError(t, err, "cannot describe int: expected struct or struct pointer")
+
+	// rules of other instances are rendered using their options
+	v := New(func(opts *ValidationOptions) {
+		opts.FilterTagName = "filters"
+		opts.FunctionSeparator = ","
+	})
+	type Custom struct {
+		Name string `validator:"length(3,80),enum('a,b',c)" filters:"trim"`
+	}
+	rules, err = v.Rules(Custom{})
+	assert.NoError(t, err)
+	assertEqual(t, `validator:"length(3,80),enum('a,b',c)" filters:"trim"`, rules[0].TagString())
+}
+
+func TestParseStruct(t *testing.T) {
+	tenantType := func(tag string) reflect.Type {
+		return reflect.StructOf([]reflect.StructField{
+			{Name: "Code", Type: reflect.TypeOf(""), Tag: reflect.StructTag(tag)},
+			{Name: "Note", Type: reflect.TypeOf("")},
+		})
+	}
+
+	v := New()
+	rules, err := v.ParseStruct(tenantType(`validator:"length(5,_)"`), nil)
+	assert.NoError(t, err)
+	assertEqual(t, 1, len(rules))
+	assertEqual(t, "Code", rules[0].Field)
+	assertEqual(t, `validator:"length(5,_)"`, rules[0].TagString())
+
+	rules, err = v.ParseStruct(reflect.PointerTo(tenantType(`validator:"required" filter:"trim"`)), nil)
+	assert.NoError(t, err)
+	assertEqual(t, `validator:"required" filter:"trim"`, rules[0].TagString())
+
+	// the cache is left untouched
+	v.cache.backend.Range(func(key, _ any) bool {
+		t.Errorf("unexpected cache entry %v", key)
+		return true
+	})
+
+	// the given options apply instead of the options of the instance
+	opts := defaultOptions()
+	opts.ValidatorTagName = "checks"
+	rules, err = v.ParseStruct(tenantType(`checks:"length(1,_)" validator:"emial"`), &opts)
+	assert.NoError(t, err)
+	assertEqual(t, `checks:"length(1,_)"`, rules[0].TagString())
+
+	_, err = v.ParseStruct(tenantType(`validator:"emial"`), nil)
+	assert.ErrorContains(t, err, "validator `emial` not found")
+	_, err = ParseStruct(reflect.TypeOf(10), nil)
+	assert.EqualError(t, err, "cannot parse int: expected struct or struct pointer type")
+	opts.FunctionSeparator = ""
+	_, err = v.ParseStruct(tenantType(""), &opts)
+	assert.Error(t, err)
+}
+
+func TestFieldRuleTagString(t *testing.T) {
+	rule := FieldRule{
+		Validators: []FunctionRule{{Name: "enum", Args: []string{"a|b", "(c", ""}}, {Name: "required"}},
+		Flags:      []ValidationFlag{Sensitive, OmitEmpty, Sensitive},
+		Triggers:   []string{"update", "!create"},
+	}
+	assertEqual(t, `validator:"enum(a|b,'(c','')|required" flags:"omitempty|sensitive" trigger:"!create,update"`, rule.TagString())
+	assertEqual(t, "", FieldRule{}.TagString())
+
+	// quotes within quoted arguments are escaped
+	rule = FieldRule{Validators: []FunctionRule{{Name: "enum", Args: []string{"it's", "New York, NY", "'a,b'"}}}}
+	assertEqual(t, `validator:"enum(it's,'New York, NY','\\'a,b\\'')"`, rule.TagString())
+
+	// backslashes preceding quotes or ending quoted arguments are doubled
+	rule = FieldRule{Validators: []FunctionRule{{Name: "enum", Args: []string{`a,b\`, "c", `^\d$`}}}}
+	assertEqual(t, `validator:"enum('a,b\\\\',c,^\\d$)"`, rule.TagString())
+	_, args, err := extractFunctionInformation(`enum('a,b\\',c,^\d$)`)
+	assertNull(t, err)
+	assertEqual(t, rule.Validators[0].Args, args)
+}
+
+// randomRule generates a rule from a small alphabet including characters with a special meaning in tags
+func randomRule(r *rand.Rand) FieldRule {
+	pick := func(values ...string) string {
+		return values[r.Intn(len(values))]
+	}
+	functions := func() []FunctionRule {
+		n := r.Intn(4)
+		if n == 0 {
+			return nil
+		}
+		functions := make([]FunctionRule, n)
+		for i := range functions {
+			args := make([]string, r.Intn(4))
+			for j := range args {
+				var sb strings.Builder
+				for k := r.Intn(5); k > 0; k-- {
+					sb.WriteString(pick("a", "1", "_", " ", ",", "|", "(", ")", "()", "$", "^", "${Field}", "\"", "'", `\`))
+				}
+				args[j] = sb.String()
+			}
+			functions[i] = FunctionRule{Name: pick("required", "min", "length", "enum", "regex"), Args: args}
+		}
+		return functions
+	}
+
+	rule := FieldRule{Validators: functions(), Filters: functions()}
+	for n := r.Intn(3); n > 0; n-- {
+		rule.Flags = append(rule.Flags, ValidationFlag(pick("omitempty", "sensitive", "skip_filters")))
+	}
+	for n := r.Intn(3); n > 0; n-- {
+		rule.Triggers = append(rule.Triggers, pick("create", "update", "!delete", "admin.*", "!*.audit"))
+	}
+	return rule
+}
+
+func TestTagRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	opts := defaultOptions()
+	syntax := newTagSyntax(&opts)
+
+	for i := 0; i < 2000; i++ {
+		tag := randomRule(r).TagString()
+
+		parsed, err := parseRule(reflect.StructTag(tag), syntax)
+		if !assert.NoError(t, err, tag) {
+			continue
+		}
+		rendered := parsed.TagString()
+		again, err := parseRule(reflect.StructTag(rendered), syntax)
+		assert.NoError(t, err, rendered)
+		assert.Equal(t, parsed, again, tag)
+		assertEqual(t, tag, rendered, tag)
+	}
+}
+
+func FuzzCanonicalizeTag(f *testing.F) {
+	f.Add(`validator:"required|length(3,80)" filter:"trim" flags:"omitempty" trigger:"create,update"`)
+	f.Add(`validator:"enum('a,b',c)|regex('^(a|b)$')" json:"name"`)
+	f.Add(`validator:"length(1, 80)|trim()" trigger:"!update,admin.*"`)
+	f.Add(`validator:"enum('')" label:"x"`)
+
+	opts := defaultOptions()
+	syntax := newTagSyntax(&opts)
+	f.Fuzz(func(t *testing.T, tag string) {
+		canonical, err := CanonicalizeTag(tag)
+		if err != nil {
+			return
+		}
+		// parse → render → parse yields the same rules, and canonical tags are fixpoints
+		original, err := parseRule(reflect.StructTag(tag), syntax)
+		if err != nil {
+			t.Fatalf("%q canonicalized but does not parse: %v", tag, err)
+		}
+		parsed, err := parseRule(reflect.StructTag(canonical), syntax)
+		if err != nil {
+			t.Fatalf("canonical form %q of %q does not parse: %v", canonical, tag, err)
+		}
+		if !reflect.DeepEqual(original, parsed) {
+			t.Fatalf("canonical form %q of %q parses differently", canonical, tag)
+		}
+		again, err := CanonicalizeTag(canonical)
+		if err != nil || again != canonical {
+			t.Fatalf("canonical form %q of %q is not canonical: %q, %v", canonical, tag, again, err)
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package validator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ruleSetPrefix marks references to rule sets in validator tags, e.g. `validator:"$name_field"`
+const ruleSetPrefix = "$"
+
+// maxRuleSetDepth is the default number of rule sets and aliases that may be nested within each other, see
+// TagComplexityLimits.MaxNesting
+const maxRuleSetDepth = 8
+
+// RegisterRuleSet RegisterRuleSet registers a named chain of validators, e.g.
+//
+//	v.RegisterRuleSet("name_field", "required|length(2,80)|alphanum")
+//
+// Validator tags reference it with a '$' prefix, optionally along with other validators: `validator:"$name_field"`.
+// References are expanded when structs are parsed, as if the validators of the set were listed in place of the
+// reference. Rule sets may reference other rule sets, up to ValidationOptions.TagLimits.MaxNesting levels deep (8 by
+// default), but not themselves.
+//
+// Validators are looked up when structs are parsed, so they may be registered after the set. Registering a set again
+// replaces it, and structs referencing it are parsed again upon their next validation. An error is returned if the
+// name is not made of letters, digits, '_', '-' and '.', or if the rules are malformed.
+func (v *Validator) RegisterRuleSet(name string, rules string) error {
+	if !isRuleSetName(name) {
+		return newValidationError("invalid rule set name `" + name + "`")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, err := splitRuleSet(rules, v.options.FunctionSeparator); err != nil {
+		return newValidationError("invalid rule set `"+name+"`", err)
+	}
+	if v.ruleSets == nil {
+		v.ruleSets = make(map[string]string)
+	}
+	v.ruleSets[name] = rules
+	v.generation.Add(1)
+	return nil
+}
+
+// RegisterRuleSet RegisterRuleSet registers a named chain of validators with the default instance.
+//
+// See Validator.RegisterRuleSet for details.
+func RegisterRuleSet(name string, rules string) error {
+	return defaultValidator.RegisterRuleSet(name, rules)
+}
+
+// RegisterAlias RegisterAlias registers a named chain of validators referenced like a validator, e.g.
+//
+//	v.RegisterAlias("username", "required|alphanum|min(3)|max(30)")
+//
+// lets tags say `validator:"username"`. Aliases are expanded like rule sets when structs are parsed: field errors
+// name the validators of the alias, such as min, and messages templates of the field apply to them. Aliases may
+// reference other aliases and rule sets, up to ValidationOptions.TagLimits.MaxNesting levels deep (8 by default),
+// but not themselves.
+//
+// An alias and a validator cannot share a name: registering an alias under the name of a validator, or the reverse,
+// panics unless ValidationOptions.NoPanicOnFunctionConflict is set, in which case the latest registration wins.
+// Registering an alias again replaces it. An error is returned if the name is not made of letters, digits, '_', '-'
+// and '.', or if the rules are malformed.
+func (v *Validator) RegisterAlias(name string, rules string) error {
+	if !isRuleSetName(name) {
+		return newValidationError("invalid alias name `" + name + "`")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, err := splitRuleSet(rules, v.options.FunctionSeparator); err != nil {
+		return newValidationError("invalid alias `"+name+"`", err)
+	}
+	if _, exists := v.validators[name]; exists && !v.options.NoPanicOnFunctionConflict {
+		panic(errors.New("a validator by the name of " + name + " already exists"))
+	}
+	if v.aliases == nil {
+		v.aliases = make(map[string]string)
+	}
+	v.aliases[name] = rules
+	v.generation.Add(1)
+	return nil
+}
+
+// RegisterAlias RegisterAlias registers a named chain of validators referenced like a validator with the default
+// instance.
+//
+// See Validator.RegisterAlias for details.
+func RegisterAlias(name string, rules string) error {
+	return defaultValidator.RegisterAlias(name, rules)
+}
+
+// isRuleSetName tests whether the given name is a valid rule set or alias name
+func isRuleSetName(name string) bool {
+	return name != "" && strings.IndexFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.')
+	}) < 0
+}
+
+// splitRuleSet splits the rules of a rule set into function definitions
+func splitRuleSet(rules string, separator string) ([]string, error) {
+	parts, err := splitTopLevel(rules, separator)
+	if err != nil {
+		return nil, err
+	}
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+		if parts[i] == "" {
+			return nil, errors.New("empty validator in `" + rules + "`")
+		}
+	}
+	return parts, nil
+}
+
+// expandRuleSets replaces references to rule sets and aliases found in the given function definitions with the
+// functions they stand for, recursively, within the limits of the given options
+func (v *Validator) expandRuleSets(functions []string, opts *ValidationOptions) ([]string, error) {
+	return v.expandRuleSetsAt(functions, opts.FunctionSeparator, opts.TagLimits, nil)
+}
+
+// expandRuleSetsAt expands the given function definitions found within the given chain of rule set and alias
+// references. Expansion stops as soon as the expanded functions exceed TagComplexityLimits.MaxFunctions, so that
+// nested references cannot multiply without bounds.
+func (v *Validator) expandRuleSetsAt(functions []string, separator string, limits TagComplexityLimits, chain []string) ([]string, error) {
+	var expanded []string
+	for _, function := range functions {
+		ref := strings.TrimSpace(function)
+		kind := "rule set"
+
+		v.mu.RLock()
+		name, isRuleSet := strings.CutPrefix(ref, ruleSetPrefix)
+		rules, found := v.ruleSets[name]
+		if !isRuleSet {
+			kind = "alias"
+			rules, found = v.aliases[ref]
+		}
+		v.mu.RUnlock()
+
+		if !isRuleSet && !found {
+			expanded = append(expanded, function)
+			continue
+		}
+
+		for i, outer := range chain {
+			if outer == ref {
+				cycle := append(append([]string(nil), chain[i:]...), ref)
+				return nil, errors.New(kind + " `" + ref + "` references itself: " + strings.Join(cycle, " -> "))
+			}
+		}
+		if exceeds(len(chain)+1, limits.MaxNesting) {
+			return nil, errors.New(kind + " `" + ref + "` exceeds the maximum nesting depth of rule sets and aliases: " +
+				limitMessage(strconv.Itoa(len(chain)+1)+" levels", "MaxNesting", limits.MaxNesting))
+		}
+		if !found {
+			return nil, errors.New("rule set `" + ref + "` not found")
+		}
+
+		parts, err := splitRuleSet(rules, separator)
+		if err != nil {
+			return nil, errors.New("invalid " + kind + " `" + ref + "`: " + err.Error())
+		}
+		parts, err = v.expandRuleSetsAt(parts, separator, limits, append(chain, ref))
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, parts...)
+		if exceeds(len(expanded), limits.MaxFunctions) {
+			return nil, errors.New(limitMessage("rule sets and aliases expand to more than "+strconv.Itoa(limits.MaxFunctions)+" validators", "MaxFunctions", limits.MaxFunctions))
+		}
+	}
+	return expanded, nil
+}
@@ -0,0 +1,146 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSets(t *testing.T) {
+	v := New(func(opts *ValidationOptions) {
+		opts.ExposeValidatorNames = true
+	})
+	assert.NoError(t, v.RegisterRuleSet("name_field", "required | length(2,80)"))
+	assert.NoError(t, v.RegisterRuleSet("person_name", "$name_field|alphanum"))
+
+	type Person struct {
+		FirstName string `validator:"$person_name"`
+		Nickname  string `validator:"$name_field|max(0)" flags:"omitempty"`
+	}
+
+	person := Person{FirstName: "j", Nickname: "jo"}
+	res := v.Validate(&person)
+	assertEqual(t, []FieldError{
+		{Field: "FirstName", Message: "length (1) must be at least 2", Validator: "length"},
+		{Field: "Nickname", Message: "length (jo) must not exceed 0", Validator: "max"},
+	}, res.FieldErrors)
+
+	person = Person{FirstName: "jane!"}
+	res = v.Validate(&person)
+	assertEqual(t, []FieldError{{Field: "FirstName", Message: MsgAlphaNumeric, Validator: "alphanum"}}, res.FieldErrors)
+
+	// redefining a set applies to structs parsed before
+	assert.NoError(t, v.RegisterRuleSet("name_field", "required|length(6,80)"))
+	res = v.Validate(&person)
+	assertEqual(t, []FieldError{
+		{Field: "FirstName", Message: "length (5) must be at least 6", Validator: "length"},
+		{Field: "FirstName", Message: MsgAlphaNumeric, Validator: "alphanum"},
+	}, res.FieldErrors)
+
+	assert.EqualError(t, v.RegisterRuleSet("name field", "required"), "invalid rule set name `name field`")
+	assert.EqualError(t, v.RegisterRuleSet("broken", "required|length(2,80"), "invalid rule set `broken`: unbalanced parentheses in `required|length(2,80`")
+	assert.EqualError(t, v.RegisterRuleSet("empty", "required||min(1)"), "invalid rule set `empty`: empty validator in `required||min(1)`")
+}
+
+func TestRuleSetErrors(t *testing.T) {
+	v := New(func(opts *ValidationOptions) {
+		opts.PanicOnTagError = false
+	})
+	assert.NoError(t, v.RegisterRuleSet("a", "required|$b"))
+	assert.NoError(t, v.RegisterRuleSet("b", "$c"))
+	assert.NoError(t, v.RegisterRuleSet("c", "min(1)|$a"))
+	assert.NoError(t, v.RegisterRuleSet("typo", "requried"))
+
+	type Cyclic struct {
+		Value int `validator:"$b"`
+	}
+	assert.ErrorContains(t, v.Register(Cyclic{}), "rule set `$b` references itself: $b -> $c -> $a -> $b")
+
+	type Unknown struct {
+		Value int `validator:"$missing"`
+	}
+	assert.ErrorContains(t, v.Register(Unknown{}), "rule set `$missing` not found")
+
+	type Typo struct {
+		Value int `validator:"$typo"`
+	}
+	assert.ErrorContains(t, v.Register(Typo{}), "validator `requried` not found")
+
+	for i := 0; i <= maxRuleSetDepth; i++ {
+		next := "$level" + string(rune('0'+i+1))
+		if i == maxRuleSetDepth {
+			next = "required"
+		}
+		assert.NoError(t, v.RegisterRuleSet("level"+string(rune('0'+i)), next))
+	}
+	type Deep struct {
+		Value int `validator:"$level0"`
+	}
+	assert.ErrorContains(t, v.Register(Deep{}), "exceeds the maximum nesting depth of rule sets")
+
+	type Shallow struct {
+		Value int `validator:"$level1"`
+	}
+	assert.NoError(t, v.Register(Shallow{}))
+}
+
+func TestAliases(t *testing.T) {
+	v := New()
+	assert.NoError(t, v.RegisterAlias("username", "required|alphanum|length(3,30)"))
+	assert.NoError(t, v.RegisterAlias("handle", "username|$lower"))
+	assert.NoError(t, v.RegisterRuleSet("lower", "enum(jane,john)"))
+
+	type Account struct {
+		Login  *string `validator:"username"`
+		Handle string  `validator:"handle"`
+		Custom string  `validator:"username" message:"not a valid username"`
+	}
+
+	jo, jane := "jo", "jane"
+	res := v.Validate(&Account{Login: &jo, Handle: "jo", Custom: "jo"})
+	assertEqual(t, []FieldError{
+		{Field: "Login", Message: "length (2) must be at least 3", Validator: "length"},
+		{Field: "Handle", Message: "length (2) must be at least 3", Validator: "length"},
+		{Field: "Handle", Message: MsgEnum, Validator: "enum"},
+		{Field: "Custom", Message: "not a valid username", Validator: "length"},
+	}, res.FieldErrors)
+
+	res = v.Validate(&Account{Handle: "jack", Custom: "jane"})
+	assertEqual(t, []FieldError{
+		{Field: "Login", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "Handle", Message: MsgEnum, Validator: "enum"},
+	}, res.FieldErrors)
+	assertTrue(t, v.Validate(&Account{Login: &jane, Handle: "john", Custom: "jane"}).IsValid(), "expected valid account")
+
+	// cycles
+	assert.NoError(t, v.RegisterAlias("a", "required|b"))
+	assert.NoError(t, v.RegisterAlias("b", "$c"))
+	assert.NoError(t, v.RegisterRuleSet("c", "a"))
+	type Cyclic struct {
+		Value string `validator:"b"`
+	}
+	assert.ErrorContains(t, v.Register(Cyclic{}), "alias `b` references itself: b -> $c -> a -> b")
+
+	// conflicts with validators
+	assert.PanicsWithError(t, "a validator by the name of email already exists", func() {
+		_ = v.RegisterAlias("email", "required")
+	})
+	assert.PanicsWithError(t, "a validator by the name of username already exists", func() {
+		v.AddValidator("username", IsRequired)
+	})
+	assert.EqualError(t, v.RegisterAlias("user name", "required"), "invalid alias name `user name`")
+	assert.ErrorContains(t, v.RegisterAlias("empty", "required|"), "invalid alias `empty`")
+
+	lenient := New(func(opts *ValidationOptions) {
+		opts.NoPanicOnFunctionConflict = true
+	})
+	type Contact struct {
+		Email string `validator:"email"`
+	}
+	assert.NoError(t, lenient.RegisterAlias("email", "length(5,_)"))
+	res = lenient.Validate(&Contact{Email: "a@b"})
+	assertEqual(t, []FieldError{{Field: "Email", Message: "length (3) must be at least 5", Validator: "length"}}, res.FieldErrors)
+	lenient.AddValidator("email", IsEmail)
+	res = lenient.Validate(&Contact{Email: "a@b"})
+	assertEqual(t, "email", res.FieldErrors[0].Validator)
+}
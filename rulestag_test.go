@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hashFilter replaces the value with its SHA-256 digest in hex
+func hashFilter(ctx *ValidationContext) reflect.Value {
+	sum := sha256.Sum256([]byte(ctx.GetValue().String()))
+	return reflect.ValueOf(hex.EncodeToString(sum[:]))
+}
+
+func TestRulesTag(t *testing.T) {
+	type Legacy struct {
+		Password string `validator:"length(8,_)" filter:"trim|hash"`
+	}
+	type Signup struct {
+		Password string `rules:"trim|length(8,_)|hash|length(64,64)"`
+	}
+
+	v := New()
+	v.AddFilter("hash", hashFilter)
+
+	// the legacy tags validate the hash, whatever the password
+	legacy := Legacy{Password: " short "}
+	assertTrue(t, v.Validate(&legacy).IsValid(), "expected validators to see the hashed value")
+
+	// the rules tag validates the trimmed password, then hashes it
+	signup := Signup{Password: "  correct horse  "}
+	assertTrue(t, v.Validate(&signup).IsValid())
+	sum := sha256.Sum256([]byte("correct horse"))
+	assertEqual(t, hex.EncodeToString(sum[:]), signup.Password)
+
+	// the first failing validator ends the chain, leaving the value unhashed
+	signup = Signup{Password: " short "}
+	res := v.Validate(&signup)
+	assertEqual(t, []FieldError{{Field: "Password", Message: "length (5) must be at least 8", Validator: "length"}}, res.FieldErrors)
+	assertEqual(t, "short", signup.Password)
+
+	// prefixes select validators and filters sharing a name
+	v.AddValidator("trim", func(ctx *ValidationContext) bool {
+		return ctx.GetValue().String() == strings.TrimSpace(ctx.GetValue().String())
+	})
+	type Prefixed struct {
+		Filtered  string `rules:"trim"`
+		Validated string `rules:"f:trim|v:trim"`
+		Checked   string `rules:"v:trim"`
+	}
+	prefixed := Prefixed{Filtered: " a ", Validated: " b ", Checked: " c "}
+	res = v.Validate(&prefixed)
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "Checked", res.FieldErrors[0].Field)
+	assertEqual(t, Prefixed{Filtered: "a", Validated: "b", Checked: " c "}, prefixed)
+
+	// introspection classifies the functions
+	rules, err := v.Rules(&Signup{})
+	assert.NoError(t, err)
+	assertEqual(t, []FunctionRule{{Name: "length", Args: []string{"8", "_"}}, {Name: "length", Args: []string{"64", "64"}}}, rules[0].Validators)
+	assertEqual(t, []FunctionRule{{Name: "trim", Args: []string{}}, {Name: "hash", Args: []string{}}}, rules[0].Filters)
+	assertEqual(t, `rules:"trim|length(8,_)|hash|length(64,64)"`, rules[0].TagString())
+
+	// the rules tag replaces the validator and filter tags
+	type Mixed struct {
+		Password string `rules:"trim" validator:"length(8,_)"`
+	}
+	assert.PanicsWithError(t, "struct validator.Mixed, field Password: the rules tag cannot be combined with the validator and filter tags", func() {
+		v.Validate(&Mixed{})
+	})
+	type Unknown struct {
+		Password string `rules:"trim|v:hash"`
+	}
+	assert.PanicsWithError(t, "struct validator.Unknown, field Password, rule `hash`: validator `hash` not found", func() {
+		v.Validate(&Unknown{})
+	})
+}
+
+func TestRulesTagNameOption(t *testing.T) {
+	type Account struct {
+		Name string `rules:"length(3,_)" checks:"length(1,_)"`
+	}
+
+	v := New()
+	assertFalse(t, v.Validate(&Account{Name: "ab"}).IsValid())
+
+	// the rules tag name is part of the cache key
+	opts := v.currentOptions()
+	opts.RulesTagName = "checks"
+	assertTrue(t, v.ValidateWithOptions(&Account{Name: "ab"}, opts).IsValid(), "expected the fields to be parsed again")
+	assertFalse(t, v.Validate(&Account{Name: "ab"}).IsValid())
+}
@@ -0,0 +1,167 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// fieldSelection restricts validation to the fields listed, or to the fields not listed if except is set, see
+// Validator.ValidateFields and Validator.ValidateExcept. Paths are dotted field names without indexes, e.g.
+// Address.City or Items.Sku. A nil selection selects every field.
+type fieldSelection struct {
+	paths  []string
+	except bool
+}
+
+// normalizeFieldPath returns the given field path, as built during traversal, without indexes and trailing dot,
+// e.g. Items.Sku for Items[2].Sku
+func normalizeFieldPath(path string) string {
+	var sb strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '[' {
+			if end := strings.IndexByte(path[i:], ']'); end >= 0 {
+				i += end
+				continue
+			}
+		}
+		sb.WriteByte(path[i])
+	}
+	return strings.TrimSuffix(sb.String(), ".")
+}
+
+// covered tests whether the field at the given path, or a struct containing it, is listed
+func (s *fieldSelection) covered(path string) bool {
+	path = normalizeFieldPath(path)
+	for _, selected := range s.paths {
+		if path == selected || strings.HasPrefix(path, selected+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// contains tests whether a field nested within the field or struct at the given path is listed
+func (s *fieldSelection) contains(path string) bool {
+	path = normalizeFieldPath(path)
+	for _, selected := range s.paths {
+		if path == "" || strings.HasPrefix(selected, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// applies tests whether the validators and filters of the field at the given path are applied
+func (s *fieldSelection) applies(path string) bool {
+	if s == nil {
+		return true
+	}
+	return s.covered(path) != s.except
+}
+
+// descends tests whether the structs contained in the field at the given path are validated, at least partially
+func (s *fieldSelection) descends(path string) bool {
+	if s == nil {
+		return true
+	}
+	if s.except {
+		return !s.covered(path)
+	}
+	return s.covered(path) || s.contains(path)
+}
+
+// whole tests whether every field of the struct at the given path is validated, in which case its struct level
+// validation runs
+func (s *fieldSelection) whole(path string) bool {
+	if s == nil {
+		return true
+	}
+	if s.except {
+		return !s.covered(path) && !s.contains(path)
+	}
+	return s.covered(path)
+}
+
+// unknownFields returns the listed paths which do not name a field of the given struct type. Path segments may
+// name fields of structs contained in pointer, slice, array and map fields, and promoted fields of embedded structs.
+func unknownFields(t reflect.Type, paths []string) (unknown []string) {
+	for _, path := range paths {
+		current := t
+		for _, name := range strings.Split(path, ".") {
+			for current != nil && current.Kind() != reflect.Struct {
+				switch current.Kind() {
+				case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+					current = current.Elem()
+				default:
+					current = nil
+				}
+			}
+			if current == nil {
+				break
+			}
+			field, ok := current.FieldByName(name)
+			if !ok || name == "" {
+				current = nil
+				break
+			}
+			current = field.Type
+		}
+		if current == nil {
+			unknown = append(unknown, path)
+		}
+	}
+	return
+}
+
+// validateSelection validates the fields of the given struct selected by the given selection
+func (v *Validator) validateSelection(structPtr interface{}, selection *fieldSelection, trigger []string) *ValidationResult {
+	opts := v.currentOptions()
+	if t := reflect.TypeOf(structPtr); t != nil {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			if unknown := unknownFields(t, selection.paths); len(unknown) > 0 {
+				return &ValidationResult{Error: newValidationError("unknown fields of " + t.String() + ": " + strings.Join(unknown, ", "))}
+			}
+		}
+	}
+	return v.validate(context.Background(), structPtr, &opts, trigger, selection)
+}
+
+// ValidateFields ValidateFields validates the listed fields of the given struct using this instance, e.g. the fields
+// present in the body of a PATCH request. Other fields are neither validated nor filtered.
+//
+// Fields are named as declared, not by label. Fields of nested structs are named by dotted paths without indexes,
+// e.g. Address.City or Items.Sku for the Sku field of every element of Items. Listing a struct field, e.g. Address,
+// selects all its fields. Struct level validation (see Validatable) only runs for structs whose fields are all
+// selected. An unknown field name sets ValidationResult.Error and nothing is validated.
+//
+// See Validate for details about the other parameters.
+func (v *Validator) ValidateFields(structPtr interface{}, fields []string, trigger ...string) *ValidationResult {
+	return v.validateSelection(structPtr, &fieldSelection{paths: fields}, trigger)
+}
+
+// ValidateFields ValidateFields validates the listed fields of the given struct using the default instance.
+//
+// See Validator.ValidateFields for details.
+func ValidateFields(structPtr interface{}, fields []string, trigger ...string) *ValidationResult {
+	return defaultValidator.ValidateFields(structPtr, fields, trigger...)
+}
+
+// ValidateExcept ValidateExcept validates the given struct using this instance, except for the listed fields, which
+// are neither validated nor filtered. Listing a struct field, e.g. Address, excludes all its fields.
+//
+// Fields are named as for ValidateFields. Struct level validation only runs for structs none of whose fields are
+// excluded.
+func (v *Validator) ValidateExcept(structPtr interface{}, fields []string, trigger ...string) *ValidationResult {
+	return v.validateSelection(structPtr, &fieldSelection{paths: fields, except: true}, trigger)
+}
+
+// ValidateExcept ValidateExcept validates the given struct using the default instance, except for the listed fields.
+//
+// See Validator.ValidateExcept for details.
+func ValidateExcept(structPtr interface{}, fields []string, trigger ...string) *ValidationResult {
+	return defaultValidator.ValidateExcept(structPtr, fields, trigger...)
+}
@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"testing"
+)
+
+type patchAddress struct {
+	Street string `validator:"length(1,_)" filter:"trim"`
+	City   string `validator:"length(2,_)" filter:"trim"`
+}
+
+type patchItem struct {
+	Sku      string `validator:"length(3,_)"`
+	Quantity int    `validator:"min(1)"`
+}
+
+type patchUser struct {
+	Email       string `validator:"email" filter:"trim"`
+	DisplayName string `validator:"length(3,_)" filter:"trim" label:"Display name"`
+	Password    string `validator:"length(8,_)"`
+	Address     patchAddress
+	Items       []*patchItem
+}
+
+// ValidateStruct reports an error only struct level validation can detect
+func (u *patchUser) ValidateStruct(sl StructLevel) {
+	if u.DisplayName == u.Password {
+		sl.AddFieldError("Password", "password must differ from the display name")
+	}
+}
+
+func newPatchUser() patchUser {
+	return patchUser{
+		Email:       " not an email ",
+		DisplayName: " x ",
+		Password:    "x",
+		Address:     patchAddress{Street: "  ", City: " B "},
+		Items:       []*patchItem{{Sku: "a", Quantity: 1}, {Sku: "abc", Quantity: 0}},
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	user := newPatchUser()
+	res := New().ValidateFields(&user, []string{"DisplayName", "Address.City", "Items.Quantity"})
+	assertEqual(t, []FieldError{
+		{Field: "Display name", Message: "length (1) must be at least 3", Validator: "length"},
+		{Field: "Address.City", Message: "length (1) must be at least 2", Validator: "length"},
+		{Field: "Items[1].Quantity", Message: "value (0) must be at least 1", Validator: "min"},
+	}, res.FieldErrors)
+	// only selected fields are filtered, and struct level validation is skipped
+	assertEqual(t, " not an email ", user.Email)
+	assertEqual(t, "x", user.DisplayName)
+	assertEqual(t, "  ", user.Address.Street)
+	assertEqual(t, "B", user.Address.City)
+
+	// listing a struct selects all its fields
+	user = newPatchUser()
+	res = New().ValidateFields(&user, []string{"Address"})
+	assertEqual(t, []FieldError{
+		{Field: "Address.Street", Message: "length (0) must be at least 1", Validator: "length"},
+		{Field: "Address.City", Message: "length (1) must be at least 2", Validator: "length"},
+	}, res.FieldErrors)
+
+	user = newPatchUser()
+	assertTrue(t, New().ValidateFields(&user, nil).IsValid(), "expected no field to be validated")
+
+	// typos do not pass silently
+	res = New().ValidateFields(&user, []string{"Emial", "Address.Cty", "Items.Sku", "Address.City.Name"})
+	assertFalse(t, res.IsValid(), "expected unknown fields to fail")
+	assertEqual(t, "unknown fields of validator.patchUser: Emial, Address.Cty, Address.City.Name", res.Error.Error())
+	assertEqual(t, 0, len(res.FieldErrors))
+	assertEqual(t, " not an email ", user.Email)
+}
+
+func TestValidateExcept(t *testing.T) {
+	user := newPatchUser()
+	user.Email = "jo@example.com"
+	user.DisplayName = "secret-value"
+	user.Password = "secret-value"
+	res := New().ValidateExcept(&user, []string{"Address", "Items.Sku"})
+	assertEqual(t, []FieldError{
+		{Field: "Items[1].Quantity", Message: "value (0) must be at least 1", Validator: "min"},
+	}, res.FieldErrors)
+	assertEqual(t, "  ", user.Address.Street)
+
+	// struct level validation only runs for structs validated entirely
+	res = New().ValidateExcept(&user, []string{"Items"})
+	assertEqual(t, []FieldError{
+		{Field: "Address.Street", Message: "length (0) must be at least 1", Validator: "length"},
+		{Field: "Address.City", Message: "length (1) must be at least 2", Validator: "length"},
+	}, res.FieldErrors)
+	res = New().ValidateExcept(&user, []string{})
+	assertEqual(t, FieldError{Field: "Password", Message: "password must differ from the display name"}, res.FieldErrors[len(res.FieldErrors)-1])
+
+	res = New().ValidateExcept(&user, []string{"Nickname"})
+	assertEqual(t, "unknown fields of validator.patchUser: Nickname", res.Error.Error())
+}
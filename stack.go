@@ -1,5 +1,6 @@
 package validator
 
+// Stack Stack is a last in, first out collection of items
 type Stack []any
 
 func (s *Stack) mustNotBeEmpty() {
@@ -8,23 +9,33 @@ func (s *Stack) mustNotBeEmpty() {
 	}
 }
 
+// Push Push adds the given item on top of the stack
 func (s *Stack) Push(item any) {
 	*s = append(*s, item)
 }
 
+// IsEmpty IsEmpty tests whether the stack holds no items
 func (s *Stack) IsEmpty() bool {
 	return len(*s) == 0
 }
 
+// Len Len returns the number of items of the stack
+func (s *Stack) Len() int {
+	return len(*s)
+}
+
+// Peek Peek returns the item on top of the stack without removing it, panicking if the stack is empty
 func (s *Stack) Peek() any {
 	s.mustNotBeEmpty()
-	return (*s)[0]
+	return (*s)[len(*s)-1]
 }
 
+// Pop Pop removes and returns the item on top of the stack, panicking if the stack is empty
 func (s *Stack) Pop() any {
 	s.mustNotBeEmpty()
 	index := len(*s) - 1
 	item := (*s)[index]
+	(*s)[index] = nil
 	*s = (*s)[:index]
 	return item
 }
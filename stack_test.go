@@ -0,0 +1,29 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStack(t *testing.T) {
+	stack := Stack{}
+	assertTrue(t, stack.IsEmpty(), "expected a new stack to be empty")
+	assert.PanicsWithValue(t, "empty stack", func() { stack.Peek() })
+	assert.PanicsWithValue(t, "empty stack", func() { stack.Pop() })
+
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+	assertEqual(t, 3, stack.Len())
+	assertEqual(t, 3, stack.Peek(), "expected Peek to return the top item")
+	assertEqual(t, 3, stack.Len(), "expected Peek to keep the item")
+
+	assertEqual(t, 3, stack.Pop())
+	assertEqual(t, 2, stack.Peek())
+	stack.Push(4)
+	assertEqual(t, 4, stack.Pop())
+	assertEqual(t, 2, stack.Pop())
+	assertEqual(t, 1, stack.Pop())
+	assertTrue(t, stack.IsEmpty(), "expected the stack to be empty")
+}
@@ -0,0 +1,146 @@
+package validator
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FieldStat FieldStat counts the evaluations and failures of a validator of a struct field, see EnableStats
+type FieldStat struct {
+	// Struct the struct type declaring the field, e.g. "api.SignupRequest"
+	Struct string
+	// Field the name of the field
+	Field string
+	// Validator the name of the validator
+	Validator string
+	// Evaluations the number of times the validator was applied to the field
+	Evaluations uint64
+	// Failures the number of times the validator failed, including panics and timeouts
+	Failures uint64
+	// LastFailure the time of the last failure, zero if the validator never failed
+	LastFailure time.Time
+}
+
+// statKey identifies a validator of a struct field
+type statKey struct {
+	structType reflect.Type
+	field      string
+	validator  string
+}
+
+// statCounters holds the counters of a validator of a struct field, updated atomically
+type statCounters struct {
+	evaluations atomic.Uint64
+	failures    atomic.Uint64
+	// lastFailure the time of the last failure in nanoseconds since the Unix epoch
+	lastFailure atomic.Int64
+}
+
+// validationStats holds the counters of all validators evaluated since statistics were enabled or reset. Counters are
+// created once per validator and field, after which recording only involves atomic operations.
+type validationStats struct {
+	counters sync.Map // statKey -> *statCounters
+}
+
+// record counts an evaluation of the named validator of the given field
+func (s *validationStats) record(fc *fieldContext, validator string, failed bool) {
+	key := statKey{structType: fc.structType, field: fc.fieldName, validator: validator}
+	value, ok := s.counters.Load(key)
+	if !ok {
+		value, _ = s.counters.LoadOrStore(key, &statCounters{})
+	}
+	counters := value.(*statCounters)
+	counters.evaluations.Add(1)
+	if failed {
+		counters.failures.Add(1)
+		counters.lastFailure.Store(time.Now().UnixNano())
+	}
+}
+
+// snapshot returns the current counters sorted by struct, field and validator
+func (s *validationStats) snapshot() []FieldStat {
+	stats := make([]FieldStat, 0)
+	s.counters.Range(func(k, v any) bool {
+		key, counters := k.(statKey), v.(*statCounters)
+		// failures are counted after evaluations, loading them first keeps them within the evaluations
+		failures := counters.failures.Load()
+		stat := FieldStat{
+			Struct:      key.structType.String(),
+			Field:       key.field,
+			Validator:   key.validator,
+			Evaluations: counters.evaluations.Load(),
+			Failures:    failures,
+		}
+		if last := counters.lastFailure.Load(); last != 0 {
+			stat.LastFailure = time.Unix(0, last)
+		}
+		stats = append(stats, stat)
+		return true
+	})
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.Struct != b.Struct {
+			return a.Struct < b.Struct
+		}
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		return a.Validator < b.Validator
+	})
+	return stats
+}
+
+// EnableStats EnableStats starts counting the evaluations and failures of each validator of each struct field
+// validated by this instance, e.g. to find out which rules reject users most often. Statistics are disabled by
+// default and cost a few atomic operations per validator when enabled. Enabling statistics again has no effect.
+//
+// Statistics are shared with the instances returned by WithTrigger and WithoutCache. See SnapshotStats and ResetStats.
+func (v *Validator) EnableStats() {
+	v.stats.CompareAndSwap(nil, &validationStats{})
+}
+
+// EnableStats EnableStats starts counting the evaluations and failures of the validators of the default instance.
+//
+// See Validator.EnableStats for details.
+func EnableStats() {
+	defaultValidator.EnableStats()
+}
+
+// SnapshotStats SnapshotStats returns the statistics counted since they were enabled or reset, sorted by struct,
+// field and validator. Validations running concurrently may be partially counted. An empty slice is returned if
+// statistics are not enabled.
+func (v *Validator) SnapshotStats() []FieldStat {
+	stats := v.stats.Load()
+	if stats == nil {
+		return []FieldStat{}
+	}
+	return stats.snapshot()
+}
+
+// SnapshotStats SnapshotStats returns the statistics of the default instance.
+//
+// See Validator.SnapshotStats for details.
+func SnapshotStats() []FieldStat {
+	return defaultValidator.SnapshotStats()
+}
+
+// ResetStats ResetStats clears the statistics of this instance, if enabled. Validations running concurrently with
+// the reset may not be counted.
+func (v *Validator) ResetStats() {
+	for {
+		stats := v.stats.Load()
+		if stats == nil || v.stats.CompareAndSwap(stats, &validationStats{}) {
+			return
+		}
+	}
+}
+
+// ResetStats ResetStats clears the statistics of the default instance.
+//
+// See Validator.ResetStats for details.
+func ResetStats() {
+	defaultValidator.ResetStats()
+}
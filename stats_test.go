@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type statsSignup struct {
+	Email string `validator:"email"`
+	Age   int    `validator:"min(18)|max(65)"`
+	Team  struct {
+		Name string `validator:"length(3,_)"`
+	}
+}
+
+func TestStats(t *testing.T) {
+	v := New()
+
+	// statistics are disabled by default
+	v.Validate(&statsSignup{})
+	assertEqual(t, []FieldStat{}, v.SnapshotStats())
+
+	v.EnableStats()
+	start := time.Now()
+
+	// concurrent validations, every other one failing min and length
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				signup := statsSignup{Email: "jane@example.com", Age: 30}
+				signup.Team.Name = "core"
+				if j%2 == 0 {
+					signup.Age = 12
+					signup.Team.Name = "x"
+				}
+				v.WithTrigger().Validate(&signup)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	stats := v.SnapshotStats()
+	assertEqual(t, 4, len(stats))
+	expected := []struct {
+		structName string
+		field      string
+		validator  string
+		failures   uint64
+	}{
+		{"struct { Name string \"validator:\\\"length(3,_)\\\"\" }", "Name", "length", 200},
+		{"validator.statsSignup", "Age", "max", 0},
+		{"validator.statsSignup", "Age", "min", 200},
+		{"validator.statsSignup", "Email", "email", 0},
+	}
+	for i, e := range expected {
+		stat := stats[i]
+		assertEqual(t, e.structName, stat.Struct)
+		assertEqual(t, e.field, stat.Field)
+		assertEqual(t, e.validator, stat.Validator)
+		assertEqual(t, uint64(400), stat.Evaluations, stat.Field+" "+stat.Validator)
+		assertEqual(t, e.failures, stat.Failures, stat.Field+" "+stat.Validator)
+		if e.failures > 0 {
+			assertFalse(t, stat.LastFailure.Before(start), "expected the time of the last failure")
+		} else {
+			assertTrue(t, stat.LastFailure.IsZero(), "expected no last failure")
+		}
+	}
+
+	// stop on first error skips validators, which are not counted
+	var opts ValidationOptions
+	v.CopyOptions(&opts)
+	opts.StopOnFirstError = true
+	v.ResetStats()
+	v.ValidateWithOptions(&statsSignup{Email: "jane"}, opts)
+	stats = v.SnapshotStats()
+	assertEqual(t, 1, len(stats))
+	assertEqual(t, FieldStat{Struct: "validator.statsSignup", Field: "Email", Validator: "email", Evaluations: 1, Failures: 1, LastFailure: stats[0].LastFailure}, stats[0])
+
+	// enabling statistics again keeps them, resetting clears them
+	v.EnableStats()
+	assertEqual(t, 1, len(v.SnapshotStats()))
+	v.ResetStats()
+	assertEqual(t, []FieldStat{}, v.SnapshotStats())
+
+	// statistics are kept per instance
+	assertEqual(t, []FieldStat{}, New().SnapshotStats())
+}
+
+// BenchmarkStats validates a struct with and without statistics
+func BenchmarkStats(b *testing.B) {
+	signup := statsSignup{Email: "jane@example.com", Age: 30}
+	signup.Team.Name = "core"
+
+	for _, enabled := range []bool{false, true} {
+		v := New()
+		if enabled {
+			v.EnableStats()
+		}
+		b.Run(fmt.Sprintf("stats=%t", enabled), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !v.Validate(&signup).IsValid() {
+					b.Fatal("validation failed")
+				}
+			}
+		})
+	}
+}
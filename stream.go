@@ -0,0 +1,133 @@
+package validator
+
+import (
+	"encoding/csv"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// ResultSink receives the results of records validated by ValidateStream
+type ResultSink interface {
+	// Accept Accept receives the result of the record at the given zero based position of the stream
+	Accept(index int, res *ValidationResult)
+}
+
+// Summary summarizes the results of records validated by ValidateStream
+type Summary struct {
+	// Valid Valid number of valid records
+	Valid int
+	// Invalid Invalid number of invalid records, including records with a top level error
+	Invalid int
+	// Errors Errors number of records whose result has a top level error (ValidationResult.Error)
+	Errors int
+	// FieldFailures FieldFailures number of field errors per field. Slice indexes and map keys are collapsed,
+	// so Items[0].Sku and Items[7].Sku are both counted as Items[].Sku
+	FieldFailures map[string]int
+}
+
+// Total returns the number of validated records
+func (s Summary) Total() int {
+	return s.Valid + s.Invalid
+}
+
+var elementPathMatcher = regexp.MustCompile(`\[[^\]]*\]`)
+
+// ValidateStream ValidateStream validates the records returned by next until it reports that there are no more records,
+// passing each result to the given sink. Only the summary is retained, allowing large record sets, such as database
+// cursors or files, to be validated without materializing them.
+//
+// The sink may be nil if only the summary is of interest.
+func ValidateStream[T any](next func() (*T, bool), sink ResultSink, trigger ...string) Summary {
+	summary := Summary{FieldFailures: make(map[string]int)}
+
+	for index := 0; ; index++ {
+		record, ok := next()
+		if !ok {
+			break
+		}
+
+		res := ValidateT(record, trigger...)
+		if res.IsValid() {
+			summary.Valid++
+		} else {
+			summary.Invalid++
+			if res.Error != nil {
+				summary.Errors++
+			}
+			for _, fe := range res.FieldErrors {
+				summary.FieldFailures[elementPathMatcher.ReplaceAllString(fe.Field, "[]")]++
+			}
+		}
+
+		if sink != nil {
+			sink.Accept(index, res)
+		}
+	}
+
+	return summary
+}
+
+// IndexedResult the result of the record at the given position of a stream
+type IndexedResult struct {
+	Index  int
+	Result *ValidationResult
+}
+
+// MemorySink MemorySink keeps the results of invalid records in memory
+type MemorySink struct {
+	Failures []IndexedResult
+}
+
+func (s *MemorySink) Accept(index int, res *ValidationResult) {
+	if !res.IsValid() {
+		s.Failures = append(s.Failures, IndexedResult{Index: index, Result: res})
+	}
+}
+
+// CSVSink CSVSink writes a row per error of invalid records, consisting of the record index, the field and the
+// error message. Top level errors are written with an empty field.
+//
+// Call Flush once the stream has been validated.
+type CSVSink struct {
+	writer *csv.Writer
+	header bool
+	err    error
+}
+
+// NewCSVSink creates a sink writing CSV rows to the given writer. A header row precedes the first row written
+func NewCSVSink(w io.Writer) *CSVSink {
+	return &CSVSink{writer: csv.NewWriter(w), header: true}
+}
+
+func (s *CSVSink) Accept(index int, res *ValidationResult) {
+	if res.IsValid() {
+		return
+	}
+	if s.header {
+		s.write("index", "field", "message")
+		s.header = false
+	}
+	if res.Error != nil {
+		s.write(strconv.Itoa(index), "", res.Error.Error())
+	}
+	for _, fe := range res.FieldErrors {
+		s.write(strconv.Itoa(index), fe.Field, fe.Message)
+	}
+}
+
+// write writes a row, retaining the first error encountered
+func (s *CSVSink) write(row ...string) {
+	if err := s.writer.Write(row); err != nil && s.err == nil {
+		s.err = err
+	}
+}
+
+// Flush Flush writes buffered rows to the underlying writer and returns the first error encountered while writing
+func (s *CSVSink) Flush() error {
+	s.writer.Flush()
+	if s.err == nil {
+		s.err = s.writer.Error()
+	}
+	return s.err
+}
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+type streamItem struct {
+	Sku string `validator:"length(3,_)"`
+}
+
+type streamRecord struct {
+	Name  string `validator:"length(1,_)"`
+	Items []streamItem
+}
+
+// sliceIterator returns an iterator over the given records, as a database cursor would
+func sliceIterator(records []streamRecord) func() (*streamRecord, bool) {
+	i := 0
+	return func() (*streamRecord, bool) {
+		if i == len(records) {
+			return nil, false
+		}
+		i++
+		return &records[i-1], true
+	}
+}
+
+func TestValidateStream(t *testing.T) {
+	records := []streamRecord{
+		{Name: "first", Items: []streamItem{{Sku: "abc"}}},
+		{Name: "", Items: []streamItem{{Sku: "a"}, {Sku: "abc"}, {Sku: "b"}}},
+		{Name: "third"},
+		{Name: "", Items: []streamItem{{Sku: "c,d"}}},
+	}
+
+	sink := &MemorySink{}
+	summary := ValidateStream(sliceIterator(records), sink)
+
+	assertEqual(t, 2, summary.Valid)
+	assertEqual(t, 2, summary.Invalid)
+	assertEqual(t, 4, summary.Total())
+	assertEqual(t, 0, summary.Errors)
+	assertEqual(t, map[string]int{"Name": 2, "Items[].Sku": 2}, summary.FieldFailures)
+
+	assertEqual(t, 2, len(sink.Failures))
+	assertEqual(t, 1, sink.Failures[0].Index)
+	assertEqual(t, 3, len(sink.Failures[0].Result.FieldErrors))
+	assertEqual(t, 3, sink.Failures[1].Index)
+}
+
+func TestCSVSink(t *testing.T) {
+	records := []streamRecord{
+		{Name: "first"},
+		{Name: "", Items: []streamItem{{Sku: "a"}}},
+	}
+
+	var out strings.Builder
+	sink := NewCSVSink(&out)
+	summary := ValidateStream(sliceIterator(records), sink)
+	assertNull(t, sink.Flush())
+
+	assertEqual(t, 1, summary.Invalid)
+	assertEqual(t, "index,field,message\n"+
+		"1,Name,length (0) must be at least 1\n"+
+		"1,Items[0].Sku,length (1) must be at least 3\n", out.String())
+}
+
+func TestValidateStreamWithoutSink(t *testing.T) {
+	summary := ValidateStream(func() (*int, bool) { return new(int), false }, nil)
+	assertEqual(t, 0, summary.Total())
+
+	count := 0
+	summary = ValidateStream(func() (*int, bool) {
+		count++
+		return new(int), count <= 2
+	}, nil)
+	assertEqual(t, 2, summary.Errors)
+	assertEqual(t, 2, summary.Invalid)
+}
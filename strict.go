@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// knownFlags holds the flags accepted by strict tag parsing, see ValidationOptions.StrictTagParsing
+var knownFlags = []ValidationFlag{OmitEmpty, AllowZero, SkipFilters, StopOnError, Sensitive}
+
+// strictTagProblems returns the problems strict tag parsing finds in the tags of the given field, see
+// ValidationOptions.StrictTagParsing. Problems the regular parser reports, such as unknown validators, are left to it.
+func strictTagProblems(structType reflect.Type, field reflect.StructField, opts *ValidationOptions) (problems []*RuleProblem) {
+	// tags of unexported fields are never read
+	if !field.IsExported() {
+		return nil
+	}
+	if problem := opts.TagLimits.checkTag(structType, field); problem != nil {
+		return []*RuleProblem{problem}
+	}
+	report := func(rule string, msg string) {
+		problems = append(problems, newTagError(structType, field, rule, msg))
+	}
+
+	entries, err := splitStructTag(string(field.Tag))
+	if err != nil {
+		report("", err.Error())
+		return
+	}
+
+	options := []string{opts.ValidatorTagName, opts.FilterTagName, opts.RulesTagName, opts.FlagTagName, opts.TriggerTagName, opts.MessageTagName, opts.LabelTagName}
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		for _, option := range options {
+			if !matchesTagName(entry.key, option, opts.CaseInsensitiveTagNames) {
+				continue
+			}
+			if seen[option] {
+				report("", "duplicate `"+entry.key+"` tag")
+			}
+			seen[option] = true
+			switch option {
+			case opts.ValidatorTagName:
+				problems = append(problems, strictFunctionProblems(structType, field, "validator", entry.value, opts.FunctionSeparator)...)
+			case opts.FilterTagName:
+				problems = append(problems, strictFunctionProblems(structType, field, "filter", entry.value, opts.FunctionSeparator)...)
+			case opts.RulesTagName:
+				problems = append(problems, strictFunctionProblems(structType, field, "rule", entry.value, opts.FunctionSeparator)...)
+			case opts.FlagTagName:
+				for _, flag := range strings.Split(entry.value, opts.FunctionSeparator) {
+					flag = strings.TrimSpace(flag)
+					if flag == "" {
+						report(entry.value, "empty flag in `"+entry.value+"`")
+					} else if !strings.HasPrefix(flag, string(Timeout)+"=") && !slices.Contains(knownFlags, ValidationFlag(flag)) {
+						report(flag, "unknown flag `"+flag+"`")
+					}
+				}
+			case opts.TriggerTagName:
+				for _, trigger := range strings.Split(entry.value, ",") {
+					if strings.TrimSpace(trigger) == "" {
+						report(entry.value, "empty trigger in `"+entry.value+"`")
+					} else if trigger != strings.TrimSpace(trigger) {
+						report(trigger, "whitespace around trigger `"+strings.TrimSpace(trigger)+"`")
+					}
+				}
+			}
+			break
+		}
+	}
+	return
+}
+
+// strictFunctionProblems returns the problems strict tag parsing finds in the given list of validators or filters:
+// unbalanced parentheses, empty entries, whitespace between names and arguments, and names containing characters
+// no function name contains, such as a trailing comma.
+func strictFunctionProblems(structType reflect.Type, field reflect.StructField, kind string, value string, separator string) (problems []*RuleProblem) {
+	report := func(rule string, msg string) {
+		problems = append(problems, newTagError(structType, field, rule, msg))
+	}
+
+	parts, err := splitTopLevel(value, separator)
+	if err != nil {
+		report(value, "invalid "+kind+" tag: "+err.Error())
+		return
+	}
+	for _, part := range parts {
+		function := strings.TrimSpace(part)
+		if function == "" {
+			report(value, "empty "+kind+" in `"+value+"`")
+			continue
+		}
+		name, _, hasArgs := strings.Cut(function, "(")
+		if trimmed := strings.TrimRight(name, " \t"); hasArgs && trimmed != name {
+			report(function, "whitespace between `"+trimmed+"` and its arguments")
+			continue
+		}
+		if strings.ContainsAny(name, " \t,'\")") {
+			report(function, "invalid "+kind+" name `"+name+"`")
+		}
+	}
+	return
+}
+
+// problemsError converts the problems found in the given struct type into the error reported when validating it.
+// Only the first problem is reported, unless ValidationOptions.StrictTagParsing is set, in which case all of them are.
+func problemsError(t reflect.Type, problems []*RuleProblem, opts *ValidationOptions) *ValidationError {
+	if len(problems) == 1 || !opts.StrictTagParsing {
+		return problems[0].validationError()
+	}
+	errs := make([]error, len(problems))
+	for i, problem := range problems {
+		errs[i] = problem
+	}
+	return newValidationError("invalid struct tags in "+t.String(), errors.Join(errs...))
+}
@@ -0,0 +1,133 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictTagParsing(t *testing.T) {
+	type Request struct {
+		A string `validator:"required,"`
+		B int    `validator:"min (5)|max(10)"`
+		C string `validator:"required" flags:"allowzero|omitempty"`
+		D string `validator:"required|" trigger:"create,"`
+		E string `validator:"required" validator:"min(1)"`
+		F string `validator:"length(1,3" filter:"trim"`
+		G string `validator:"length(1,3)" flags:"sensitive|timeout=2s|stop_on_error" trigger:"create,update" label:"G"`
+		h string `validator:"min (5)"`
+	}
+	strict := New(func(opts *ValidationOptions) {
+		opts.StrictTagParsing = true
+	})
+
+	err := strict.Register(&Request{})
+	assert.EqualError(t, err, "struct validator.Request, field A, rule `required,`: invalid validator name `required,`\n"+
+		"struct validator.Request, field B, rule `min (5)`: whitespace between `min` and its arguments\n"+
+		"struct validator.Request, field C, rule `allowzero`: unknown flag `allowzero`\n"+
+		"struct validator.Request, field D, rule `required|`: empty validator in `required|`\n"+
+		"struct validator.Request, field D, rule `create,`: empty trigger in `create,`\n"+
+		"struct validator.Request, field E: duplicate `validator` tag\n"+
+		"struct validator.Request, field F, rule `length(1,3`: invalid validator tag: unbalanced parentheses in `length(1,3`")
+
+	assert.ErrorAs(t, err, new(*RuleProblem))
+	assertEqual(t, 7, len(strict.CheckStruct(&Request{})))
+
+	// validation reports all problems at once
+	assert.PanicsWithError(t, "invalid struct tags in validator.Request: "+err.Error(), func() {
+		strict.Validate(&Request{})
+	}, "expected the panic to list all problems")
+	res := strict.ValidateWithOptions(&Request{}, func() ValidationOptions {
+		opts := strict.currentOptions()
+		opts.PanicOnTagError = false
+		return opts
+	}())
+	assertEqual(t, "invalid struct tags in validator.Request: "+err.Error(), res.Error.Error())
+
+	// tolerated, or reported one at a time, by default
+	res = New(func(opts *ValidationOptions) {
+		opts.PanicOnTagError = false
+	}).Validate(&Request{})
+	assertEqual(t, "struct validator.Request, field A, rule `required,`: validator `required,` not found", res.Error.Error())
+
+	type Valid struct {
+		Name  string `validator:"required|length(1,3)" filter:"trim" flags:"omitempty" trigger:"!import,admin.*"`
+		Email string `validator:"enum('a,b', c)" message:"invalid"`
+		Code  string `rules:"trim|v:length(1,3)"`
+	}
+	assert.NoError(t, strict.Register(&Valid{}))
+}
+
+func TestSkipMarkerAndStrictTags(t *testing.T) {
+	type Address struct {
+		City string `validator:"length(2,_)"`
+	}
+	type Profile struct {
+		Name     string `validator:"length(3,_)"`
+		Nickname string `validator:"-"`
+		Notes    string
+		Internal string `validator:" - " filter:"upper"`
+		Home     Address
+		Legacy   Address  `validator:"-"`
+		Previous *Address `validator:"-"`
+		secret   string
+	}
+
+	profile := func() Profile {
+		return Profile{
+			Name:     " Jane ",
+			Nickname: " jj ",
+			Notes:    " x ",
+			Internal: " abc ",
+			Home:     Address{City: "Oslo"},
+			Legacy:   Address{City: "x"},
+			Previous: &Address{City: "y"},
+		}
+	}
+
+	// skipped fields are neither validated nor filtered, even with StringAutoTrim
+	v := New(func(opts *ValidationOptions) {
+		opts.StringAutoTrim = true
+	})
+	p := profile()
+	assertTrue(t, v.Validate(&p).IsValid(), "expected skipped fields and nested structs not to be validated")
+	assertEqual(t, "Jane", p.Name)
+	assertEqual(t, " jj ", p.Nickname)
+	assertEqual(t, " abc ", p.Internal)
+	rules, err := v.ParseStruct(reflect.TypeOf(Profile{}), nil)
+	assert.NoError(t, err)
+	fields := []string{}
+	for _, rule := range rules {
+		fields = append(fields, rule.Field)
+	}
+	assertEqual(t, []string{"Name", "Home.City"}, fields)
+
+	// strict tags require rules or the marker on every exported field, except for fields containing structs
+	strict := New(func(opts *ValidationOptions) {
+		opts.StrictTags = true
+		opts.StrictTagParsing = true
+	})
+	assert.EqualError(t, strict.Register(&Profile{}), "struct validator.Profile, field Notes: "+
+		"field has no rules, add a validator tag or `validator:\"-\"` to skip it (StrictTags)")
+
+	type Complete struct {
+		Name    string `validator:"length(3,_)"`
+		Notes   string `validator:"-"`
+		Labeled string `label:"Labeled" filter:"trim"`
+		Home    Address
+		Items   []*Address
+	}
+	err = strict.Register(&Complete{})
+	assert.EqualError(t, err, "struct validator.Complete, field Labeled: field has no rules, add a validator tag or `validator:\"-\"` to skip it (StrictTags)")
+
+	// the option is part of the cache key
+	lenient := New()
+	assert.NoError(t, lenient.Register(&Complete{}))
+	var opts ValidationOptions
+	lenient.CopyOptions(&opts)
+	opts.StrictTags = true
+	opts.PanicOnTagError = false
+	res := lenient.ValidateWithOptions(&Complete{}, opts)
+	assertEqual(t, "struct validator.Complete, field Labeled: field has no rules, add a validator tag or `validator:\"-\"` to skip it (StrictTags)", res.Error.Error())
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Validatable is implemented by structs enforcing invariants that span multiple fields, such as a start date
+// preceding an end date.
+//
+// ValidateStruct is called after the validators and filters of all fields of the struct have been applied, for
+// the validated struct as well as for nested structs. Use a pointer receiver to inspect filtered values.
+type Validatable interface {
+	ValidateStruct(sl StructLevel)
+}
+
+// StructLevel StructLevel gives struct level validation access to the struct being validated and allows it to
+// report field errors into the result of the current validation.
+type StructLevel struct {
+	// Value the struct being validated
+	Value reflect.Value
+	// Trigger the active triggers of the current validation, comma separated, e.g. "update,audit"
+	Trigger string
+	// Triggers the active triggers of the current validation
+	Triggers []string
+	// Options the options used for the current validation
+	Options *ValidationOptions
+
+	path   string
+	errors *[]FieldError
+}
+
+// AddFieldError AddFieldError reports an error for the given field of the struct. The field is prefixed with the
+// path of the struct relative to the root struct, e.g. Periods[0].EndDate.
+//
+// When ValidationOptions.StopOnFirstError is set, only the first error is kept.
+func (sl StructLevel) AddFieldError(field string, message string) {
+	if sl.Options.StopOnFirstError && len(*sl.errors) > 0 {
+		return
+	}
+	*sl.errors = append(*sl.errors, FieldError{Field: sl.path + field, Message: message})
+}
+
+// validatable returns the given struct value as a Validatable, if the struct or a pointer to it implements the interface
+func validatable(structValue reflect.Value) (Validatable, bool) {
+	// structs reached through unexported embedded pointers cannot be converted to interfaces
+	if !structValue.CanInterface() {
+		return nil, false
+	}
+	if structValue.CanAddr() {
+		if s, ok := structValue.Addr().Interface().(Validatable); ok {
+			return s, true
+		}
+	}
+	s, ok := structValue.Interface().(Validatable)
+	return s, ok
+}
+
+// validateStructLevel calls the struct level validation of the given struct value and of the struct values
+// contained in its named struct fields, innermost first. Embedded structs are covered through their promoted method.
+func (v *Validator) validateStructLevel(state *validationState, structValue reflect.Value, path string) []FieldError {
+	var errorList []FieldError
+
+	t := structValue.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type.Kind() != reflect.Struct || field.Anonymous || !field.IsExported() {
+			continue
+		}
+		errorList = append(errorList, v.validateStructLevel(state, structValue.Field(i), path+field.Name+".")...)
+		if len(errorList) > 0 && state.opts.StopOnFirstError {
+			return errorList
+		}
+	}
+
+	// structs validated partially skip struct level validation, see Validator.ValidateFields
+	if s, ok := validatable(structValue); ok && state.selection.whole(path) {
+		s.ValidateStruct(StructLevel{
+			Value:    structValue,
+			Trigger:  strings.Join(state.triggers, ","),
+			Triggers: state.triggers,
+			Options:  state.opts,
+			path:     path,
+			errors:   &errorList,
+		})
+	}
+
+	return errorList
+}
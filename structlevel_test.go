@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bookingPeriod struct {
+	StartDate string `validator:"required" filter:"trim"`
+	EndDate   string `validator:"required" filter:"trim"`
+}
+
+// ValidateStruct requires the start date to precede the end date
+func (p *bookingPeriod) ValidateStruct(sl StructLevel) {
+	start, err1 := time.Parse(defaultLayout, p.StartDate)
+	end, err2 := time.Parse(defaultLayout, p.EndDate)
+	if err1 == nil && err2 == nil && !start.Before(end) {
+		sl.AddFieldError("EndDate", "end date must be after start date")
+	}
+}
+
+type contactForm struct {
+	Email string
+	Phone string
+}
+
+// ValidateStruct requires exactly one of email and phone, using a value receiver
+func (f contactForm) ValidateStruct(sl StructLevel) {
+	if (f.Email == "") == (f.Phone == "") {
+		sl.AddFieldError("Email", "exactly one of email and phone must be set")
+		sl.AddFieldError("Phone", "exactly one of email and phone must be set")
+	}
+}
+
+type booking struct {
+	Guest   string `validator:"length(1,_)"`
+	Stay    bookingPeriod
+	Periods []bookingPeriod
+	Contact *contactForm
+}
+
+func TestStructLevelValidation(t *testing.T) {
+	period := bookingPeriod{StartDate: " 2023-05-10 ", EndDate: "2023-05-12"}
+	assertTrue(t, Validate(&period).IsValid(), "expected filtered dates to pass")
+
+	period.EndDate = "2023-05-01"
+	res := Validate(&period)
+	assertFalse(t, res.IsValid(), "expected end date before start date to fail")
+	assertEqual(t, []FieldError{{Field: "EndDate", Message: "end date must be after start date"}}, res.FieldErrors)
+}
+
+func TestNestedStructLevelValidation(t *testing.T) {
+	form := booking{
+		Stay: bookingPeriod{StartDate: "2023-05-10", EndDate: "2023-05-01"},
+		Periods: []bookingPeriod{
+			{StartDate: "2023-05-10", EndDate: "2023-05-12"},
+			{StartDate: "2023-06-10", EndDate: "2023-06-01"},
+		},
+		Contact: &contactForm{},
+	}
+
+	res := Validate(&form)
+	assertFalse(t, res.IsValid(), "expected validation to fail")
+	assertEqual(t, []FieldError{
+		{Field: "Guest", Message: "length (0) must be at least 1", Validator: "length"},
+		{Field: "Periods[1].EndDate", Message: "end date must be after start date"},
+		{Field: "Contact.Email", Message: "exactly one of email and phone must be set"},
+		{Field: "Contact.Phone", Message: "exactly one of email and phone must be set"},
+		{Field: "Stay.EndDate", Message: "end date must be after start date"},
+	}, res.FieldErrors)
+
+	// struct passed by value: the value receiver still applies
+	contact := contactForm{Email: "a@b.c"}
+	assertTrue(t, Validate(contact).IsValid(), "expected a single contact method to pass")
+}
+
+func TestStructLevelStopOnFirstError(t *testing.T) {
+	opts := ValidationOptions{}
+	CopyOptions(&opts)
+	opts.StopOnFirstError = true
+
+	res := ValidateWithOptions(&contactForm{}, opts)
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "Email", res.FieldErrors[0].Field)
+
+	// struct level validation is skipped once a field has failed
+	res = ValidateWithOptions(&booking{Stay: bookingPeriod{StartDate: "2023-05-10", EndDate: "2023-05-01"}}, opts)
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "Guest", res.FieldErrors[0].Field)
+}
+
+func TestStructLevelTrigger(t *testing.T) {
+	var triggers []string
+	form := triggerForm{record: func(sl StructLevel) {
+		triggers = append(triggers, sl.Trigger)
+		assert.Equal(t, "Name", sl.Value.Type().Field(0).Name)
+	}}
+
+	Validate(&form)
+	Validate(&form, "update")
+	assertEqual(t, []string{"all", "update"}, triggers)
+}
+
+type triggerForm struct {
+	Name   string
+	record func(sl StructLevel)
+}
+
+func (f *triggerForm) ValidateStruct(sl StructLevel) {
+	f.record(sl)
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// tagNames returns the tag names listed by a tag name option, in order of preference, e.g. validator and validate
+// for "validator,validate"
+func tagNames(option string) []string {
+	names := strings.Split(option, ",")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+	}
+	return names
+}
+
+// matchesTagName tests whether the given struct tag key is one of the names listed by the given tag name option
+func matchesTagName(key string, option string, caseInsensitive bool) bool {
+	for _, name := range tagNames(option) {
+		if key == name || caseInsensitive && strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupTag returns the value of the tag named by the given tag name option, which may list fallback names such as
+// "validator,validate". Keys are compared case-insensitively if caseInsensitive is set. An error is returned if the
+// tag carries more than one of the names, as the rules of the field would be ambiguous.
+func lookupTag(tag reflect.StructTag, option string, caseInsensitive bool) (value string, ok bool, err error) {
+	if !caseInsensitive && !strings.Contains(option, ",") {
+		value, ok = tag.Lookup(option)
+		return value, ok, nil
+	}
+
+	var found string
+	consider := func(key string, v string) error {
+		if ok && key == found {
+			// like reflect.StructTag.Lookup, repeated keys resolve to their first occurrence
+			return nil
+		}
+		if ok {
+			return errors.New("ambiguous tags: found both `" + found + "` and `" + key + "`")
+		}
+		found, value, ok = key, v, true
+		return nil
+	}
+
+	if !caseInsensitive {
+		for _, name := range tagNames(option) {
+			if v, present := tag.Lookup(name); present {
+				if err := consider(name, v); err != nil {
+					return "", false, err
+				}
+			}
+		}
+		return value, ok, nil
+	}
+
+	entries, err := splitStructTag(string(tag))
+	if err != nil {
+		return "", false, err
+	}
+	for _, entry := range entries {
+		if matchesTagName(entry.key, option, true) {
+			if err := consider(entry.key, entry.value); err != nil {
+				return "", false, err
+			}
+		}
+	}
+	return value, ok, nil
+}
@@ -0,0 +1,213 @@
+package validator
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultLayout is the layout used by date validators when none is specified
+	defaultLayout = "2006-01-02"
+
+	// unixLayout is the layout alias for integer Unix timestamps in seconds
+	unixLayout = "unix"
+
+	// unixMilliLayout is the layout alias for integer Unix timestamps in milliseconds
+	unixMilliLayout = "unixmilli"
+)
+
+// maxPlausibleAge is the maximum age implied by a date of birth accepted by the dob validator
+const maxPlausibleAge = 130
+
+// layoutAliases maps friendly layout names accepted by date validators to Go layouts
+var layoutAliases = map[string]string{
+	"iso8601":       "2006-01-02T15:04:05Z07:00",
+	"rfc3339":       time.RFC3339,
+	"date":          "2006-01-02",
+	"datetime":      "2006-01-02 15:04:05",
+	unixLayout:      unixLayout,
+	unixMilliLayout: unixMilliLayout,
+}
+
+// errAbsentTime indicates that a temporal value is blank and must be treated as absent
+var errAbsentTime = errors.New("absent time value")
+
+// layoutAliasNames returns the sorted list of supported layout aliases
+func layoutAliasNames() []string {
+	names := make([]string, 0, len(layoutAliases))
+	for name := range layoutAliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveLayout translates the given layout alias into a Go layout.
+//
+// Arguments that are not aliases are returned as is if they contain at least one element of Go's reference time.
+func resolveLayout(layout string) (string, error) {
+	if resolved, ok := layoutAliases[strings.ToLower(layout)]; ok {
+		return resolved, nil
+	}
+	// a layout without any reference time element formats to itself
+	if (time.Time{}).Format(layout) == layout {
+		return "", errors.New("unsupported date layout `" + layout + "`. supported aliases are " + strings.Join(layoutAliasNames(), ", ") + " or a Go time layout")
+	}
+	return layout, nil
+}
+
+// mustResolveLayout resolves the given layout, panicking if it is not supported
+func mustResolveLayout(layout string) string {
+	resolved, err := resolveLayout(layout)
+	if err != nil {
+		panic(newValidationError(err.Error()))
+	}
+	return resolved
+}
+
+// checkLayoutArgument verifies the optional layout argument of date validators at parse time
+func checkLayoutArgument(args []string) error {
+	if len(args) == 1 {
+		_, err := resolveLayout(args[0])
+		return err
+	}
+	return nil
+}
+
+// parseTime parses the given string value using the given resolved layout
+func parseTime(value string, layout string) (time.Time, error) {
+	switch layout {
+	case unixLayout, unixMilliLayout:
+		timestamp, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return unixTime(timestamp, layout), nil
+	default:
+		return time.Parse(layout, value)
+	}
+}
+
+// unixTime converts the given Unix timestamp, interpreted in seconds unless the layout is unixmilli
+func unixTime(timestamp int64, layout string) time.Time {
+	if layout == unixMilliLayout {
+		return time.UnixMilli(timestamp)
+	}
+	return time.Unix(timestamp, 0)
+}
+
+// formatTime formats the given time using the given resolved layout
+func formatTime(t time.Time, layout string) string {
+	switch layout {
+	case unixLayout:
+		return strconv.FormatInt(t.Unix(), 10)
+	case unixMilliLayout:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	default:
+		return t.Format(layout)
+	}
+}
+
+// dateRange holds the arguments of the between_dates validator
+type dateRange struct {
+	from      time.Time
+	to        time.Time
+	layout    string
+	exclusive bool
+}
+
+// parseDateRange parses the arguments of the between_dates validator: two reference dates, followed by an optional
+// layout and an optional 'exclusive' modifier.
+func parseDateRange(args []string) (r dateRange, err error) {
+	if len(args) < 2 || len(args) > 4 {
+		return r, errors.New("between_dates: expected from and to dates, followed by an optional layout and 'exclusive' modifier")
+	}
+
+	r.layout = defaultLayout
+	for _, arg := range args[2:] {
+		if arg == "exclusive" {
+			r.exclusive = true
+		} else if r.layout, err = resolveLayout(arg); err != nil {
+			return r, err
+		}
+	}
+
+	if r.from, err = parseTime(args[0], r.layout); err != nil {
+		return r, errors.New("between_dates: invalid from date " + args[0] + ": " + err.Error())
+	}
+	if r.to, err = parseTime(args[1], r.layout); err != nil {
+		return r, errors.New("between_dates: invalid to date " + args[1] + ": " + err.Error())
+	}
+	if r.to.Before(r.from) {
+		return r, errors.New("between_dates: from date " + args[0] + " is after to date " + args[1])
+	}
+	return r, nil
+}
+
+// checkDateRangeArguments verifies the arguments of the between_dates validator at parse time
+func checkDateRangeArguments(args []string) error {
+	_, err := parseDateRange(args)
+	return err
+}
+
+// currentTime returns the current time according to ValidationOptions.Clock, in ValidationOptions.TimeZone
+func currentTime(opts *ValidationOptions) time.Time {
+	now := time.Now()
+	if opts.Clock != nil {
+		now = opts.Clock()
+	}
+	if opts.TimeZone != nil {
+		now = now.In(opts.TimeZone)
+	}
+	return now
+}
+
+// age returns the number of full years elapsed between the given date of birth and the given time.
+//
+// The date of birth is interpreted in the location of now.
+func age(dob time.Time, now time.Time) int {
+	dob = dob.In(now.Location())
+	years := now.Year() - dob.Year()
+	if now.Month() < dob.Month() || (now.Month() == dob.Month() && now.Day() < dob.Day()) {
+		years--
+	}
+	return years
+}
+
+// ageRange holds the arguments of the age_between validator
+type ageRange struct {
+	min    int
+	max    int
+	layout string
+}
+
+// parseAgeRange parses the arguments of the age_between validator: minimum and maximum age, followed by an
+// optional layout.
+func parseAgeRange(args []string) (r ageRange, err error) {
+	if len(args) < 2 || len(args) > 3 {
+		return r, errors.New("age_between: expected minimum and maximum age, followed by an optional layout")
+	}
+	if r.min, err = strconv.Atoi(args[0]); err != nil {
+		return r, errors.New("age_between: invalid minimum age " + args[0])
+	}
+	if r.max, err = strconv.Atoi(args[1]); err != nil {
+		return r, errors.New("age_between: invalid maximum age " + args[1])
+	}
+	if r.min < 0 || r.max < r.min {
+		return r, errors.New("age_between: invalid age range " + args[0] + " to " + args[1])
+	}
+	r.layout = defaultLayout
+	if len(args) == 3 {
+		r.layout, err = resolveLayout(args[2])
+	}
+	return r, err
+}
+
+// checkAgeRangeArguments verifies the arguments of the age_between validator at parse time
+func checkAgeRangeArguments(args []string) error {
+	_, err := parseAgeRange(args)
+	return err
+}
@@ -0,0 +1,280 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutAliases(t *testing.T) {
+	type Form struct {
+		ISO8601   string `validator:"before_today(iso8601)"`
+		RFC3339   string `validator:"before_today(rfc3339)"`
+		Date      string `validator:"before_today(date)"`
+		DateTime  string `validator:"before_today(datetime)"`
+		Unix      string `validator:"before_today(unix)"`
+		UnixMilli string `validator:"before_today(unixmilli)"`
+		Epoch     int64  `validator:"before_today(unix)"`
+		Layout    string `validator:"before_today(02/01/2006)"`
+	}
+
+	form := Form{
+		ISO8601:   "2000-01-02T15:04:05Z",
+		RFC3339:   "2000-01-02T15:04:05+02:00",
+		Date:      "2000-01-02",
+		DateTime:  "2000-01-02 15:04:05",
+		Unix:      "946684800",
+		UnixMilli: "946684800000",
+		Epoch:     946684800,
+		Layout:    "02/01/2000",
+	}
+	res := Validate(&form)
+	assertTrue(t, res.IsValid(), "validation failed")
+
+	type Future struct {
+		Epoch int64  `validator:"after_today(unix)"`
+		Unix  string `validator:"after_today(UNIX)"`
+	}
+	res = Validate(&Future{Epoch: 946684800, Unix: "not a number"})
+	assertEqual(t, 2, len(res.FieldErrors))
+	assertTrue(t, strings.HasPrefix(res.FieldErrors[0].Message, "946684800 must be after "), res.FieldErrors[0].Message)
+	assertEqual(t, "invalid date format. expected format is unix", res.FieldErrors[1].Message)
+}
+
+func TestUnknownLayoutAlias(t *testing.T) {
+	type Form struct {
+		Date string `validator:"before_today(YYYY-MM-DD)"`
+	}
+
+	defer func() {
+		err := recover().(*ValidationError)
+		assert.Contains(t, err.Error(), "unsupported date layout `YYYY-MM-DD`")
+		assert.Contains(t, err.Error(), "date, datetime, iso8601, rfc3339, unix, unixmilli")
+	}()
+	Validate(&Form{})
+}
+
+func TestUnixTimestampFields(t *testing.T) {
+	type Form struct {
+		Seconds  int64  `validator:"before_today"`
+		Millis   int64  `validator:"before_today(unixmilli)"`
+		Unsigned uint32 `validator:"before_today"`
+		Pointer  *int   `validator:"before_today"`
+	}
+
+	future := time.Now().Add(48 * time.Hour)
+
+	res := Validate(&Form{Seconds: 946684800, Millis: 946684800000, Unsigned: 946684800})
+	assertTrue(t, res.IsValid(), "validation failed")
+
+	// pre-1970 timestamps are negative
+	res = Validate(&Form{Seconds: -86400, Millis: -86400000})
+	assertTrue(t, res.IsValid(), "validation failed")
+
+	// zero timestamps are absent
+	zero := 0
+	res = Validate(&Form{Pointer: &zero})
+	assertTrue(t, res.IsValid(), "validation failed")
+
+	res = Validate(&Form{Seconds: future.Unix(), Millis: future.UnixMilli()})
+	assertEqual(t, 2, len(res.FieldErrors))
+	assertEqual(t, future.Format("2006-01-02")+" must be before "+time.Now().Format("2006-01-02"), res.FieldErrors[0].Message)
+	assertTrue(t, strings.HasPrefix(res.FieldErrors[1].Message, strconv.FormatInt(future.UnixMilli(), 10)), res.FieldErrors[1].Message)
+
+	var opts ValidationOptions
+	CopyOptions(&opts)
+	opts.RejectEmptyDates = true
+	type Strict struct {
+		Seconds int64 `validator:"after_today"`
+	}
+	res = ValidateWithOptions(&Strict{}, opts)
+	assertFalse(t, res.IsValid(), "expected zero timestamp to fail in strict mode")
+}
+
+func TestBetweenDates(t *testing.T) {
+	type Campaign struct {
+		Start     string     `validator:"between_dates(2024-01-01,2024-12-31)"`
+		End       *time.Time `validator:"between_dates(2024-01-01,2024-12-31,exclusive)"`
+		Timestamp int64      `validator:"between_dates(1704067200,1735603200,unix)"`
+		Month     *string    `validator:"between_dates(01/2024,12/2024,01/2006)"`
+	}
+
+	month := "06/2024"
+	end := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	campaign := Campaign{
+		Start:     "2024-01-01",
+		End:       &end,
+		Timestamp: 1704067200,
+		Month:     &month,
+	}
+	res := Validate(&campaign)
+	assertTrue(t, res.IsValid(), "validation failed")
+
+	month = "01/2025"
+	end = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	campaign = Campaign{
+		Start:     "2025-01-01",
+		End:       &end,
+		Timestamp: 1704067199,
+		Month:     &month,
+	}
+	res = Validate(&campaign)
+	assertEqual(t, 4, len(res.FieldErrors))
+	assertEqual(t, "2025-01-01 must be between 2024-01-01 and 2024-12-31", res.FieldErrors[0].Message)
+	assertEqual(t, "2024-12-31 must be strictly between 2024-01-01 and 2024-12-31", res.FieldErrors[1].Message)
+	assertEqual(t, "1704067199 must be between 1704067200 and 1735603200", res.FieldErrors[2].Message)
+	assertEqual(t, "01/2025 must be between 01/2024 and 12/2024", res.FieldErrors[3].Message)
+}
+
+func TestBetweenDatesInvalidReference(t *testing.T) {
+	type Campaign struct {
+		Start string `validator:"between_dates(2024-01-01,2024-13-01)"`
+	}
+
+	var opts ValidationOptions
+	CopyOptions(&opts)
+	opts.PanicOnTagError = false
+
+	res := ValidateWithOptions(&Campaign{}, opts)
+	assertFalse(t, res.IsValid(), "expected tag error")
+	assert.Contains(t, res.Error.Error(), "invalid to date 2024-13-01")
+}
+
+func TestAgeValidators(t *testing.T) {
+	type Employee struct {
+		BirthDate string `validator:"age_between(18,65)"`
+		Epoch     int64  `validator:"dob"`
+		Born      string `validator:"dob(02/01/2006)"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.Clock = func() time.Time {
+			return time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+		}
+		opts.TimeZone = time.UTC
+	})
+
+	employee := Employee{
+		BirthDate: "2006-06-15",
+		Epoch:     time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		Born:      "01/01/1990",
+	}
+	assertTrue(t, v.Validate(&employee).IsValid(), "validation failed")
+
+	employee = Employee{BirthDate: "2006-06-16", Epoch: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), Born: "01/01/1890"}
+	res := v.Validate(&employee)
+	assertEqual(t, 3, len(res.FieldErrors))
+	assertEqual(t, "age must be between 18 and 65", res.FieldErrors[0].Message)
+	assertEqual(t, "date of birth must be in the past", res.FieldErrors[1].Message)
+	assertEqual(t, "age must be between 0 and 130", res.FieldErrors[2].Message)
+
+	v.SetupOptions(func(opts *ValidationOptions) {
+		opts.IncludeFieldValues = true
+	})
+	res = v.Validate(&employee)
+	assertEqual(t, "age (17) must be between 18 and 65", res.FieldErrors[0].Message)
+	assertEqual(t, "age (134) must be between 0 and 130", res.FieldErrors[2].Message)
+}
+
+func TestDateParseErrorCause(t *testing.T) {
+	type Form struct {
+		Date string `validator:"before_today"`
+	}
+
+	res := Validate(&Form{Date: "2024-13-01"})
+	assertEqual(t, "invalid date format. expected format is 2006-01-02", res.FieldErrors[0].Message)
+
+	var parseError *time.ParseError
+	assertTrue(t, errors.As(res.FieldErrors[0], &parseError), "expected a time.ParseError")
+	assertEqual(t, "2024-13-01", parseError.Value)
+
+	encoded, err := json.Marshal(res.FieldErrors[0])
+	assert.NoError(t, err)
+	assertEqual(t, `{"field":"Date","message":"invalid date format. expected format is 2006-01-02","validator":"before_today"}`, string(encoded))
+
+	var opts ValidationOptions
+	CopyOptions(&opts)
+	opts.ExposeUnderlyingErrors = true
+
+	res = ValidateWithOptions(&Form{Date: "2024-13-01"}, opts)
+	assertEqual(t, "invalid date format. expected format is 2006-01-02: "+parseError.Error(), res.FieldErrors[0].Message)
+}
+
+type birthDate time.Time
+
+func TestDefinedTimeTypes(t *testing.T) {
+	type Person struct {
+		Born      *birthDate `validator:"before_today|age_between(18,65)"`
+		Signature *time.Time `validator:"before_today"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.Clock = func() time.Time {
+			return time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+		}
+	})
+
+	born := birthDate(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC))
+	assertTrue(t, v.Validate(&Person{Born: &born}).IsValid(), "expected a defined time type to be accepted")
+
+	born = birthDate(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC))
+	res := v.Validate(&Person{Born: &born})
+	assertFalse(t, res.IsValid(), "expected a minor to fail")
+	assertEqual(t, "age must be between 18 and 65", res.FieldErrors[0].Message)
+}
+
+// version is a struct printed as a version number, validated as a value when tagged
+type version struct {
+	Major, Minor int
+}
+
+func (v version) String() string {
+	return strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor)
+}
+
+func TestTimeFields(t *testing.T) {
+	type Person struct {
+		BirthDate   time.Time  `validator:"before_today"`
+		Signed      *time.Time `validator:"before_today"`
+		Appointment time.Time  `validator:"after_today"`
+		Renewal     *time.Time `validator:"required|after_today"`
+	}
+
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	v := New(func(opts *ValidationOptions) {
+		opts.Clock = func() time.Time {
+			return now
+		}
+	})
+
+	past, future := now.AddDate(-1, 0, 0), now.AddDate(0, 1, 0)
+	person := Person{BirthDate: past, Signed: &past, Appointment: future, Renewal: &future}
+	assertTrue(t, v.Validate(&person).IsValid(), "expected dates on the right side of today to pass")
+
+	res := v.Validate(&Person{BirthDate: future, Signed: &future, Appointment: past})
+	fields := make([]string, 0, len(res.FieldErrors))
+	for _, fe := range res.FieldErrors {
+		fields = append(fields, fe.Field+" "+fe.Validator)
+	}
+	assertEqual(t, []string{"BirthDate before_today", "Signed before_today", "Appointment after_today", "Renewal required"}, fields)
+
+	// time values are validated as values in read-only mode as well
+	assertFalse(t, v.Validate(Person{BirthDate: future, Renewal: &future}).IsValid(), "expected a future birth date to fail")
+
+	// tagged fmt.Stringer structs are validated as values, untagged ones are traversed
+	type Release struct {
+		Version  version `validator:"stable"`
+		Previous version
+	}
+	v.AddValidator("stable", func(ctx *ValidationContext) bool {
+		return ctx.GetValue().Interface().(version).Major > 0
+	})
+	assertTrue(t, v.Validate(&Release{Version: version{Major: 1}}).IsValid())
+	res = v.Validate(&Release{Version: version{Minor: 3}})
+	assertEqual(t, []FieldError{{Field: "Version", Message: "Version: field validation failed", Validator: "stable"}}, res.FieldErrors)
+}
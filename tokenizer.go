@@ -0,0 +1,203 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+)
+
+// splitTopLevel splits s at each occurrence of sep found outside parentheses and quoted arguments.
+//
+// A single quote opens a quoted argument at the start of s or right after '(' or ',', optionally followed by spaces,
+// and the next single quote not preceded by a backslash closes it. Within quoted arguments, parentheses, commas and
+// separators have no special meaning, allowing arguments such as '^a|b$' or 'a,b'. Outside quoted arguments, a
+// backslash followed by a comma or a single quote escapes it, e.g. New York\, NY. Backslashes preceding an escaped
+// character or ending an argument are themselves escaped by doubling them, see unescape. Unbalanced parentheses and
+// unterminated quotes are reported as errors.
+func splitTopLevel(s string, sep string) ([]string, error) {
+	parts, problem := scanTopLevel(s, sep)
+	if problem != "" {
+		return nil, errors.New(problem + " in `" + s + "`")
+	}
+	return parts, nil
+}
+
+// scanTopLevel splits s like splitTopLevel, returning a description of the syntax problem found, if any
+func scanTopLevel(s string, sep string) ([]string, string) {
+	var parts []string
+	depth := 0
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			// an odd number of backslashes escapes the following comma or quote
+			n := backslashes(s, i)
+			if i += n - 1; n%2 == 1 && i+1 < len(s) && (s[i+1] == ',' || s[i+1] == '\'') {
+				i++
+			}
+		case c == '\'' && opensQuote(s, i):
+			end := closingQuote(s, i+1)
+			if end < 0 {
+				return nil, "unterminated quote"
+			}
+			i = end
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return nil, "unbalanced parentheses"
+			}
+		case depth == 0 && strings.HasPrefix(s[i:], sep):
+			parts = append(parts, s[start:i])
+			start = i + len(sep)
+			i = start - 1
+		}
+	}
+	if depth != 0 {
+		return nil, "unbalanced parentheses"
+	}
+	return append(parts, s[start:]), ""
+}
+
+// opensQuote tests whether the single quote found at the given index of s opens a quoted argument, see splitTopLevel
+func opensQuote(s string, i int) bool {
+	j := i - 1
+	for j >= 0 && s[j] == ' ' {
+		j--
+	}
+	return j < 0 || s[j] == '(' || s[j] == ','
+}
+
+// closingQuote returns the index of the single quote closing the quoted argument starting at the given index of s,
+// skipping escaped quotes, or -1 if there is none
+func closingQuote(s string, from int) int {
+	for k := from; k < len(s); k++ {
+		switch {
+		case s[k] == '\\':
+			n := backslashes(s, k)
+			if k += n - 1; n%2 == 1 && k+1 < len(s) && s[k+1] == '\'' {
+				k++
+			}
+		case s[k] == '\'':
+			return k
+		}
+	}
+	return -1
+}
+
+// backslashes returns the number of consecutive backslashes found at the given index of s
+func backslashes(s string, i int) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == '\\' {
+		n++
+	}
+	return n
+}
+
+// unquote removes the single quotes enclosing the given argument along with the spaces around them, if any, and
+// replaces escape sequences, see unescape.
+func unquote(arg string) string {
+	if isQuoted(arg) {
+		quoted := strings.Trim(arg, " ")
+		return unescape(quoted[1:len(quoted)-1], true)
+	}
+	return unescape(arg, false)
+}
+
+// unescape replaces the escape sequences of the given argument, the content of a quoted argument if quoted is set.
+// Backslashes followed by a quote, by a comma outside quoted arguments or by the end of the argument are halved, an
+// odd one escaping the following character, e.g. a\\\,b reading as a\,b and 'a\\' as a\. Other characters,
+// including spaces and other backslashes such as in ^\d+$, are kept as is.
+func unescape(arg string, quoted bool) string {
+	if strings.IndexByte(arg, '\\') < 0 {
+		return arg
+	}
+	var sb strings.Builder
+	for i := 0; i < len(arg); i++ {
+		if arg[i] != '\\' {
+			sb.WriteByte(arg[i])
+			continue
+		}
+		n := backslashes(arg, i)
+		i += n
+		switch {
+		case i == len(arg) && n%2 == 0:
+			sb.WriteString(strings.Repeat(`\`, n/2))
+		case i < len(arg) && (arg[i] == '\'' || (!quoted && arg[i] == ',')):
+			sb.WriteString(strings.Repeat(`\`, n/2))
+			sb.WriteByte(arg[i])
+		default:
+			sb.WriteString(strings.Repeat(`\`, n))
+			i--
+		}
+	}
+	return sb.String()
+}
+
+// isQuoted tests whether the given argument is enclosed in single quotes, ignoring the spaces around them
+func isQuoted(arg string) bool {
+	quoted := strings.Trim(arg, " ")
+	return len(quoted) >= 2 && quoted[0] == '\'' && quoted[len(quoted)-1] == '\''
+}
+
+// extractFunctionInformation splits a function definition such as length(1,80) into the name of the function and
+// its arguments, unquoting quoted arguments and unescaping escaped ones, see splitTopLevel. Arguments are otherwise
+// kept as is, including spaces and trailing empty arguments, e.g. enum(a, b,) has the arguments "a", " b" and "".
+// Arguments may contain balanced parentheses nested at any depth, only commas outside of them separating arguments,
+// e.g. regex(^(\d{3}(-\d{4})?)$,x) has the arguments "^(\d{3}(-\d{4})?)$" and "x". Unbalanced parentheses and
+// unterminated quotes are reported as errors naming the whole definition.
+func extractFunctionInformation(funcDefinition string) (name string, args []string, err error) {
+	if name, args, err = scanFunction(funcDefinition); err != nil {
+		return "", nil, err
+	}
+	for i, arg := range args {
+		args[i] = unquote(arg)
+	}
+	return name, args, nil
+}
+
+// quotedArguments reports which arguments of the given function definition are quoted, e.g. false and true for
+// enum(a,' '), so that their content can be told apart from the spaces around unquoted arguments
+func quotedArguments(funcDefinition string) []bool {
+	_, args, err := scanFunction(funcDefinition)
+	if err != nil {
+		return nil
+	}
+	quoted := make([]bool, len(args))
+	for i, arg := range args {
+		quoted[i] = isQuoted(arg)
+	}
+	return quoted
+}
+
+// scanFunction splits a function definition into the name of the function and its raw arguments, see
+// extractFunctionInformation
+func scanFunction(funcDefinition string) (name string, args []string, err error) {
+	open := strings.IndexByte(funcDefinition, '(')
+	if open < 0 {
+		if strings.IndexByte(funcDefinition, ')') >= 0 {
+			return "", nil, errors.New("unbalanced parentheses in `" + funcDefinition + "`")
+		}
+		return funcDefinition, []string{}, nil
+	}
+	if !strings.HasSuffix(funcDefinition, ")") {
+		return "", nil, errors.New("expected `" + funcDefinition + "` to end with ')'")
+	}
+
+	name = funcDefinition[:open]
+	if strings.IndexByte(name, ')') >= 0 {
+		return "", nil, errors.New("unbalanced parentheses in `" + funcDefinition + "`")
+	}
+	inner := funcDefinition[open+1 : len(funcDefinition)-1]
+	if inner == "" {
+		return name, []string{}, nil
+	}
+
+	// the arguments are split at depth zero, the parentheses enclosing them having been removed
+	args, problem := scanTopLevel(inner, ",")
+	if problem != "" {
+		return "", nil, errors.New(problem + " in `" + funcDefinition + "`")
+	}
+	return name, args, nil
+}
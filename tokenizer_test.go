@@ -0,0 +1,167 @@
+package validator
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTopLevel(t *testing.T) {
+	tests := []struct {
+		input string
+		sep   string
+		parts []string
+		err   string
+	}{
+		{input: "required|min(1)", sep: "|", parts: []string{"required", "min(1)"}},
+		{input: "regex(^a|b$)|max(10)", sep: "|", parts: []string{"regex(^a|b$)", "max(10)"}},
+		{input: "regex('^(a|b$')|max(10)", sep: "|", parts: []string{"regex('^(a|b$')", "max(10)"}},
+		{input: "enum('a,b',c)|length(1,_)", sep: "|", parts: []string{"enum('a,b',c)", "length(1,_)"}},
+		{input: "min(1);;max(10)", sep: ";;", parts: []string{"min(1)", "max(10)"}},
+		{input: "'a,b',c's", sep: ",", parts: []string{"'a,b'", "c's"}},
+		{input: "", sep: "|", parts: []string{""}},
+		{input: "regex(^(a|b$)|max(10)", sep: "|", err: "unbalanced parentheses in `regex(^(a|b$)|max(10)`"},
+		{input: "min(1))|max(10)", sep: "|", err: "unbalanced parentheses in `min(1))|max(10)`"},
+		{input: "enum('a,b)", sep: "|", err: "unterminated quote in `enum('a,b)`"},
+	}
+
+	for _, test := range tests {
+		parts, err := splitTopLevel(test.input, test.sep)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.input)
+			continue
+		}
+		assert.NoError(t, err, test.input)
+		assertEqual(t, test.parts, parts, test.input)
+	}
+}
+
+func TestExtractFunctionInformation(t *testing.T) {
+	tests := []struct {
+		input string
+		name  string
+		args  []string
+		err   string
+	}{
+		{input: "required", name: "required", args: []string{}},
+		{input: "trim()", name: "trim", args: []string{}},
+		{input: "length(1,_)", name: "length", args: []string{"1", "_"}},
+		{input: "regex(^a|b$)", name: "regex", args: []string{"^a|b$"}},
+		{input: "enum('a,b','(c)',d)", name: "enum", args: []string{"a,b", "(c)", "d"}},
+		// the simple syntax is kept as is, including spaces, backslashes and empty arguments
+		{input: "enum(a, b ,c)", name: "enum", args: []string{"a", " b ", "c"}},
+		{input: `regex(^\d+\(x\)$)`, name: "regex", args: []string{`^\d+\(x\)$`}},
+		{input: "enum(,)", name: "enum", args: []string{"", ""}},
+		{input: "enum(a,b,)", name: "enum", args: []string{"a", "b", ""}},
+		{input: "enum(a,,b)", name: "enum", args: []string{"a", "", "b"}},
+		// escaped commas and quotes
+		{input: `enum(New York\, NY,Boston)`, name: "enum", args: []string{"New York, NY", "Boston"}},
+		{input: `enum(it\'s,\'quoted\')`, name: "enum", args: []string{"it's", "'quoted'"}},
+		{input: `enum(a\\,b)`, name: "enum", args: []string{`a\`, "b"}},
+		{input: `enum(a\\\,b,c\\)`, name: "enum", args: []string{`a\,b`, `c\`}},
+		{input: `enum(a\\b\\\'c\)`, name: "enum", args: []string{`a\\b\'c\`}},
+		// quoted arguments, spaces around them being ignored
+		{input: "enum('New York, NY', 'Boston' ,'')", name: "enum", args: []string{"New York, NY", "Boston", ""}},
+		{input: "enum('f(x)','(',')')", name: "enum", args: []string{"f(x)", "(", ")"}},
+		{input: `enum('it\'s','\'a,b\'')`, name: "enum", args: []string{"it's", "'a,b'"}},
+		{input: `regex('^\d{2}$')`, name: "regex", args: []string{`^\d{2}$`}},
+		{input: `enum('a,b\\','\\\'','\,\\ ')`, name: "enum", args: []string{`a,b\`, `\'`, `\,\\ `}},
+		{input: "enum('a' ,'b',)", name: "enum", args: []string{"a", "b", ""}},
+		{input: "enum(it's)", name: "enum", args: []string{"it's"}},
+		// nested parentheses, only commas at depth zero separating arguments
+		{input: `regex(^\(\d{3}\) \d{4}$)`, name: "regex", args: []string{`^\(\d{3}\) \d{4}$`}},
+		{input: `regex(^(\d{3}(-\d{4})?)$,x)`, name: "regex", args: []string{`^(\d{3}(-\d{4})?)$`, "x"}},
+		{input: "f(g(h(a,b),c),d)", name: "f", args: []string{"g(h(a,b),c)", "d"}},
+		{input: "message(name (optional),x)", name: "message", args: []string{"name (optional)", "x"}},
+		{input: "enum(')',a)", name: "enum", args: []string{")", "a"}},
+		{input: "regex('^a)$')", name: "regex", args: []string{"^a)$"}},
+		{input: "min(1", err: "expected `min(1` to end with ')'"},
+		{input: "min)", err: "unbalanced parentheses in `min)`"},
+		{input: "min)(1)", err: "unbalanced parentheses in `min)(1)`"},
+		{input: "min(1))", err: "unbalanced parentheses in `min(1))`"},
+		{input: "f(g(h(a),b)", err: "unbalanced parentheses in `f(g(h(a),b)`"},
+		{input: "f(a)(b)", err: "unbalanced parentheses in `f(a)(b)`"},
+		{input: "enum(),a)", err: "unbalanced parentheses in `enum(),a)`"},
+		{input: `enum('a\')`, err: "unterminated quote in `enum('a\\')`"},
+		{input: "enum( 'a,b)", err: "unterminated quote in `enum( 'a,b)`"},
+	}
+
+	for _, test := range tests {
+		name, args, err := extractFunctionInformation(test.input)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.input)
+			continue
+		}
+		assert.NoError(t, err, test.input)
+		assertEqual(t, test.name, name, test.input)
+		assertEqual(t, test.args, args, test.input)
+	}
+}
+
+func TestFunctionSeparator(t *testing.T) {
+	v := New()
+	v.AddValidator("regex", func(ctx *ValidationContext) bool {
+		return regexp.MustCompile(ctx.Args[0]).MatchString(ctx.GetValue().String())
+	})
+
+	type Form struct {
+		Choice string `validator:"regex(^(a|b)$)|length(_,1)"`
+		Kind   string `validator:"enum('x,y',z)"`
+	}
+	assertTrue(t, v.Validate(&Form{Choice: "a", Kind: "x,y"}).IsValid(), "Validation failed")
+	res := v.Validate(&Form{Choice: "c", Kind: "x"})
+	assertEqual(t, 2, len(res.FieldErrors))
+	assertEqual(t, "regex", res.FieldErrors[0].Validator)
+	assertEqual(t, "enum", res.FieldErrors[1].Validator)
+
+	type Unbalanced struct {
+		Choice string `validator:"regex(^(a|b$)|length(_,1)"`
+	}
+	err := v.Register(Unbalanced{})
+	assert.EqualError(t, err, "struct validator.Unbalanced, field Choice, rule `regex(^(a|b$)|length(_,1)`: "+
+		"invalid validator tag: unbalanced parentheses in `regex(^(a|b$)|length(_,1)`")
+
+	semicolon := New(func(opts *ValidationOptions) {
+		opts.FunctionSeparator = ";"
+	})
+	type Item struct {
+		Code string `validator:"length(1,_);alphanum" filter:"trim"`
+	}
+	res = semicolon.Validate(&Item{Code: "a|b"})
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "alphanum", res.FieldErrors[0].Validator)
+}
+
+func FuzzSplitTopLevel(f *testing.F) {
+	f.Add("required|min(1)", "|")
+	f.Add("regex(^(a|b$)|max(10)", "|")
+	f.Add("enum('a,b',c)|length(1,_)", ";")
+	f.Add("min(1))(", ",")
+
+	f.Fuzz(func(t *testing.T, input string, sep string) {
+		if sep == "" || strings.ContainsAny(sep, "(),'") {
+			t.Skip()
+		}
+		parts, err := splitTopLevel(input, sep)
+		if err != nil {
+			return
+		}
+		if joined := strings.Join(parts, sep); joined != input {
+			t.Fatalf("split of %q by %q does not round trip: %q", input, sep, parts)
+		}
+		for _, part := range parts {
+			if _, _, err := extractFunctionInformation(part); err != nil {
+				return
+			}
+		}
+
+		// balanced tags parse without panicking
+		structType := reflect.StructOf([]reflect.StructField{
+			{Name: "Value", Type: reflect.TypeOf(""), Tag: reflect.StructTag(`validator:"` + strings.ReplaceAll(input, `"`, "") + `"`)},
+		})
+		_ = New().Register(reflect.New(structType).Interface())
+	})
+}
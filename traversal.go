@@ -0,0 +1,292 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validationState holds the state of a single validation call, shared by all struct levels being validated
+type validationState struct {
+	// engine the instance performing the validation, see ValidationContext.engine
+	engine   *engine
+	ctx      context.Context
+	opts     *ValidationOptions
+	triggers []string
+	res      *ValidationResult
+	rng      *rand.Rand
+	// root the struct passed to the validation call, see ValidationContext.RootValue
+	root reflect.Value
+	// visiting holds the pointers being validated along the current path, see validateValue
+	visiting map[visitedPointer]bool
+	// restores undo the changes of filters when validation fails, see ValidationOptions.SkipAllFiltersOnError
+	restores []func()
+	// tagError indicates that struct tags could not be parsed
+	tagError bool
+	// readOnly indicates that the struct was passed by value and cannot be modified by filters
+	readOnly bool
+	// selection the fields to validate, nil for all fields, see Validator.ValidateFields
+	selection *fieldSelection
+	// stats counts evaluations and failures of validators, nil unless enabled, see Validator.EnableStats
+	stats *validationStats
+	// overrides the validator functions replaced by the context of the call, see WithValidatorOverride
+	overrides map[string]ValidationFunction
+}
+
+// visitedPointer identifies a pointer being validated. The type is part of the key since a struct and its first
+// field share the same address.
+type visitedPointer struct {
+	pointer   uintptr
+	valueType reflect.Type
+}
+
+// random returns the random number generator used for sampling slice elements
+func (s *validationState) random() *rand.Rand {
+	if s.rng == nil {
+		seed := s.opts.SliceSampleSeed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		s.rng = rand.New(rand.NewSource(seed))
+	}
+	return s.rng
+}
+
+// containsStruct tests whether the given type is a pointer, slice, array or map ultimately containing struct values
+func containsStruct(t reflect.Type) bool {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// innerStructType returns the struct type contained in the given pointer, slice, array or map type
+func innerStructType(t reflect.Type) reflect.Type {
+	for t.Kind() != reflect.Struct {
+		t = t.Elem()
+	}
+	return t
+}
+
+// validateStruct applies the field contexts of the given struct value, descending into nested structs, followed
+// by struct level validation (see Validatable).
+//
+// path is the path of the struct relative to the root struct and depth is the number of struct levels above it.
+func (v *Validator) validateStruct(state *validationState, structValue reflect.Value, path string, depth int) []FieldError {
+	var errorList []FieldError
+	opts := state.opts
+
+	if depth > opts.MaxDepth {
+		if state.res.Error == nil {
+			state.res.Error = newValidationError("maximum validation depth of " + strconv.Itoa(opts.MaxDepth) +
+				" exceeded at " + strings.TrimSuffix(path, "."))
+		}
+		return nil
+	}
+
+	// get from cache
+	fieldContexts, err := v.getStructFields(structValue.Type(), opts)
+	if err != nil {
+		if opts.PanicOnTagError {
+			panic(err)
+		}
+		state.res.Error = err.(*ValidationError)
+		state.tagError = true
+		return nil
+	}
+
+	// see ValidationOptions.Parallelism
+	var outcomes []fieldOutcome
+	if opts.Parallelism > 1 {
+		outcomes = v.applyConcurrently(state, structValue, path, fieldContexts)
+	}
+
+	for i, fc := range fieldContexts {
+		if err := state.ctx.Err(); err != nil {
+			if state.res.Error == nil {
+				state.res.Error = newValidationError("validation canceled", err)
+			}
+			return errorList
+		}
+		if !fc.activate(state.triggers) {
+			continue
+		}
+		if outcomes != nil && outcomes[i].applied {
+			errorList = append(errorList, outcomes[i].merge(state)...)
+		} else if state.selection.applies(fc.containerPath(path)) {
+			errorList = append(errorList, fc.apply(state, structValue, path)...)
+		}
+		if len(errorList) > 0 && opts.StopOnFirstError {
+			return errorList
+		}
+		if fc.nested && state.selection.descends(fc.nestedPath(path)) {
+			value := structValue.FieldByIndex(fc.fieldIndex)
+			errorList = append(errorList, v.validateValue(state, value, fc.nestedPath(path), depth)...)
+			if len(errorList) > 0 && opts.StopOnFirstError {
+				return errorList
+			}
+		}
+	}
+
+	// struct level validation runs once all fields have been validated and filtered
+	errorList = append(errorList, v.validateStructLevel(state, structValue, path)...)
+
+	return errorList
+}
+
+// validateValue descends into the given pointer, slice, array, map or struct value and validates every struct found.
+// Interface values are descended into if ValidationOptions.ValidateInterfaceValues is set.
+func (v *Validator) validateValue(state *validationState, value reflect.Value, path string, depth int) []FieldError {
+	var errorList []FieldError
+	opts := state.opts
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			break
+		}
+		// a pointer already being validated further up the path forms a cycle, e.g. a node referencing itself
+		visit := visitedPointer{pointer: value.Pointer(), valueType: value.Type()}
+		if state.visiting[visit] {
+			break
+		}
+		if state.visiting == nil {
+			state.visiting = make(map[visitedPointer]bool)
+		}
+		state.visiting[visit] = true
+		errorList = v.validateValue(state, value.Elem(), path, depth)
+		delete(state.visiting, visit)
+	case reflect.Struct:
+		// structs embedded by pointer in the root struct have an empty path, see fieldContext.nestedPath
+		prefix := path
+		if prefix != "" {
+			prefix += "."
+		}
+		errorList = v.validateStruct(state, value, prefix, depth+1)
+	case reflect.Interface:
+		if value.IsNil() || !opts.ValidateInterfaceValues {
+			break
+		}
+		element := value.Elem()
+		switch element.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			errorList = v.validateValue(state, element, path, depth)
+		default:
+			// values stored in interfaces are not addressable, so validate a copy and store it back like map elements
+			copied := reflect.New(element.Type()).Elem()
+			copied.Set(element)
+			errorList = v.validateValue(state, copied, path, depth)
+			if !state.readOnly && value.CanSet() {
+				if opts.SkipAllFiltersOnError {
+					state.restores = append(state.restores, func() {
+						value.Set(element)
+					})
+				}
+				value.Set(copied)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		errorList = v.validateElements(state, value, path, depth)
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			elementPath := path + "[" + fmt.Sprint(key.Interface()) + "]"
+			element := value.MapIndex(key)
+			if element.Kind() == reflect.Ptr {
+				errorList = append(errorList, v.validateValue(state, element, elementPath, depth)...)
+			} else {
+				// map elements are not addressable, so validate a copy and store it back to keep filter changes
+				copied := reflect.New(element.Type()).Elem()
+				copied.Set(element)
+				errorList = append(errorList, v.validateValue(state, copied, elementPath, depth)...)
+				if !state.readOnly {
+					if opts.SkipAllFiltersOnError {
+						key, element := key, element
+						state.restores = append(state.restores, func() {
+							value.SetMapIndex(key, element)
+						})
+					}
+					value.SetMapIndex(key, copied)
+				}
+			}
+			if len(errorList) > 0 && opts.StopOnFirstError {
+				break
+			}
+		}
+	}
+
+	return errorList
+}
+
+// validateElements validates the elements of the given slice or array, honoring ValidationOptions.SliceSample
+// and ValidationOptions.MaxSliceErrors.
+func (v *Validator) validateElements(state *validationState, value reflect.Value, path string, depth int) []FieldError {
+	var errorList []FieldError
+	opts := state.opts
+	indexes := v.elementIndexes(state, value.Len(), path)
+
+	for i, index := range indexes {
+		errorList = append(errorList, v.validateValue(state, value.Index(index), path+"["+strconv.Itoa(index)+"]", depth)...)
+		if len(errorList) > 0 && opts.StopOnFirstError {
+			break
+		}
+		if opts.MaxSliceErrors > 0 && len(errorList) >= opts.MaxSliceErrors {
+			state.res.SkippedElements += len(indexes) - i - 1
+			break
+		}
+	}
+
+	return errorList
+}
+
+// elementIndexes returns the indexes of the slice elements to validate.
+//
+// When sampling is enabled and the slice is larger than the sample size, the first ValidationOptions.SliceSample
+// elements are returned along with as many randomly chosen elements from the rest of the slice.
+func (v *Validator) elementIndexes(state *validationState, length int, path string) []int {
+	sample := state.opts.SliceSample
+	if sample <= 0 || length <= sample {
+		indexes := make([]int, length)
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return indexes
+	}
+
+	indexes := make([]int, sample, sample*2)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	rest := length - sample
+	sampled := state.random().Perm(rest)
+	if len(sampled) > sample {
+		sampled = sampled[:sample]
+	}
+	for i := range sampled {
+		sampled[i] += sample
+	}
+	sort.Ints(sampled)
+
+	if state.res.SampledIndexes == nil {
+		state.res.SampledIndexes = make(map[string][]int)
+	}
+	state.res.SampledIndexes[path] = sampled
+	state.res.SkippedElements += rest - len(sampled)
+
+	return append(indexes, sampled...)
+}
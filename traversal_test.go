@@ -0,0 +1,315 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNestedContainers(t *testing.T) {
+	type OrderItem struct {
+		Sku string `validator:"length(3,_)" filter:"trim"`
+	}
+	type Address struct {
+		City string `validator:"length(2,_)"`
+	}
+	type Cell struct {
+		Value int `validator:"max(9)"`
+	}
+	type Order struct {
+		Items     []*OrderItem
+		Addresses map[string]*Address
+		Copies    map[string]OrderItem
+		Matrix    [][]Cell
+		Billing   *Address
+	}
+
+	order := Order{
+		Items: []*OrderItem{{Sku: "ab"}, nil, {Sku: " abc "}},
+		Addresses: map[string]*Address{
+			"home": {City: "X"},
+			"work": nil,
+		},
+		Copies: map[string]OrderItem{"first": {Sku: " xyz "}},
+		Matrix: [][]Cell{{{Value: 1}}, {{Value: 2}, {Value: 10}}},
+	}
+
+	res := Validate(&order)
+	assertFalse(t, res.IsValid(), "expected validation to fail")
+	assertEqual(t, 3, len(res.FieldErrors))
+	assertEqual(t, "Items[0].Sku", res.FieldErrors[0].Field)
+	assertEqual(t, "Addresses[home].City", res.FieldErrors[1].Field)
+	assertEqual(t, "Matrix[1][1].Value", res.FieldErrors[2].Field)
+	assertEqual(t, "abc", order.Items[2].Sku)
+	assertEqual(t, "xyz", order.Copies["first"].Sku)
+
+	order.Billing = &Address{City: "Y"}
+	res = Validate(&order)
+	assertEqual(t, 4, len(res.FieldErrors))
+	assertEqual(t, "Billing.City", res.FieldErrors[3].Field)
+}
+
+func TestNestedMaxDepth(t *testing.T) {
+	type Node struct {
+		Name string `validator:"length(1,_)"`
+		Next *Node
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.MaxDepth = 2
+	})
+
+	list := &Node{Name: "a", Next: &Node{Name: "b", Next: &Node{Name: "c", Next: &Node{Name: "d"}}}}
+	res := v.Validate(list)
+	assertFalse(t, res.IsValid(), "expected maximum depth to be exceeded")
+	assertEqual(t, 0, len(res.FieldErrors))
+	assertEqual(t, "maximum validation depth of 2 exceeded at Next.Next.Next", res.Error.Error())
+}
+
+type selfReferential struct {
+	Name     string `validator:"length(1,_)"`
+	Parent   *selfReferential
+	Children []*selfReferential
+}
+
+type cycleA struct {
+	Name string `validator:"length(1,_)"`
+	B    *cycleB
+}
+
+type cycleB struct {
+	Name string `validator:"length(1,_)"`
+	A    cycleA
+	As   map[string]*cycleA
+}
+
+func TestCyclicStructs(t *testing.T) {
+	v := New()
+	assert.NoError(t, v.Register(selfReferential{}, cycleA{}))
+
+	// pointers to values being validated are not followed again
+	root := &selfReferential{Name: "root"}
+	child := &selfReferential{Name: "", Parent: root}
+	root.Children = []*selfReferential{child, child}
+	root.Parent = root
+	res := v.Validate(root)
+	assertEqual(t, []FieldError{
+		{Field: "Children[0].Name", Message: "length (0) must be at least 1", Validator: "length"},
+		{Field: "Children[1].Name", Message: "length (0) must be at least 1", Validator: "length"},
+	}, res.FieldErrors)
+	assertNull(t, res.Error)
+
+	a := &cycleA{Name: "a", B: &cycleB{Name: "b"}}
+	a.B.A = cycleA{Name: "", B: a.B}
+	a.B.As = map[string]*cycleA{"self": a}
+	res = v.Validate(a)
+	assertEqual(t, []FieldError{{Field: "B.A.Name", Message: "length (0) must be at least 1", Validator: "length"}}, res.FieldErrors)
+	assertNull(t, res.Error)
+
+	// long chains of distinct values are bounded by MaxDepth
+	var deep *selfReferential
+	for i := 0; i < 40; i++ {
+		deep = &selfReferential{Name: "n", Parent: deep}
+	}
+	res = v.Validate(deep)
+	assertFalse(t, res.IsValid(), "expected maximum depth to be exceeded")
+	assertEqual(t, "maximum validation depth of 32 exceeded at "+strings.TrimSuffix(strings.Repeat("Parent.", 33), "."), res.Error.Error())
+}
+
+func TestMaxSliceErrors(t *testing.T) {
+	type Row struct {
+		Name string `validator:"length(1,_)"`
+	}
+	type Batch struct {
+		Rows []Row
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.MaxSliceErrors = 3
+	})
+
+	batch := Batch{Rows: make([]Row, 100)}
+	res := v.Validate(&batch)
+	assertEqual(t, 3, len(res.FieldErrors))
+	assertEqual(t, 97, res.SkippedElements)
+}
+
+func TestSliceSample(t *testing.T) {
+	type Row struct {
+		Name string `validator:"length(1,_)"`
+	}
+	type Batch struct {
+		Rows []Row
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.SliceSample = 5
+		opts.SliceSampleSeed = 42
+	})
+
+	batch := Batch{Rows: make([]Row, 100)}
+	first := v.Validate(&batch)
+	assertEqual(t, 10, len(first.FieldErrors))
+	assertEqual(t, 90, first.SkippedElements)
+	assertEqual(t, "Rows[0].Name", first.FieldErrors[0].Field)
+
+	sampled := first.SampledIndexes["Rows"]
+	assertEqual(t, 5, len(sampled))
+	for _, index := range sampled {
+		assertTrue(t, index >= 5 && index < 100, "sampled index out of range")
+	}
+
+	second := v.Validate(&batch)
+	assertEqual(t, sampled, second.SampledIndexes["Rows"])
+	assertEqual(t, first.FieldErrors, second.FieldErrors)
+}
+
+func TestContextPaths(t *testing.T) {
+	type Item struct {
+		Sku      string `validator:"record" label:"SKU"`
+		Quantity int    `validator:"max_per_item"`
+	}
+	type Order struct {
+		MaxPerItem int
+		Items      []Item
+		Gifts      map[string]*Item
+		Tags       []string `filter:"trim|record"`
+	}
+
+	var paths []string
+	v := New()
+	v.AddValidator("record", func(ctx *ValidationContext) bool {
+		paths = append(paths, ctx.FieldPath())
+		return true
+	})
+	v.AddFilter("record", func(ctx *ValidationContext) reflect.Value {
+		paths = append(paths, ctx.FieldPath())
+		return ctx.value
+	})
+	v.AddValidator("max_per_item", func(ctx *ValidationContext) bool {
+		max := ctx.RootValue().FieldByName("MaxPerItem").Int()
+		if ctx.GetValue().Int() > max {
+			ctx.ErrorMessage = fmt.Sprintf("%s exceeds the limit of %d, %s has %d", ctx.FieldPath(), max,
+				ctx.ParentValue().FieldByName("Sku"), ctx.GetValue().Int())
+			return false
+		}
+		return true
+	})
+
+	order := Order{
+		MaxPerItem: 5,
+		Items:      []Item{{Sku: "a", Quantity: 5}, {Sku: "b", Quantity: 6}},
+		Gifts:      map[string]*Item{"x": {Sku: "c", Quantity: 9}},
+		Tags:       []string{" new "},
+	}
+	res := v.Validate(&order)
+	assertEqual(t, []FieldError{
+		{Field: "Items[1].Quantity", Message: "Items[1].Quantity exceeds the limit of 5, b has 6", Validator: "max_per_item"},
+		{Field: "Gifts[x].Quantity", Message: "Gifts[x].Quantity exceeds the limit of 5, c has 9", Validator: "max_per_item"},
+	}, res.FieldErrors)
+	assertEqual(t, []string{"Items[0].Sku", "Items[1].Sku", "Gifts[x].Sku", "Tags[0]"}, paths)
+}
+
+type Pagination struct {
+	Page int    `validator:"min(1)"`
+	Sort string `validator:"enum(asc,desc)" filter:"trim"`
+}
+
+type auditInfo struct {
+	Author string `validator:"length(1,_)" filter:"trim"`
+}
+
+func TestEmbeddedPointers(t *testing.T) {
+	type Search struct {
+		*Pagination
+		Query string `validator:"length(1,_)"`
+	}
+	type Listing struct {
+		*Pagination `validator:"required"`
+		*auditInfo
+		Pages []Search
+	}
+
+	// fields of embedded pointers are promoted and filtered in place
+	search := Search{Pagination: &Pagination{Page: 0, Sort: " asc "}, Query: "go"}
+	res := New().Validate(&search)
+	assertEqual(t, []FieldError{{Field: "Page", Message: "value (0) must be at least 1", Validator: "min"}}, res.FieldErrors)
+	assertEqual(t, "asc", search.Sort)
+
+	// nil embedded pointers are skipped
+	search = Search{Query: "go"}
+	assertTrue(t, New().Validate(&search).IsValid(), "Validation failed")
+
+	// unless required
+	res = New().Validate(&Listing{})
+	assertEqual(t, []FieldError{{Field: "Pagination", Message: MsgRequired, Validator: "required", Code: CodeMissing}}, res.FieldErrors)
+
+	// unexported embedded pointers are followed as well, and paths of nested structs promote embedded fields too
+	listing := Listing{
+		Pagination: &Pagination{Page: 1, Sort: "desc"},
+		auditInfo:  &auditInfo{Author: "  "},
+		Pages:      []Search{{Query: "a"}, {Pagination: &Pagination{Page: 2, Sort: " up "}, Query: "b"}},
+	}
+	res = New().Validate(&listing)
+	assertEqual(t, []FieldError{
+		{Field: "Author", Message: "length (0) must be at least 1", Validator: "length"},
+		{Field: "Pages[1].Sort", Message: MsgEnum, Validator: "enum"},
+	}, res.FieldErrors)
+	assertEqual(t, "", listing.Author)
+	assertEqual(t, "up", listing.Pages[1].Sort)
+}
+
+func TestInterfaceFields(t *testing.T) {
+	type Payment struct {
+		Amount int    `validator:"min(1)"`
+		Note   string `filter:"trim"`
+	}
+	type Message struct {
+		Value   interface{} `validator:"required"`
+		Code    any         `validator:"min(2)|max(4)" filter:"trim"`
+		Payload any
+	}
+
+	// nil interfaces are treated like nil pointers
+	res := New().Validate(&Message{Code: "ab"})
+	assertEqual(t, []FieldError{{Field: "Value", Message: MsgRequired, Validator: "required", Code: CodeMissing}}, res.FieldErrors)
+
+	// validators and filters apply to the dynamic value
+	msg := Message{Value: 1, Code: " abcde "}
+	res = New().Validate(&msg)
+	assertEqual(t, []FieldError{{Field: "Code", Message: "length (abcde) must not exceed 4", Validator: "max"}}, res.FieldErrors)
+	assertEqual(t, "abcde", msg.Code)
+
+	code := " abc "
+	msg = Message{Value: 1, Code: &code}
+	assertTrue(t, New().Validate(&msg).IsValid(), "Validation failed")
+	assertEqual(t, "abc", *msg.Code.(*string))
+
+	// unsupported dynamic kinds are reported as field errors
+	res = New().Validate(&Message{Value: 1, Code: true})
+	assertEqual(t, []FieldError{
+		{Field: "Code", Message: "filter trim failed: unexpected type found: bool"},
+		{Field: "Code", Message: "validator min failed: unexpected type found: bool", Validator: "min"},
+		{Field: "Code", Message: "validator max failed: unexpected type found: bool", Validator: "max"},
+	}, res.FieldErrors)
+	assertNull(t, res.Error)
+
+	// structs stored in interfaces are only validated on demand, using their own tags
+	v := New(func(opts *ValidationOptions) {
+		opts.ValidateInterfaceValues = true
+	})
+	assertTrue(t, New().Validate(&Message{Value: 1, Code: "ab", Payload: Payment{}}).IsValid(), "Validation failed")
+
+	msg = Message{Value: 1, Code: "ab", Payload: Payment{Note: " paid "}}
+	res = v.Validate(&msg)
+	assertEqual(t, []FieldError{{Field: "Payload.Amount", Message: "value (0) must be at least 1", Validator: "min"}}, res.FieldErrors)
+	assertEqual(t, "paid", msg.Payload.(Payment).Note)
+
+	payment := &Payment{Amount: 1, Note: " ok "}
+	msg = Message{Value: 1, Code: "ab", Payload: payment}
+	assertTrue(t, v.Validate(&msg).IsValid(), "Validation failed")
+	assertEqual(t, "ok", payment.Note)
+}
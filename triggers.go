@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// triggerSet holds activation triggers a field is tagged with: exact names, and patterns split into their dot
+// separated segments at parse time, e.g. admin.* or *.create
+type triggerSet struct {
+	exact    []string
+	patterns [][]string
+}
+
+// add adds the given trigger to the set. Triggers containing '*' are patterns, in which '*' must be a whole segment.
+func (s *triggerSet) add(trigger string) error {
+	if !strings.Contains(trigger, "*") {
+		s.exact = append(s.exact, trigger)
+		return nil
+	}
+	segments := strings.Split(trigger, ".")
+	for _, segment := range segments {
+		if segment != "*" && (segment == "" || strings.Contains(segment, "*")) {
+			return errors.New("invalid trigger pattern `" + trigger + "`: '*' must be a whole segment")
+		}
+	}
+	s.patterns = append(s.patterns, segments)
+	return nil
+}
+
+// empty tests whether the set holds no triggers
+func (s *triggerSet) empty() bool {
+	return len(s.exact) == 0 && len(s.patterns) == 0
+}
+
+// matchesExactly tests whether any of the given triggers is an exact trigger of the set
+func (s *triggerSet) matchesExactly(triggers []string) bool {
+	for _, trigger := range triggers {
+		if slices.Contains(s.exact, trigger) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPattern tests whether any of the given triggers matches a pattern of the set
+func (s *triggerSet) matchesPattern(triggers []string) bool {
+	for _, pattern := range s.patterns {
+		for _, trigger := range triggers {
+			if matchTriggerPattern(pattern, trigger) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchTriggerPattern tests whether the given trigger has as many segments as the pattern and each segment equals
+// the segment of the pattern, or the pattern segment is '*'
+func matchTriggerPattern(pattern []string, trigger string) bool {
+	for i, segment := range pattern {
+		var current string
+		var more bool
+		current, trigger, more = strings.Cut(trigger, ".")
+		if segment != "*" && segment != current {
+			return false
+		}
+		if more != (i < len(pattern)-1) {
+			return false
+		}
+	}
+	return true
+}
+
+// activationTriggers returns the given triggers without empty values, or "all" if none remain
+func activationTriggers(trigger []string) []string {
+	triggers := make([]string, 0, len(trigger))
+	for _, t := range trigger {
+		if t != "" {
+			triggers = append(triggers, t)
+		}
+	}
+	if len(triggers) == 0 {
+		triggers = append(triggers, "all")
+	}
+	return triggers
+}
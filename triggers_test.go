@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTriggerPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		trigger string
+		match   bool
+	}{
+		{pattern: "admin.*", trigger: "admin.create", match: true},
+		{pattern: "admin.*", trigger: "admin.update", match: true},
+		{pattern: "admin.*", trigger: "public.create", match: false},
+		{pattern: "admin.*", trigger: "admin", match: false},
+		{pattern: "admin.*", trigger: "admin.", match: true},
+		{pattern: "admin.*", trigger: "admin.users.create", match: false},
+		{pattern: "*.create", trigger: "public.create", match: true},
+		{pattern: "*.create", trigger: "create", match: false},
+		{pattern: "*.*", trigger: "admin.create", match: true},
+		{pattern: "*", trigger: "all", match: true},
+		{pattern: "*", trigger: "admin.create", match: false},
+	}
+
+	for _, test := range tests {
+		match := matchTriggerPattern(strings.Split(test.pattern, "."), test.trigger)
+		assertEqual(t, test.match, match, fmt.Sprintf("%s matching %s", test.pattern, test.trigger))
+	}
+}
+
+func TestTriggerPatterns(t *testing.T) {
+	tests := []struct {
+		tag      string
+		triggers []string
+		active   bool
+	}{
+		// exact triggers
+		{tag: "admin.create", triggers: []string{"admin.create"}, active: true},
+		{tag: "admin.create", triggers: []string{"admin.update"}, active: false},
+		{tag: "create,update", triggers: []string{"update"}, active: true},
+		{tag: "create,update", triggers: []string{"delete"}, active: false},
+		// patterns
+		{tag: "admin.*", active: false},
+		{tag: "admin.*", triggers: []string{"admin.create"}, active: true},
+		{tag: "admin.*", triggers: []string{"public.create"}, active: false},
+		{tag: "*.create", triggers: []string{"public.create"}, active: true},
+		{tag: "*.create", triggers: []string{"public.update", "admin.create"}, active: true},
+		{tag: "*.create", triggers: []string{"public.update"}, active: false},
+		// overlapping patterns
+		{tag: "admin.*,*.create", triggers: []string{"admin.update"}, active: true},
+		{tag: "admin.*,*.create", triggers: []string{"public.create"}, active: true},
+		{tag: "admin.*,*.create", triggers: []string{"public.update"}, active: false},
+		// exact matches are preferred over patterns
+		{tag: "admin.*,!admin.delete", triggers: []string{"admin.delete"}, active: false},
+		{tag: "admin.*,!admin.delete", triggers: []string{"admin.update"}, active: true},
+		{tag: "!admin.*,admin.update", triggers: []string{"admin.update"}, active: true},
+		{tag: "!admin.*,admin.update", triggers: []string{"admin.delete"}, active: false},
+		{tag: "*.create,!admin.*", triggers: []string{"admin.create"}, active: true},
+		{tag: "!admin.*", triggers: []string{"public.create"}, active: true},
+		{tag: "!admin.*", active: true},
+		{tag: "all,!*.delete", triggers: []string{"public.delete"}, active: false},
+	}
+
+	v := New()
+	for _, test := range tests {
+		structType := reflect.StructOf([]reflect.StructField{
+			{Name: "Id", Type: reflect.TypeOf(0), Tag: reflect.StructTag(`validator:"min(1)" trigger:"` + test.tag + `"`)},
+		})
+
+		res := v.Validate(reflect.New(structType).Interface(), test.triggers...)
+		assertNull(t, res.Error)
+		assertEqual(t, test.active, len(res.FieldErrors) == 1, fmt.Sprintf("trigger:%q activated by %v", test.tag, test.triggers))
+	}
+
+	type Invalid struct {
+		Id   int `validator:"min(1)" trigger:"admin*"`
+		Name int `validator:"min(1)" trigger:"!admin.*x"`
+		Age  int `validator:"min(1)" trigger:"*..create"`
+	}
+	err := v.Register(Invalid{})
+	assert.ErrorContains(t, err, "field Id, rule `admin*`: invalid trigger pattern `admin*`: '*' must be a whole segment")
+	assert.ErrorContains(t, err, "field Name, rule `!admin.*x`: invalid trigger pattern `admin.*x`")
+	assert.ErrorContains(t, err, "field Age, rule `*..create`: invalid trigger pattern `*..create`")
+}
+
+func TestWithTrigger(t *testing.T) {
+	type Resource struct {
+		Id   string `validator:"length(1,_)" trigger:"update"`
+		Name string `validator:"length(1,_)" trigger:"create"`
+		Note string `validator:"length(1,_)"`
+	}
+	fields := func(res *ValidationResult) (names []string) {
+		for _, fieldError := range res.FieldErrors {
+			names = append(names, fieldError.Field)
+		}
+		return
+	}
+
+	v := New()
+	create := v.WithTrigger("create")
+
+	// the default triggers apply to calls passing none
+	assertEqual(t, []string{"Name", "Note"}, fields(create.Validate(&Resource{})))
+	assertEqual(t, []string{"Name"}, fields(create.ValidateFields(&Resource{}, []string{"Name", "Id"})))
+
+	// explicit triggers override the default ones
+	assertEqual(t, []string{"Id", "Note"}, fields(create.Validate(&Resource{}, "update")))
+	assertEqual(t, []string{"Note"}, fields(create.Validate(&Resource{}, "all")))
+
+	// the original instance, and an instance bound to no trigger, keep the 'all' default
+	assertEqual(t, []string{"Note"}, fields(v.Validate(&Resource{})))
+	assertEqual(t, []string{"Note"}, fields(create.WithTrigger().Validate(&Resource{})))
+
+	// multiple triggers, and state shared with the original instance
+	both := create.WithTrigger("create", "update")
+	assertEqual(t, []string{"Id", "Name", "Note"}, fields(both.Validate(&Resource{})))
+	v.SetupOptions(func(opts *ValidationOptions) {
+		opts.StopOnFirstError = true
+	})
+	assertEqual(t, []string{"Id"}, fields(both.Validate(&Resource{})))
+
+	// the zero value reports invalid options instead of panicking, as do the instances derived from it
+	var zero Validator
+	for _, instance := range []*Validator{&zero, zero.WithTrigger("create"), zero.WithoutCache()} {
+		res := instance.Validate(&Resource{})
+		assertFalse(t, res.IsValid())
+		assertEqual(t, 0, len(res.FieldErrors))
+		assert.ErrorContains(t, res.Error, "FilterTagName")
+	}
+	res := zero.ValidateWithOptions(&Resource{}, defaultOptions())
+	assertEqual(t, "Invalid validator. Instances must be created with New", res.Error.Error())
+}
@@ -1,5 +1,27 @@
 package validator
 
+import "reflect"
+
+// signedIntegerKinds lists all signed integer kinds. Validators classifying integer values must use this list
+// instead of enumerating the kinds themselves.
+var signedIntegerKinds = []reflect.Kind{
+	reflect.Int,
+	reflect.Int8,
+	reflect.Int16,
+	reflect.Int32,
+	reflect.Int64,
+}
+
+// unsignedIntegerKinds lists all unsigned integer kinds. Validators classifying integer values must use this list
+// instead of enumerating the kinds themselves.
+var unsignedIntegerKinds = []reflect.Kind{
+	reflect.Uint,
+	reflect.Uint8,
+	reflect.Uint16,
+	reflect.Uint32,
+	reflect.Uint64,
+}
+
 type Comparator string
 type ComparatorDescription byte
 
@@ -1,8 +1,10 @@
 package validator
 
 import (
-	"errors"
+	"context"
 	"reflect"
+	"strings"
+	"time"
 )
 
 type ValidationOptions struct {
@@ -23,11 +25,23 @@ type ValidationOptions struct {
 	//		Age int `validator:"min(10)" trigger:"all"`
 	//	}
 	//
+	// Triggers prefixed with '!' are negated: `trigger:"!update"` evaluates a field for any trigger other than 'update'.
+	// A field tagged with any of the active triggers is evaluated, even if it is also tagged with another active
+	// trigger negated.
+	//
+	// A '*' segment matches any single dot separated segment: `trigger:"admin.*"` evaluates a field for 'admin.create'
+	// and 'admin.update'. Exact matches are preferred over such patterns.
+	//
 	// default: 'trigger'
 	TriggerTagName string
 
 	// ValidatorTagName specifies the tag to use when looking up validation functions
 	//
+	// Like the other tag name options, it may list fallback names separated by commas, e.g. 'validator,validate'
+	// when migrating structs from one tag name to another: the first name present on a field is used. A field
+	// carrying more than one of the names is reported as an invalid struct tag. Rules and CanonicalizeTag render
+	// tags using the first name.
+	//
 	// default: 'validator'
 	ValidatorTagName string
 
@@ -41,16 +55,38 @@ type ValidationOptions struct {
 	// default: 'label'
 	LabelTagName string
 
-	// StringAutoTrim specifies whether to automatically trim all strings
+	// RulesTagName specifies the tag listing filters and validators together, which run strictly from left to right so
+	// that filters and validators can alternate, e.g. `rules:"trim|length(8,_)|hash_password(12)"` validates the
+	// trimmed value before hashing it. Names are looked up among filters first, then among validators, unless prefixed
+	// with 'f:' or 'v:', e.g. `rules:"v:trim"` for a validator named trim. The first failing function ends the chain.
+	// LegacyFilterOrder does not apply. A field carrying the rules tag as well as the validator or filter tag is
+	// reported as an invalid struct tag.
+	//
+	// default: 'rules'
+	RulesTagName string
+
+	// StringAutoTrim specifies whether to automatically trim the string and string pointer fields which have
+	// validators or filters, as if their filters started with trim. Values are trimmed before validators run,
+	// whatever LegacyFilterOrder. Nil pointers and fields with the skip_filters flag are left as is. Structs validated
+	// in read-only mode cannot be trimmed: ValidationResult.Error names the first field which would need trimming.
 	//
 	// default: false
 	StringAutoTrim bool
 
 	// StopOnFirstError specifies whether to stop validation upon encountering the first validation error
 	//
+	// It implies StopOnFirstErrorPerField: no further validator, filter or field is evaluated.
+	//
 	// default: false
 	StopOnFirstError bool
 
+	// StopOnFirstErrorPerField specifies whether to stop evaluating the validators of a field after its first
+	// failing validator, as if every field had the flag stop_on_error. Other fields are still evaluated and filters
+	// still run.
+	//
+	// default: false
+	StopOnFirstErrorPerField bool
+
 	// ExposeValidatorNames specifies whether to expose validator function names in default error messages
 	// when neither a validator nor a struct tag has specified an error message.
 	//
@@ -71,37 +107,350 @@ type ValidationOptions struct {
 	// default: false
 	ExposeEnumValues bool
 
+	// ExposePatterns specifies whether to append the regular expression to the error message of the pattern
+	// validator, which otherwise only names the pattern.
+	//
+	// default: false
+	ExposePatterns bool
+
 	// FlagTagName specifies the name of tag to use when looking up flags
 	//
 	// default: 'flags'
 	FlagTagName string
-}
 
-var cache *fieldCache
-var globalOptions ValidationOptions
+	// FunctionSeparator specifies the separator of the validators, filters and flags listed in a tag, e.g.
+	// `validator:"min(1);max(10)"` using ';'.
+	//
+	// Separators found within parentheses or quoted arguments do not split tags, so `validator:"regex('^a|b$')|max(10)"`
+	// lists two validators. The separator must not contain parentheses, commas or quotes.
+	//
+	// default: '|'
+	FunctionSeparator string
 
-func init() {
-	// default parameters
-	globalOptions = ValidationOptions{
+	// LegacyMinMaxStringLength specifies whether the min and max validators accept strings and compare their length.
+	//
+	// This behavior is deprecated in favor of the length validator, which measures strings, slices, arrays and maps.
+	// When disabled, min and max only accept numeric values.
+	//
+	// default: true
+	LegacyMinMaxStringLength bool
+
+	// LengthInBytes specifies whether string lengths are measured in bytes rather than runes.
+	//
+	// Useful when length limits mirror byte based storage limits such as column sizes.
+	//
+	// default: false
+	LengthInBytes bool
+
+	// MaxDepth specifies the maximum number of nested struct levels to descend into when validating structs
+	// contained in pointer, slice, array and map fields. Exceeding it sets ValidationResult.Error. Pointers to values
+	// already being validated further up the path, as found in cyclic structures, are not followed.
+	//
+	// default: 32
+	MaxDepth int
+
+	// MaxSliceErrors specifies the number of field errors after which the remaining elements of a slice or array
+	// of structs are no longer validated. The number of skipped elements is recorded in ValidationResult.SkippedElements.
+	//
+	// default: 0 (disabled)
+	MaxSliceErrors int
+
+	// SliceSample specifies the number of leading elements of a slice or array of structs to validate. When a slice
+	// is longer, as many elements are randomly sampled from the rest of the slice. Sampled indexes are recorded
+	// in ValidationResult.SampledIndexes.
+	//
+	// default: 0 (disabled)
+	SliceSample int
+
+	// SliceSampleSeed specifies the seed of the random number generator used for sampling slice elements, making
+	// sampling deterministic. A new seed is chosen for every validation if the value is 0.
+	//
+	// default: 0
+	SliceSampleSeed int64
+
+	// RejectEmptyDates specifies whether date validators reject empty or blank strings and zero Unix timestamps.
+	//
+	// By default, blank strings and zero timestamps are treated as absent values and pass date validation,
+	// leaving presence checks to the required validator.
+	//
+	// default: false
+	RejectEmptyDates bool
+
+	// RequiredRejectsZeroValues specifies whether the required validator rejects empty values in addition to missing
+	// ones: empty strings, empty or nil slices and maps, and zero structs such as a zero time.Time. Pointers are
+	// dereferenced, so a *string pointing to "" fails as well. Numbers and booleans are left alone, 0 and false being
+	// meaningful values: use nonzero to reject them.
+	//
+	// By default, required only rejects nil pointers, nil interfaces and all zero arrays.
+	//
+	// default: false
+	RequiredRejectsZeroValues bool
+
+	// PanicOnTagError specifies whether to panic upon encountering invalid struct tags, such as references to unknown
+	// validators or filters. When disabled, the problem is reported through ValidationResult.Error instead.
+	//
+	// default: true
+	PanicOnTagError bool
+
+	// RecoverFromPanics specifies whether to recover from panics raised by validation and filter functions, such as
+	// type mismatches or malformed arguments. A panic with a *ValidationError value is reported as a field error,
+	// any other panic through ValidationResult.Error.
+	//
+	// default: false
+	RecoverFromPanics bool
+
+	// Clock specifies the function returning the current time, used by date and age validators.
+	//
+	// default: nil (time.Now)
+	Clock func() time.Time
+
+	// TimeZone specifies the location of the current time used by date and age validators. Dates of birth are
+	// interpreted in this location when computing ages.
+	//
+	// default: nil (location of the current time)
+	TimeZone *time.Location
+
+	// IncludeFieldValues specifies whether error messages of age validators include the age computed from the field value.
+	//
+	// default: false
+	IncludeFieldValues bool
+
+	// LegacyMessages specifies whether packaged validators report the messages of previous versions, which contain
+	// typos, instead of the current messages. See MsgRequiredLegacy and MsgUUIDVersionMismatchLegacy.
+	//
+	// default: false
+	LegacyMessages bool
+
+	// LegacyFilterOrder specifies whether validators run before filters, as in previous versions, and therefore see
+	// the unfiltered value: `filter:"trim" validator:"length(3,_)"` would accept "  ab  " and store "ab".
+	//
+	// By default, filters run first and validators see the filtered value.
+	//
+	// default: false
+	LegacyFilterOrder bool
+
+	// ExposeUnderlyingErrors specifies whether to append the text of the underlying error reported by a validator
+	// (ValidationContext.AdditionalError) to the field error message. The underlying error is always available
+	// through FieldError.Cause.
+	//
+	// Underlying errors, such as parse errors, may contain input values.
+	//
+	// default: false
+	ExposeUnderlyingErrors bool
+
+	// Resolver specifies the resolver used by the resolvable validator to look up host names.
+	//
+	// default: nil (net.DefaultResolver)
+	Resolver Resolver
+
+	// SkipFiltersOnError specifies whether the changes filters made to a field are undone when the field fails
+	// validation, leaving the field as it was passed. Filters still run first, as validators need the filtered value,
+	// and the field is restored afterwards. With LegacyFilterOrder, the filters of a failing field do not run at all.
+	//
+	// Restoring copies the field value back: values the field references, such as the string a pointer points to,
+	// must not be modified in place by filters. When StopOnFirstError is set, no field is filtered after the first
+	// failure, so the struct keeps the changes of earlier, valid fields only.
+	//
+	// default: false
+	SkipFiltersOnError bool
+
+	// SkipAllFiltersOnError specifies whether the changes filters made to all fields are undone when validation
+	// fails, including errors of other fields and of struct level validation, leaving the struct as it was passed.
+	// The same restrictions as for SkipFiltersOnError apply.
+	//
+	// default: false
+	SkipAllFiltersOnError bool
+
+	// ValidatorTimeout specifies the duration after which a validator is abandoned, e.g. a custom validator checking
+	// uniqueness against a database that stopped responding. The field error reported in its place has the code
+	// CodeTimeout and the remaining validators of the field still run. Fields may override it with the timeout flag,
+	// e.g. `flags:"timeout=2s"`.
+	//
+	// Each validator then runs in its own goroutine, with a context bounded by the timeout (see
+	// ValidationContext.Context). An abandoned validator keeps running until it returns and its outcome is
+	// discarded: validators should honor the context and must not modify the validated struct.
+	//
+	// default: 0 (disabled)
+	ValidatorTimeout time.Duration
+
+	// CaseInsensitiveTagNames specifies whether tag names are matched regardless of case, e.g. `Validator:"required"`
+	// using the default ValidatorTagName.
+	//
+	// default: false
+	CaseInsensitiveTagNames bool
+
+	// DeduplicateFieldErrors specifies whether to remove field errors repeating the field, code and message of an
+	// earlier error, e.g. when min and a custom validator of a field report the same message. The first occurrence of
+	// each error is kept, in order. See also ValidationResult.Coalesce.
+	//
+	// default: false
+	DeduplicateFieldErrors bool
+
+	// ValidateInterfaceValues specifies whether structs stored in interface fields, such as `Payload any`, are
+	// validated using their own tags, like structs stored in pointer fields. Validators and filters of interface
+	// fields always apply to the dynamic value of the field.
+	//
+	// default: false
+	ValidateInterfaceValues bool
+
+	// Parallelism specifies the number of fields of a struct whose validators and filters run concurrently, worthwhile
+	// for structs with many fields or expensive custom validators. Values greater than 1 enable a pool of as many
+	// workers for each struct. Field errors are reported in declaration order, as with sequential validation.
+	//
+	// Fields whose validators or filters reference other fields, such as eqfield, required_if or ${Field} arguments,
+	// run once the concurrent fields are done, followed by nested structs and struct level validation. Custom
+	// validators and filters must only modify their own field and must not read other fields through
+	// ValidationContext.Sibling, ParentValue or RootValue. With StopOnFirstError, remaining fields are skipped on a
+	// best-effort basis: fields already running when another field fails are still filtered.
+	//
+	// default: 0 (sequential)
+	Parallelism int
+
+	// StrictTagParsing specifies whether to reject tag content that is otherwise tolerated or reported with a vague
+	// message: empty entries such as `validator:"required|"`, whitespace between a function name and its arguments
+	// such as `min (5)`, malformed names such as `required,`, unbalanced parentheses, unknown flags such as
+	// `flags:"allowzero"`, empty triggers, duplicate tags and duplicate enum values such as `enum(1,01)`. All problems
+	// found in a struct are reported at once, by Validator.Register as well as through ValidationResult.Error or the
+	// panic of PanicOnTagError.
+	//
+	// Meant for CI and tests, e.g. calling Register over every request struct.
+	//
+	// default: false
+	StrictTagParsing bool
+
+	// StrictTags specifies whether every exported field must either have rules or be explicitly skipped with
+	// `validator:"-"`, so new fields cannot silently go unvalidated. Fields without a validator tag are reported when
+	// structs are parsed, like unknown validators. Fields containing structs are exempt, as their own fields are
+	// checked instead. Unlike StrictTagParsing, this concerns missing tags rather than their syntax.
+	//
+	// default: false
+	StrictTags bool
+
+	// DecodeCaseInsensitiveKeys specifies whether ValidateInto matches keys with json names and field names ignoring
+	// case, exact matches being preferred.
+	//
+	// default: false
+	DecodeCaseInsensitiveKeys bool
+
+	// RejectUnknownKeys specifies whether ValidateInto reports keys without a field as field errors with the code
+	// CodeUnknownField, rather than ignoring them.
+	//
+	// default: false
+	RejectUnknownKeys bool
+
+	// TagLimits bounds the complexity of struct tags, e.g. the number of validators of a field or the length of their
+	// arguments, protecting against pathological tags of struct types declared by untrusted code. See
+	// TagComplexityLimits for the limits and their defaults.
+	TagLimits TagComplexityLimits
+}
+
+// defaultOptions returns the default validation options
+func defaultOptions() ValidationOptions {
+	return ValidationOptions{
 		FilterTagName:             "filter",
 		ValidatorTagName:          "validator",
 		StringAutoTrim:            false,
 		MessageTagName:            "message",
 		LabelTagName:              "label",
+		RulesTagName:              "rules",
 		StopOnFirstError:          false,
 		ExposeValidatorNames:      false,
 		NoPanicOnFunctionConflict: false,
 		ExposeEnumValues:          false,
 		TriggerTagName:            "trigger",
 		FlagTagName:               "flags",
+		FunctionSeparator:         "|",
+		LegacyMinMaxStringLength:  true,
+		MaxDepth:                  32,
+		PanicOnTagError:           true,
+		TagLimits:                 defaultTagComplexityLimits(),
+	}
+}
+
+// Check Check verifies the consistency of the options: tag names must be set and distinct, the function separator
+// must be usable and numeric limits must not be negative.
+func (o ValidationOptions) Check() error {
+	options := []struct {
+		option string
+		value  string
+	}{
+		{"FilterTagName", o.FilterTagName},
+		{"TriggerTagName", o.TriggerTagName},
+		{"ValidatorTagName", o.ValidatorTagName},
+		{"MessageTagName", o.MessageTagName},
+		{"LabelTagName", o.LabelTagName},
+		{"RulesTagName", o.RulesTagName},
+		{"FlagTagName", o.FlagTagName},
+	}
+
+	used := make(map[string]string, len(options))
+	for _, tag := range options {
+		if strings.TrimSpace(tag.value) == "" {
+			return newValidationError("invalid options: " + tag.option + " must not be empty")
+		}
+		for _, name := range tagNames(tag.value) {
+			if name == "" {
+				return newValidationError("invalid options: " + tag.option + " lists an empty tag name")
+			}
+			key := name
+			if o.CaseInsensitiveTagNames {
+				key = strings.ToLower(name)
+			}
+			if other, ok := used[key]; ok {
+				if other == tag.option {
+					return newValidationError("invalid options: " + tag.option + " lists the tag name " + name + " twice")
+				}
+				return newValidationError("invalid options: " + tag.option + " and " + other + " both use the tag name " + name)
+			}
+			used[key] = tag.option
+		}
+	}
+
+	if o.FunctionSeparator == "" {
+		return newValidationError("invalid options: FunctionSeparator must not be empty")
+	}
+	if strings.ContainsAny(o.FunctionSeparator, "(),'") {
+		return newValidationError("invalid options: FunctionSeparator must not contain parentheses, commas or quotes")
+	}
+
+	limits := []struct {
+		option string
+		value  int
+	}{
+		{"MaxDepth", o.MaxDepth},
+		{"MaxSliceErrors", o.MaxSliceErrors},
+		{"SliceSample", o.SliceSample},
+		{"Parallelism", o.Parallelism},
 	}
-	cache = &fieldCache{}
+	for _, limit := range limits {
+		if limit.value < 0 {
+			return newValidationError("invalid options: " + limit.option + " must not be negative")
+		}
+	}
+	if o.ValidatorTimeout < 0 {
+		return newValidationError("invalid options: ValidatorTimeout must not be negative")
+	}
+	if err := o.TagLimits.check(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// defaultValidator is the instance used by the package level functions
+var defaultValidator *Validator
+
+func init() {
+	defaultValidator = New()
 }
 
 type fieldValueValidator struct {
 	fn   ValidationFunction
 	name string
 	args []string
+	rule string
+	// checkArgs verifies the arguments once their placeholders are resolved, nil if there is nothing to verify
+	checkArgs func([]string) error
 }
 
 func (f fieldValueValidator) Apply(ctx *ValidationContext) interface{} {
@@ -116,12 +465,41 @@ type ValidationError struct {
 type FieldError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
+	// Validator the name of the validator which reported the error, e.g. "min". Empty for errors not reported by a
+	// validator, such as filter failures or errors added by struct level validation
+	Validator string `json:"validator,omitempty"`
+	// Code a machine readable code identifying the kind of failure, e.g. CodeTimeout. Empty for regular validation
+	// failures
+	Code string `json:"code,omitempty"`
+	// Cause the underlying error reported by the validator through ValidationContext.AdditionalError, if any
+	Cause error `json:"-"`
 }
 
+// Codes of field errors, see FieldError.Code
+const (
+	// CodeTimeout identifies validators abandoned after ValidationOptions.ValidatorTimeout or the timeout flag
+	CodeTimeout = "timeout"
+	// CodeMissing identifies values that were not provided, such as nil pointers, reported by required, required_if,
+	// required_unless and nonzero
+	CodeMissing = "missing"
+	// CodeEmpty identifies values that were provided but are empty, such as blank strings or zeros, reported by
+	// required, required_if, required_unless and nonzero
+	CodeEmpty = "empty"
+	// CodeInvalidType identifies values ValidateInto cannot convert to the type of their field
+	CodeInvalidType = "invalid_type"
+	// CodeUnknownField identifies keys ValidateInto finds no field for, see ValidationOptions.RejectUnknownKeys
+	CodeUnknownField = "unknown_field"
+)
+
 func (e FieldError) Error() string {
 	return e.Field + ": " + e.Message
 }
 
+// Unwrap Unwrap returns the underlying error reported by the validator, allowing errors.Is and errors.As to inspect it
+func (e FieldError) Unwrap() error {
+	return e.Cause
+}
+
 func (e ValidationError) Error() string {
 	if e.ErrorDelegate == nil {
 		return e.Message
@@ -139,6 +517,13 @@ type ValidationResult struct {
 	//
 	// FieldErrors FieldErrors struct field validation errors
 	FieldErrors []FieldError
+	//
+	// SkippedElements SkippedElements number of slice or array elements that were not validated
+	// because of ValidationOptions.MaxSliceErrors or ValidationOptions.SliceSample
+	SkippedElements int
+	//
+	// SampledIndexes SampledIndexes indexes of randomly sampled slice or array elements, keyed by the path of the slice
+	SampledIndexes map[string][]int
 }
 
 func (r ValidationResult) IsValid() bool {
@@ -153,130 +538,123 @@ type ValidationFunction func(ctx *ValidationContext) bool
 // This function may manipulate the value in place or return a completely new value.
 //
 // However, the contract is that they must always return a value depending on the input value and logic contained therein.
+// Filters storing the value with ValidationContext.SetValue or ValidationContext.SetReflectValue may return the invalid
+// reflect.Value{} or ctx.GetValue() instead, keeping the value they set.
+//
+// A filter fails by setting ctx.ErrorMessage or ctx.AdditionalError, e.g. when the input cannot be normalized. The
+// returned value is then discarded and the failure is reported as a field error, MsgFilterFailed being used if no
+// message is set. The remaining filters and the validators of the field do not run. Failing filters must not modify
+// the value in place: the field keeps the value returned by the preceding filters.
 type FilterFunction func(ctx *ValidationContext) reflect.Value
 
 // SetupOptions SetupOptions allows you to configure the global validation options.
-func SetupOptions(configCallback func(*ValidationOptions)) {
-	configCallback(&globalOptions)
+//
+// The new options are only applied if they pass ValidationOptions.Check, otherwise the error is returned. They affect
+// subsequent validation calls only, validations in progress keeping the options they started with.
+//
+// Since parsed struct tags depend on the options (tag names in particular), calling this function
+// clears the struct cache. Structs are then parsed again with the new options upon their next validation.
+func SetupOptions(configCallback func(*ValidationOptions)) error {
+	return defaultValidator.SetupOptions(configCallback)
+}
+
+// ClearCache ClearCache removes all parsed struct information from the cache.
+func ClearCache() {
+	defaultValidator.ClearCache()
 }
 
 // CopyOptions CopyOptions Copies the default global options into the specified destination.
 // Useful when you want to have localized validation options
 func CopyOptions(opts *ValidationOptions) {
-	*opts = globalOptions
+	defaultValidator.CopyOptions(opts)
 }
 
 // AddValidator adds the given validator function to the list of validators
 //
-// The backed storage containing the list of validators is not thread safe and so this function
-// must be called once during package or application initialization.
+// The function is safe to call concurrently with validation, e.g. when registering validators lazily.
 //
 // You cannot replace validator functions that have already been added to the list, so the function
-// will panic if the name already exists.
+// will panic if the name already exists. Use ReplaceValidator instead.
 func AddValidator(name string, v ValidationFunction) {
-	_, exists := validatorFunctions[name]
-	if exists && !globalOptions.NoPanicOnFunctionConflict {
-		panic(errors.New("a validator by the name of " + name + " already exists"))
-	} else {
-		validatorFunctions[name] = v
-	}
+	defaultValidator.AddValidator(name, v)
+}
+
+// ReplaceValidator ReplaceValidator registers the given validator function with the default instance, replacing the
+// validator registered under the same name, if any.
+//
+// See Validator.ReplaceValidator for details.
+func ReplaceValidator(name string, v ValidationFunction) {
+	defaultValidator.ReplaceValidator(name, v)
+}
+
+// RemoveValidator RemoveValidator removes the validator registered under the given name from the default instance.
+func RemoveValidator(name string) {
+	defaultValidator.RemoveValidator(name)
 }
 
 // AddFilter adds the given filter function to the list of filters
 //
-// The backed storage containing the list of filters is not thread safe and so this function
-// must be called once during package or application initialization.
+// The function is safe to call concurrently with validation, e.g. when registering filters lazily.
 //
 // You cannot replace filter functions that have already been added to the list, so the function
-// will panic if the name already exists.
+// will panic if the name already exists. Use ReplaceFilter instead.
 func AddFilter(name string, v FilterFunction) {
-	_, exists := filterFunctions[name]
-	if exists && !globalOptions.NoPanicOnFunctionConflict {
-		panic(errors.New("a filter by the name of " + name + " already exists"))
-	}
-	filterFunctions[name] = v
+	defaultValidator.AddFilter(name, v)
+}
+
+// ReplaceFilter ReplaceFilter registers the given filter function with the default instance, replacing the filter
+// registered under the same name, if any.
+//
+// See Validator.ReplaceFilter for details.
+func ReplaceFilter(name string, v FilterFunction) {
+	defaultValidator.ReplaceFilter(name, v)
+}
+
+// RemoveFilter RemoveFilter removes the filter registered under the given name from the default instance.
+func RemoveFilter(name string) {
+	defaultValidator.RemoveFilter(name)
 }
 
 // Validate validates the given struct
 //
 // # Parameters
 //
-// structPtr : Pointer to a struct
+// structPtr : Pointer to a struct. Structs passed by value are validated in read-only mode: validators run as usual
+// but filters cannot modify the struct, so ValidationResult.Error is set if a validated field has filters, whose
+// validators are skipped.
 //
-// trigger   : Activation trigger - Specifies a unique value that will trigger activation of fields that have been taggeed with
-// the same value.
+// trigger   : Activation triggers - Specify values that will trigger activation of fields that have been taggeed with
+// any of the same values. Fields tagged with (or defaulting to) 'all' are always activated. Passing no trigger is
+// equivalent to passing 'all': only such fields are activated. Empty triggers are ignored.
 func Validate(structPtr interface{}, trigger ...string) (res *ValidationResult) {
-
-	t := reflect.TypeOf(structPtr)
-	res = &ValidationResult{
-		valid: false,
-	}
-
-	if t.Kind() != reflect.Ptr {
-		res.Error = newValidationError("Invalid input type. Expected struct pointer but found " + t.Kind().String())
-		return
-	}
-
-	t = t.Elem()
-	structValue := reflect.ValueOf(structPtr).Elem()
-
-	// get from cache
-	fieldContexts := getStructFields(t, &globalOptions)
-	activationTrigger := "all"
-
-	if len(trigger) > 0 {
-		activationTrigger = trigger[0]
-	}
-
-	for _, fc := range fieldContexts {
-		if !fc.activate(activationTrigger) {
-			continue
-		}
-		errs := fc.apply(structValue, &globalOptions)
-		if len(errs) > 0 {
-			res.FieldErrors = append(res.FieldErrors, errs...)
-		}
-	}
-
-	res.valid = res.Error == nil && len(res.FieldErrors) == 0
-
-	return
+	return defaultValidator.Validate(structPtr, trigger...)
 }
 
-func getStructFields(t reflect.Type, opts *ValidationOptions) []*fieldContext {
-	fullyQualifiedStructName := t.PkgPath()
-	if len(fullyQualifiedStructName) != 0 {
-		fullyQualifiedStructName = fullyQualifiedStructName + "." + t.Name()
-	}
-
-	contexts, ok := cache.Get(fullyQualifiedStructName)
-	if ok {
-		return contexts
-	}
-
-	stack := Stack{}
-	stack.Push(t)
-	contexts = make([]*fieldContext, 0)
-
-	for !stack.IsEmpty() {
-		structType := stack.Pop().(reflect.Type)
-		for i := 0; i < structType.NumField(); i++ {
-			field := structType.Field(i)
-			if field.Type.Kind() == reflect.Struct {
-				stack.Push(field.Type)
-			} else {
-				fc := mustParseField(field, opts)
-				if fc != nil {
-					contexts = append(contexts, fc)
-				}
-			}
-		}
-	}
+// ValidateContext ValidateContext validates the given struct like Validate, passing the given context to validators
+// and filters through ValidationContext.Context, e.g. to bound validators performing I/O such as resolvable.
+//
+// Once the context is done, the remaining fields are not validated and ValidationResult.Error is set.
+func ValidateContext(ctx context.Context, structPtr interface{}, trigger ...string) (res *ValidationResult) {
+	return defaultValidator.ValidateContext(ctx, structPtr, trigger...)
+}
 
-	// add to cache
-	cache.Store(fullyQualifiedStructName, contexts)
+// Register Register eagerly parses the tags of the given structs (or struct pointers) and of the structs nested
+// within them, reporting all problems found, such as references to unknown validators or filters, invalid arguments
+// of packaged validators or packaged filters used on unsupported types.
+//
+// Use it during application startup to discover invalid tags early. Registration is idempotent and safe to call
+// concurrently. Structs that are not registered are parsed upon their first validation.
+func Register(structs ...interface{}) error {
+	return defaultValidator.Register(structs...)
+}
 
-	return contexts
+// ValidateWithOptions validates the given struct using the given options instead of the global options.
+//
+// Use CopyOptions to obtain the global options as a starting point. Options which only affect validation at run time,
+// such as StopOnFirstError, ExposeEnumValues and ExposeValidatorNames, can be varied freely. Structs parsed using
+// different tag names are cached separately.
+func ValidateWithOptions(structPtr interface{}, opts ValidationOptions, trigger ...string) (res *ValidationResult) {
+	return defaultValidator.ValidateWithOptions(structPtr, opts, trigger...)
 }
 
 func newValidationError(msg string, e ...error) *ValidationError {
@@ -1,11 +1,18 @@
 package validator
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,7 +60,7 @@ func TestCustomFunctions(t *testing.T) {
 
 	type MyStruct struct {
 		Name string `filter:"trim|upper"`
-		Age  int    `validator:"range(10,50)" filter:"square|square"`
+		Age  int    `validator:"range(100000,200000)" filter:"square|square"`
 	}
 
 	myStruct := MyStruct{Age: 20, Name: "  John Doe  "}
@@ -122,7 +129,7 @@ func TestPointer(t *testing.T) {
 
 	type MyStruct struct {
 		Name *string `filter:"trim|upper"`
-		Age  *int    `validator:"min(50)" filter:"square" label:"Agent age"`
+		Age  *int    `validator:"min(2000)" filter:"square" label:"Agent age"`
 	}
 
 	myStruct := MyStruct{Age: &age, Name: &name}
@@ -183,6 +190,117 @@ func TestEnum(t *testing.T) {
 	assertTrue(t, res.IsValid(), "Validation failed")
 }
 
+type orderStatus uint8
+
+const (
+	statusPending orderStatus = iota
+	statusApproved
+	statusRejected
+)
+
+func (s orderStatus) String() string {
+	return [...]string{"PENDING", "APPROVED", "REJECTED"}[s]
+}
+
+type priority int
+
+func (p *priority) String() string {
+	return [...]string{"LOW", "HIGH"}[*p]
+}
+
+func TestEnumStringer(t *testing.T) {
+	type Order struct {
+		Status   orderStatus  `validator:"enum(PENDING,APPROVED)"`
+		Previous *orderStatus `validator:"enum(PENDING,REJECTED)"`
+		Code     orderStatus  `validator:"enum(0,1)"`
+		Priority priority     `validator:"enum(HIGH)"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.ExposeEnumValues = true
+	})
+
+	previous := statusRejected
+	order := Order{Status: statusApproved, Previous: &previous, Code: statusApproved, Priority: 1}
+	assertTrue(t, v.Validate(&order).IsValid(), "Validation failed")
+
+	order = Order{Status: statusRejected, Code: statusRejected}
+	res := v.Validate(&order)
+	assertEqual(t, []FieldError{
+		{Field: "Status", Message: MsgEnum + fmt.Sprintf(MsgEnumValues, "PENDING,APPROVED"), Validator: "enum"},
+		{Field: "Code", Message: MsgEnum + fmt.Sprintf(MsgEnumValues, "0,1"), Validator: "enum"},
+		{Field: "Priority", Message: MsgEnum + fmt.Sprintf(MsgEnumValues, "HIGH"), Validator: "enum"},
+	}, res.FieldErrors)
+
+	// integers without String method cannot be matched against names
+	type Plain struct {
+		Value int `validator:"enum(ONE,1)"`
+	}
+	assert.PanicsWithError(t, "struct validator.Plain, field Value, rule `enum(ONE,1)`: validator `enum` has invalid arguments: `ONE` is not a valid int value", func() {
+		v.Validate(&Plain{Value: 1})
+	})
+}
+
+func TestEnumNormalization(t *testing.T) {
+	type Settings struct {
+		Level int8   `validator:"enum(01, +2,-3 , 1)"`
+		Port  uint16 `validator:"enum(080,+443)"`
+		Mode  string `validator:"enum( fast , slow,fast)"`
+		Limit *int64 `validator:"enum(007,-0)"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.ExposeEnumValues = true
+	})
+	for _, settings := range []Settings{
+		{Level: 1, Port: 80, Mode: "fast", Limit: new(int64)},
+		{Level: 2, Port: 443, Mode: "slow"},
+		{Level: -3, Port: 80, Mode: "fast"},
+	} {
+		assertTrue(t, v.Validate(&settings).IsValid(), "expected normalized values to match")
+	}
+
+	// duplicates are dropped
+	res := v.Validate(&Settings{Level: 4, Port: 80, Mode: "fast"})
+	assertEqual(t, []FieldError{{Field: "Level", Message: MsgEnum + fmt.Sprintf(MsgEnumValues, "1,2,-3"), Validator: "enum"}}, res.FieldErrors)
+
+	// duplicates are rejected by strict tag parsing
+	strict := New(func(opts *ValidationOptions) {
+		opts.StrictTagParsing = true
+	})
+	type Duplicates struct {
+		Level int `validator:"enum(1, 2,01)"`
+	}
+	assert.PanicsWithError(t, "struct validator.Duplicates, field Level, rule `enum(1, 2,01)`: validator `enum` has invalid arguments: duplicate value `01`, same as `1`", func() {
+		strict.Validate(&Duplicates{})
+	})
+
+	// values are parsed as the kind of the field
+	type Invalid struct {
+		Level int8 `validator:"enum(1,128)"`
+	}
+	assert.PanicsWithError(t, "struct validator.Invalid, field Level, rule `enum(1,128)`: validator `enum` has invalid arguments: `128` is not a valid int8 value", func() {
+		v.Validate(&Invalid{})
+	})
+	type Unsigned struct {
+		Port uint `validator:"enum(-1)"`
+	}
+	assert.PanicsWithError(t, "struct validator.Unsigned, field Port, rule `enum(-1)`: validator `enum` has invalid arguments: `-1` is not a valid uint value", func() {
+		v.Validate(&Unsigned{})
+	})
+
+	// quoted values are kept as is
+	type Separator struct {
+		Value string `validator:"enum(' ',',', ' - ' )"`
+	}
+	for _, value := range []string{" ", ",", " - "} {
+		assertTrue(t, v.Validate(&Separator{Value: value}).IsValid(), "expected quoted value `%s` to match", value)
+	}
+	for _, value := range []string{"", "-"} {
+		assertFalse(t, v.Validate(&Separator{Value: value}).IsValid())
+	}
+}
+
 func TestOptional(t *testing.T) {
 
 	type MyStruct struct {
@@ -211,6 +329,50 @@ func TestRequired(t *testing.T) {
 	assertFalse(t, res.IsValid(), "Validation failed")
 }
 
+func TestRequiredRejectsZeroValues(t *testing.T) {
+	type Profile struct {
+		Name    string     `validator:"required"`
+		Alias   *string    `validator:"required"`
+		Age     int        `validator:"required"`
+		Tags    []string   `validator:"required"`
+		Created *time.Time `validator:"required"`
+	}
+
+	empty, zero := "", time.Time{}
+	name, now := "jane", time.Now()
+	blank := Profile{Alias: &empty, Tags: []string{}, Created: &zero}
+	filled := Profile{Name: name, Alias: &name, Age: 0, Tags: []string{"a"}, Created: &now}
+
+	// by default only nil pointers fail
+	v := New()
+	assertTrue(t, v.Validate(&blank).IsValid(), "expected empty values to pass by default")
+	res := v.Validate(&Profile{})
+	assertEqual(t, []FieldError{
+		{Field: "Alias", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "Created", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+	}, res.FieldErrors)
+
+	// with the option, empty strings, slices and zero structs fail as well, numbers pass
+	strict := New(func(opts *ValidationOptions) {
+		opts.RequiredRejectsZeroValues = true
+	})
+	assertTrue(t, strict.Validate(&filled).IsValid(), "expected non-empty values and a zero number to pass")
+	res = strict.Validate(&blank)
+	assertEqual(t, []FieldError{
+		{Field: "Name", Message: MsgEmpty, Validator: "required", Code: CodeEmpty},
+		{Field: "Alias", Message: MsgEmpty, Validator: "required", Code: CodeEmpty},
+		{Field: "Tags", Message: MsgEmpty, Validator: "required", Code: CodeEmpty},
+		{Field: "Created", Message: MsgEmpty, Validator: "required", Code: CodeEmpty},
+	}, res.FieldErrors)
+	res = strict.Validate(&Profile{})
+	assertEqual(t, []FieldError{
+		{Field: "Name", Message: MsgEmpty, Validator: "required", Code: CodeEmpty},
+		{Field: "Alias", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "Tags", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "Created", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+	}, res.FieldErrors)
+}
+
 func TestNested(t *testing.T) {
 
 	type struct2 struct {
@@ -281,6 +443,477 @@ func TestActivationTrigger(t *testing.T) {
 	assertFalse(t, res.IsValid(), "Validation failed")
 }
 
+type nickname string
+
+func TestSetValue(t *testing.T) {
+	type Profile struct {
+		Name     string    `filter:"trim|upper"`
+		Nickname *nickname `filter:"trim|upper"`
+		Bio      *string   `filter:"trim|drop_empty"`
+		Tags     []string  `filter:"trim"`
+		Extra    any       `filter:"trim"`
+	}
+
+	v := New()
+	v.AddFilter("upper", func(ctx *ValidationContext) reflect.Value {
+		if !ctx.IsNull {
+			ctx.SetValue(strings.ToUpper(ctx.GetValue().String()))
+		}
+		return reflect.Value{}
+	})
+	v.AddFilter("drop_empty", func(ctx *ValidationContext) reflect.Value {
+		if !ctx.IsNull && ctx.GetValue().String() == "" {
+			ctx.SetReflectValue(reflect.Value{})
+		}
+		return reflect.Value{}
+	})
+
+	nick, bio, extra := nickname(" jo "), "   ", " x "
+	profile := Profile{Name: " jane ", Nickname: &nick, Bio: &bio, Tags: []string{" a ", "b "}, Extra: &extra}
+	assertTrue(t, v.Validate(&profile).IsValid())
+	assertEqual(t, "JANE", profile.Name)
+	assertEqual(t, nickname("JO"), *profile.Nickname)
+	assertNull(t, profile.Bio)
+	assertEqual(t, []string{"a", "b"}, profile.Tags)
+	assertEqual(t, "x", *profile.Extra.(*string))
+
+	// pointers are replaced rather than modified in place
+	assertEqual(t, nickname(" jo "), nick)
+	assertEqual(t, " x ", extra)
+
+	// filters may return the value they set, pointers included
+	v.AddFilter("lower", func(ctx *ValidationContext) reflect.Value {
+		if !ctx.IsNull {
+			ctx.SetValue(strings.ToLower(ctx.GetValue().String()))
+		}
+		return ctx.GetValue()
+	})
+	type Account struct {
+		Login *string `filter:"lower"`
+		Email string  `filter:"lower"`
+	}
+	login := "JANE"
+	account := Account{Login: &login, Email: "Jane@Example.com"}
+	assertTrue(t, v.Validate(&account).IsValid())
+	assertEqual(t, "jane", *account.Login)
+	assertEqual(t, "jane@example.com", account.Email)
+	assertEqual(t, "JANE", login)
+
+	// packaged filters return the value they set, letting filters compose them
+	type Comment struct {
+		Body   string  `filter:"shout"`
+		Author *string `filter:"trim_author"`
+	}
+	v.AddFilter("shout", func(ctx *ValidationContext) reflect.Value {
+		return reflect.ValueOf(strings.ToUpper(Trim(ctx).String()) + "!")
+	})
+	v.AddFilter("trim_author", func(ctx *ValidationContext) reflect.Value {
+		return Trim(ctx)
+	})
+	author := " jane "
+	comment := Comment{Body: " hi ", Author: &author}
+	assertTrue(t, v.Validate(&comment).IsValid())
+	assertEqual(t, "HI!", comment.Body)
+	assertEqual(t, "jane", *comment.Author)
+	assertEqual(t, " jane ", author)
+
+	// values of other kinds are rejected
+	type Score struct {
+		Points *int `filter:"upper"`
+	}
+	points := 1
+	assert.PanicsWithError(t, "cannot set a string value into the *int value of Points", func() {
+		v.Validate(&Score{Points: &points})
+	})
+}
+
+func TestMultipleActivationTriggers(t *testing.T) {
+	type User struct {
+		Id      int    `validator:"min(1000)" trigger:"create,update"`
+		Auditor string `validator:"length(1,_)" trigger:"audit"`
+		Name    string `validator:"length(1,_)"` // implicit 'all'
+	}
+
+	user := User{Id: 1, Name: "jane"}
+
+	res := Validate(&user, "update", "audit")
+	assertFalse(t, res.IsValid(), "Validation failed")
+	assertEqual(t, len(res.FieldErrors), 2)
+	assertEqual(t, res.FieldErrors[0].Field, "Id")
+	assertEqual(t, res.FieldErrors[1].Field, "Auditor")
+
+	res = Validate(&user, "delete", "audit")
+	assertEqual(t, len(res.FieldErrors), 1)
+	assertEqual(t, res.FieldErrors[0].Field, "Auditor")
+
+	// no trigger and empty triggers are equivalent to 'all'
+	assertTrue(t, Validate(&user).IsValid(), "Validation failed")
+	assertTrue(t, Validate(&user, "").IsValid(), "Validation failed")
+	assertTrue(t, Validate(&user, "all").IsValid(), "Validation failed")
+
+	user.Name = ""
+	res = Validate(&user, "all")
+	assertEqual(t, len(res.FieldErrors), 1)
+	assertEqual(t, res.FieldErrors[0].Field, "Name")
+}
+
+func TestNegatedTriggers(t *testing.T) {
+	tests := []struct {
+		tag      string
+		triggers []string
+		active   bool
+	}{
+		{tag: "", active: true},
+		{tag: "update", active: false},
+		{tag: "update", triggers: []string{"update"}, active: true},
+		{tag: "!update", active: true},
+		{tag: "!update", triggers: []string{"all"}, active: true},
+		{tag: "!update", triggers: []string{"update"}, active: false},
+		{tag: "!update", triggers: []string{"create"}, active: true},
+		{tag: "!update", triggers: []string{"create", "update"}, active: false},
+		{tag: "create,!import", triggers: []string{"create", "import"}, active: true},
+		{tag: "create,!import", triggers: []string{"import"}, active: false},
+		{tag: "create,!import", triggers: []string{"update"}, active: true},
+		{tag: "all,!update", active: true},
+		{tag: "all,!update", triggers: []string{"update"}, active: false},
+	}
+
+	v := New()
+	for _, test := range tests {
+		tag := `validator:"min(1)"`
+		if test.tag != "" {
+			tag += ` trigger:"` + test.tag + `"`
+		}
+		structType := reflect.StructOf([]reflect.StructField{
+			{Name: "Id", Type: reflect.TypeOf(0), Tag: reflect.StructTag(tag)},
+		})
+
+		res := v.Validate(reflect.New(structType).Interface(), test.triggers...)
+		assertNull(t, res.Error)
+		assertEqual(t, test.active, len(res.FieldErrors) == 1, fmt.Sprintf("trigger:%q activated by %v", test.tag, test.triggers))
+	}
+
+	type Invalid struct {
+		Id   int `validator:"min(1)" trigger:"!"`
+		Name int `validator:"min(1)" trigger:"create,!all"`
+	}
+	err := v.Register(Invalid{})
+	assert.ErrorContains(t, err, "field Id, rule `!`: invalid negated trigger `!`")
+	assert.ErrorContains(t, err, "field Name, rule `!all`: invalid negated trigger `!all`")
+}
+
+func TestSkipFiltersFlag(t *testing.T) {
+	type Envelope struct {
+		Subject   string `validator:"length(1,_)" filter:"trim"`
+		Signature string `validator:"length(1,_)" filter:"trim" flags:"skip_filters"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.StringAutoTrim = true
+	})
+
+	envelope := Envelope{Subject: "  hello ", Signature: "  c2lnbmF0dXJl"}
+	res := v.Validate(&envelope)
+	assertTrue(t, res.IsValid(), "Validation failed")
+	assertEqual(t, "hello", envelope.Subject)
+	assertEqual(t, "  c2lnbmF0dXJl", envelope.Signature)
+
+	// fields without filters to apply can be validated in read-only mode
+	type Signed struct {
+		Signature string `validator:"length(1,_)" filter:"trim" flags:"skip_filters"`
+	}
+	res = v.Validate(Signed{Signature: " c2ln"})
+	assertTrue(t, res.IsValid(), "Validation failed")
+	assertNull(t, res.Error)
+}
+
+func TestUuidCanonical(t *testing.T) {
+	type Lookup struct {
+		Id     string  `validator:"uuid_canonical"`
+		Parent *string `validator:"uuid_canonical"`
+	}
+
+	tests := []struct {
+		id      string
+		message string
+	}{
+		{id: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{id: "9b5d3d2e-54c4-4b8a-9fb4-6a1b2c3d4e5f"},
+		{id: "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", message: MsgUUIDCanonical},
+		{id: "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", message: MsgUUIDCanonical},
+		{id: "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", message: MsgUUIDCanonical},
+		{id: "6ba7b8109dad11d180b400c04fd430c8", message: MsgUUIDCanonical},
+		{id: "6ba7b810", message: MsgUUIDFormat},
+	}
+
+	for _, test := range tests {
+		res := Validate(&Lookup{Id: test.id})
+		if test.message == "" {
+			assertTrue(t, res.IsValid(), test.id)
+			continue
+		}
+		assertEqual(t, []FieldError{{Field: "Id", Message: test.message, Validator: "uuid_canonical"}}, res.FieldErrors, test.id)
+	}
+}
+
+func TestCanonicalUuidFilter(t *testing.T) {
+	type Lookup struct {
+		Id     string  `validator:"uuid1" filter:"canonical_uuid"`
+		Parent *string `filter:"canonical_uuid"`
+		Other  *string `filter:"canonical_uuid"`
+	}
+
+	parent := "{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}"
+	lookup := Lookup{Id: "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", Parent: &parent}
+	assertTrue(t, Validate(&lookup).IsValid(), "Validation failed")
+	assertEqual(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", lookup.Id)
+	assertEqual(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", *lookup.Parent)
+	assertNull(t, lookup.Other)
+
+	// values which are not UUIDs are left as is for validators to report
+	invalid := Lookup{Id: "NOT-A-UUID"}
+	res := Validate(&invalid)
+	assertEqual(t, []FieldError{{Field: "Id", Message: MsgUUIDFormat, Validator: "uuid1"}}, res.FieldErrors)
+	assertEqual(t, "NOT-A-UUID", invalid.Id)
+
+	// defined string types keep their type
+	type ID string
+	type Reference struct {
+		Id     ID  `filter:"canonical_uuid"`
+		Parent *ID `filter:"canonical_uuid"`
+	}
+	parentID := ID("{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}")
+	reference := Reference{Id: "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", Parent: &parentID}
+	assertTrue(t, Validate(&reference).IsValid(), "Validation failed")
+	assertEqual(t, ID("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), reference.Id)
+	assertEqual(t, ID("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), *reference.Parent)
+	assertEqual(t, ID("{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}"), parentID, "expected the pointed value to be left as is")
+}
+
+func TestStopOnErrorFlag(t *testing.T) {
+	type Contact struct {
+		Email *string `validator:"required|length(5,_)|email" flags:"stop_on_error"`
+		Name  string  `validator:"length(3,_)|alphanum" filter:"trim"`
+		Alias string  `validator:"length(3,_)|alphanum" filter:"trim" flags:"stop_on_error"`
+	}
+
+	newContact := func() *Contact {
+		return &Contact{Name: " A", Alias: " B"}
+	}
+
+	contact := newContact()
+	res := New().Validate(contact)
+	assertEqual(t, []FieldError{
+		{Field: "Email", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "Name", Message: "length (1) must be at least 3", Validator: "length"},
+		{Field: "Name", Message: MsgAlphaNumeric, Validator: "alphanum"},
+		{Field: "Alias", Message: "length (1) must be at least 3", Validator: "length"},
+	}, res.FieldErrors)
+	// filters still run
+	assertEqual(t, "B", contact.Alias)
+
+	perField := New(func(opts *ValidationOptions) {
+		opts.StopOnFirstErrorPerField = true
+	})
+	res = perField.Validate(newContact())
+	assertEqual(t, []FieldError{
+		{Field: "Email", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "Name", Message: "length (1) must be at least 3", Validator: "length"},
+		{Field: "Alias", Message: "length (1) must be at least 3", Validator: "length"},
+	}, res.FieldErrors)
+
+	// the global option implies the per field behavior
+	global := New(func(opts *ValidationOptions) {
+		opts.StopOnFirstError = true
+	})
+	res = global.Validate(newContact())
+	assertEqual(t, []FieldError{{Field: "Email", Message: MsgRequired, Validator: "required", Code: CodeMissing}}, res.FieldErrors)
+}
+
+func TestSensitiveFlag(t *testing.T) {
+	type Credentials struct {
+		Password string `validator:"min(12)|secret" filter:"trim" flags:"sensitive"`
+		Token    string `validator:"max(4)" flags:"sensitive"`
+		Expiry   string `validator:"after_today(date)|age_between(30,65,date)" flags:"sensitive"`
+		Hint     string `validator:"max(4)"`
+	}
+
+	v := New(func(opts *ValidationOptions) {
+		opts.IncludeFieldValues = true
+		opts.ExposeUnderlyingErrors = true
+	})
+	v.AddValidator("secret", func(ctx *ValidationContext) bool {
+		ctx.AdditionalError = fmt.Errorf("%v is a common password", ctx.GetValue())
+		return false
+	})
+
+	creds := Credentials{Password: " hunter2 ", Token: "tok-123", Expiry: "2000-01-01", Hint: "pets-name"}
+	res := v.Validate(&creds)
+	assertEqual(t, 6, len(res.FieldErrors), fmt.Sprint(res.FieldErrors))
+
+	for _, fe := range res.FieldErrors[:5] {
+		for _, value := range []string{"hunter2", "tok-123", "2000-01-01", "common password"} {
+			assertFalse(t, strings.Contains(fe.Message, value), fmt.Sprintf("%s: %q reveals %q", fe.Field, fe.Message, value))
+		}
+		assertTrue(t, fe.Cause == nil, fmt.Sprintf("%s: unexpected cause %v", fe.Field, fe.Cause))
+	}
+	assertEqual(t, "length ([redacted]) must be at least 12", res.FieldErrors[0].Message)
+	assertEqual(t, "length ([redacted]) must not exceed 4", res.FieldErrors[2].Message)
+	assertTrue(t, strings.HasPrefix(res.FieldErrors[3].Message, "[redacted] must be after "), res.FieldErrors[3].Message)
+	assertEqual(t, fmt.Sprintf(MsgAgeRange, 30, 65), res.FieldErrors[4].Message)
+
+	// other fields are unaffected
+	assertEqual(t, "length (pets-name) must not exceed 4", res.FieldErrors[5].Message)
+	// filters still run
+	assertEqual(t, "hunter2", creds.Password)
+}
+
+func TestValidatorTimeout(t *testing.T) {
+	type Account struct {
+		Username string `validator:"unique|length(5,_)"`
+		Email    string `validator:"unique" flags:"timeout=0"`
+	}
+
+	release := make(chan struct{})
+	finished := make(chan struct{}, 2)
+	v := New(func(opts *ValidationOptions) {
+		opts.ValidatorTimeout = 20 * time.Millisecond
+	})
+	// the uniqueness check of usernames stalls until released, ignoring its context
+	v.AddValidator("unique", func(ctx *ValidationContext) bool {
+		defer func() { finished <- struct{}{} }()
+		if ctx.GetValue().String() == "jane" {
+			<-release
+			ctx.ErrorMessage = "already taken"
+			return false
+		}
+		return true
+	})
+
+	start := time.Now()
+	res := v.Validate(&Account{Username: "jane", Email: "jane@example.com"})
+	assertTrue(t, time.Since(start) < time.Second, "expected the validator to be abandoned")
+
+	assertEqual(t, 2, len(res.FieldErrors), fmt.Sprint(res.FieldErrors))
+	fe := res.FieldErrors[0]
+	assertEqual(t, "Username", fe.Field)
+	assertEqual(t, "unique", fe.Validator)
+	assertEqual(t, CodeTimeout, fe.Code)
+	assertEqual(t, "validation did not complete within 20ms", fe.Message)
+	assertTrue(t, errors.Is(fe, context.DeadlineExceeded), "expected the deadline to be exceeded")
+	// the remaining validators of the field still run
+	assertEqual(t, FieldError{Field: "Username", Message: "length (4) must be at least 5", Validator: "length"}, res.FieldErrors[1])
+
+	// the outcome of the abandoned call is discarded
+	close(release)
+	<-finished
+	<-finished
+	assertEqual(t, 2, len(res.FieldErrors))
+
+	// the flag overrides the option
+	type Slow struct {
+		Name string `validator:"sleepy" flags:"timeout=10ms"`
+		Bio  string `validator:"sleepy" flags:"timeout=0"`
+	}
+	v = New()
+	v.AddValidator("sleepy", func(ctx *ValidationContext) bool {
+		select {
+		case <-ctx.Context().Done():
+		case <-time.After(50 * time.Millisecond):
+		}
+		return true
+	})
+	res = v.Validate(&Slow{})
+	assertEqual(t, 1, len(res.FieldErrors), fmt.Sprint(res.FieldErrors))
+	assertEqual(t, "Name", res.FieldErrors[0].Field)
+	assertEqual(t, CodeTimeout, res.FieldErrors[0].Code)
+
+	type Invalid struct {
+		Name string `validator:"sleepy" flags:"timeout=soon"`
+	}
+	assert.ErrorContains(t, v.Register(Invalid{}), "invalid timeout flag, expected a duration such as timeout=2s")
+}
+
+func TestValidatorTimeoutPanics(t *testing.T) {
+	type Form struct {
+		Name string `validator:"boom" flags:"timeout=1s"`
+	}
+
+	v := New()
+	v.AddValidator("boom", func(ctx *ValidationContext) bool {
+		panic(newValidationError("exploded"))
+	})
+	assert.PanicsWithError(t, "exploded", func() { v.Validate(&Form{}) })
+
+	v.SetupOptions(func(opts *ValidationOptions) {
+		opts.RecoverFromPanics = true
+	})
+	res := v.Validate(&Form{})
+	assertEqual(t, []FieldError{{Field: "Name", Message: "validator boom failed: exploded", Validator: "boom"}}, res.FieldErrors)
+
+	// type mismatches of interface fields are reported as field errors, as without timeout
+	type Message struct {
+		Code any `validator:"min(2)" flags:"timeout=1s"`
+	}
+	res = New().Validate(&Message{Code: true})
+	assertEqual(t, []FieldError{{Field: "Code", Message: "validator min failed: unexpected type found: bool", Validator: "min"}}, res.FieldErrors)
+	assertNull(t, res.Error)
+}
+
+func TestStringAutoTrim(t *testing.T) {
+	type Code string
+	type Profile struct {
+		Name      string  `validator:"min(3)|max(5)"`
+		Nickname  *string `validator:"min(3)"`
+		Missing   *string `validator:"min(3)"`
+		Code      Code    `filter:"upper"`
+		Signature string  `validator:"max(5)" flags:"skip_filters"`
+		Note      string
+	}
+
+	upper := func(ctx *ValidationContext) reflect.Value {
+		ctx.GetValue().SetString(strings.ToUpper(ctx.GetValue().String()))
+		return ctx.value
+	}
+	v := New(func(opts *ValidationOptions) {
+		opts.StringAutoTrim = true
+	})
+	v.AddFilter("upper", upper)
+
+	nickname := "  ab  "
+	profile := Profile{Name: "  abcde  ", Nickname: &nickname, Code: " x1 ", Signature: " sig ", Note: " note "}
+	res := v.Validate(&profile)
+
+	// max(5) sees the trimmed name and min(3) the trimmed nickname
+	assertEqual(t, []FieldError{{Field: "Nickname", Message: "length (ab) must be at least 3", Validator: "min"}}, res.FieldErrors)
+	assertEqual(t, "abcde", profile.Name)
+	assertEqual(t, "ab", *profile.Nickname)
+	// pointers are replaced, the strings they pointed to are left as is
+	assertEqual(t, "  ab  ", nickname)
+	assertNull(t, profile.Missing)
+	// trimming precedes the other filters
+	assertEqual(t, Code("X1"), profile.Code)
+	// fields with skip_filters and fields without validators or filters are left as is
+	assertEqual(t, " sig ", profile.Signature)
+	assertEqual(t, " note ", profile.Note)
+
+	// whitespace only values become empty
+	blank := "   "
+	profile = Profile{Name: "   ", Nickname: &blank}
+	res = v.Validate(&profile)
+	assertEqual(t, 2, len(res.FieldErrors), fmt.Sprint(res.FieldErrors))
+	assertEqual(t, "", profile.Name)
+	assertEqual(t, "", *profile.Nickname)
+
+	// disabled by default
+	plain := New()
+	plain.AddFilter("upper", upper)
+	profile = Profile{Name: "  abcde  ", Code: " x1 "}
+	res = plain.Validate(&profile)
+	assertEqual(t, []FieldError{{Field: "Name", Message: "length (  abcde  ) must not exceed 5", Validator: "max"}}, res.FieldErrors)
+	assertEqual(t, "  abcde  ", profile.Name)
+}
+
 func TestNullIfEmpty(t *testing.T) {
 	type Form struct {
 		Username *string `validator:"alphanum" filter:"trim|null_if_empty"`
@@ -293,6 +926,414 @@ func TestNullIfEmpty(t *testing.T) {
 	assertNull(t, form.Username)
 }
 
+func TestOmitEmpty(t *testing.T) {
+	type Fields struct {
+		StringPtr *string    `validator:"required|probe" filter:"probe" flags:"omitempty"`
+		String    string     `validator:"required|probe" filter:"probe" flags:"omitempty"`
+		IntPtr    *int       `validator:"required|probe" filter:"probe" flags:"omitempty"`
+		Int       int        `validator:"required|probe" filter:"probe" flags:"omitempty"`
+		Strings   []string   `validator:"required|probe" filter:"probe" flags:"omitempty"`
+		Time      *time.Time `validator:"required|probe" filter:"probe" flags:"omitempty"`
+	}
+
+	var calls []string
+	v := New()
+	v.AddValidator("probe", func(ctx *ValidationContext) bool {
+		calls = append(calls, "validator")
+		return true
+	})
+	v.AddFilter("probe", func(ctx *ValidationContext) reflect.Value {
+		calls = append(calls, "filter")
+		return ctx.value
+	})
+
+	emptyString, zeroInt, zeroTime := "", 0, time.Time{}
+	text, number, someTime := "text", 42, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		fields Fields
+		// evaluated is true if the value is not empty, in which case the filter, required and probe run
+		evaluated bool
+	}{
+		{"nil *string", Fields{}, false},
+		{"*string to empty string", Fields{StringPtr: &emptyString}, false},
+		{"*string to non-empty string", Fields{StringPtr: &text}, true},
+		{"empty string", Fields{}, false},
+		{"non-empty string", Fields{String: text}, true},
+		{"blank string", Fields{String: " "}, true},
+		{"nil *int", Fields{}, false},
+		{"*int to zero", Fields{IntPtr: &zeroInt}, false},
+		{"*int to non-zero", Fields{IntPtr: &number}, true},
+		{"zero int", Fields{}, false},
+		{"non-zero int", Fields{Int: number}, true},
+		{"negative int", Fields{Int: -1}, true},
+		{"nil []string", Fields{}, false},
+		{"empty []string", Fields{Strings: []string{}}, false},
+		{"[]string with empty element", Fields{Strings: []string{""}}, true},
+		{"nil *time.Time", Fields{}, false},
+		{"*time.Time to zero time", Fields{Time: &zeroTime}, false},
+		{"*time.Time to non-zero time", Fields{Time: &someTime}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			calls = nil
+			fields := c.fields
+			res := v.Validate(&fields)
+			assertTrue(t, res.IsValid(), fmt.Sprint(res.FieldErrors))
+			if c.evaluated {
+				assertEqual(t, []string{"filter", "validator"}, calls)
+			} else {
+				assertEqual(t, 0, len(calls), fmt.Sprint(calls))
+			}
+		})
+	}
+
+	// time.Time values are traversed as nested structs, their emptiness is tested directly
+	assertTrue(t, isEmptyValue(reflect.ValueOf(time.Time{})), "expected the zero time to be empty")
+	assertFalse(t, isEmptyValue(reflect.ValueOf(someTime)), "expected a non-zero time not to be empty")
+
+	// empty maps and values held by interfaces
+	type Containers struct {
+		Map   map[string]int `validator:"probe" flags:"omitempty"`
+		Any   interface{}    `validator:"probe" flags:"omitempty"`
+		Array [2]int         `validator:"probe" flags:"omitempty"`
+	}
+	calls = nil
+	assertTrue(t, v.Validate(&Containers{Map: map[string]int{}, Any: ""}).IsValid(), "Validation failed")
+	assertEqual(t, 0, len(calls), fmt.Sprint(calls))
+	assertTrue(t, v.Validate(&Containers{Map: map[string]int{"a": 0}, Any: 0.5, Array: [2]int{0, 1}}).IsValid(), "Validation failed")
+	assertEqual(t, []string{"validator", "validator", "validator"}, calls)
+
+	// empty values skip required, other values are evaluated by all validators
+	type Mixed struct {
+		Optional *string `validator:"required|min(3)" flags:"omitempty"`
+		Required *string `validator:"required|min(3)"`
+	}
+	res := v.Validate(&Mixed{Optional: &emptyString})
+	assertEqual(t, []FieldError{{Field: "Required", Message: MsgRequired, Validator: "required", Code: CodeMissing}}, res.FieldErrors)
+	short := "ab"
+	res = v.Validate(&Mixed{Optional: &short, Required: &text})
+	assertEqual(t, []FieldError{{Field: "Optional", Message: "length (ab) must be at least 3", Validator: "min"}}, res.FieldErrors)
+}
+
+func TestFiltersBeforeValidators(t *testing.T) {
+	type Form struct {
+		Name     string  `validator:"length(3,_)" filter:"trim"`
+		Nickname *string `validator:"seen|length(3,_)" filter:"trim"`
+		Referrer *string `validator:"seen|required" filter:"trim|null_if_empty"`
+	}
+
+	var seen []string
+	v := New()
+	v.AddValidator("seen", func(ctx *ValidationContext) bool {
+		if ctx.IsNull {
+			seen = append(seen, "<nil>")
+		} else {
+			seen = append(seen, ctx.GetValue().String())
+		}
+		return true
+	})
+
+	// validators see the trimmed value, including values replaced by Trim for pointers
+	nickname, referrer := "  ab  ", "   "
+	form := Form{Name: "  ab  ", Nickname: &nickname, Referrer: &referrer}
+	res := v.Validate(&form)
+	assertEqual(t, []FieldError{
+		{Field: "Name", Message: "length (2) must be at least 3", Validator: "length"},
+		{Field: "Nickname", Message: "length (2) must be at least 3", Validator: "length"},
+		{Field: "Referrer", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+	}, res.FieldErrors)
+	assertEqual(t, []string{"ab", "<nil>"}, seen)
+	assertEqual(t, "ab", form.Name)
+	assertEqual(t, "ab", *form.Nickname)
+	assertNull(t, form.Referrer)
+
+	seen = nil
+	nickname, referrer = " abc ", " jane "
+	form = Form{Name: " abc ", Nickname: &nickname, Referrer: &referrer}
+	assertTrue(t, v.Validate(&form).IsValid(), "Validation failed")
+	assertEqual(t, []string{"abc", "jane"}, seen)
+	assertEqual(t, "jane", *form.Referrer)
+
+	// previous versions validated the unfiltered value
+	legacy := New(func(opts *ValidationOptions) {
+		opts.LegacyFilterOrder = true
+	})
+	legacy.AddValidator("seen", func(ctx *ValidationContext) bool { return true })
+	nickname, referrer = "  ab  ", " jane "
+	form = Form{Name: "  ab  ", Nickname: &nickname, Referrer: &referrer}
+	assertTrue(t, legacy.Validate(&form).IsValid(), "Validation failed")
+	assertEqual(t, "ab", form.Name)
+	assertEqual(t, "ab", *form.Nickname)
+}
+
+func TestFilterErrors(t *testing.T) {
+	type Contact struct {
+		Phone  string  `validator:"length(10,_)" filter:"trim|phone"`
+		Mobile *string `filter:"phone"`
+		Fax    string  `filter:"phone|strict" label:"Fax number"`
+		Name   string  `validator:"length(3,_)"`
+	}
+
+	errNotANumber := errors.New("not a phone number")
+	setup := func(v *Validator) *Validator {
+		v.AddFilter("phone", func(ctx *ValidationContext) reflect.Value {
+			if ctx.IsNull {
+				return ctx.value
+			}
+			value := ctx.GetValue()
+			digits := strings.Map(func(r rune) rune {
+				if r >= '0' && r <= '9' {
+					return r
+				}
+				if r == ' ' || r == '-' || r == '(' || r == ')' {
+					return -1
+				}
+				return 'x'
+			}, value.String())
+			if strings.ContainsRune(digits, 'x') {
+				ctx.ErrorMessage = "invalid phone number"
+				ctx.AdditionalError = errNotANumber
+				return value
+			}
+			return reflect.ValueOf(digits).Convert(value.Type())
+		})
+		v.AddFilter("strict", func(ctx *ValidationContext) reflect.Value {
+			if ctx.GetValue().Len() < 10 {
+				ctx.AdditionalError = errNotANumber
+				return reflect.ValueOf("")
+			}
+			return ctx.value
+		})
+		return v
+	}
+
+	v := setup(New())
+	mobile := "call me"
+	contact := Contact{Phone: " 555-123 4567 ", Mobile: &mobile, Fax: "555 1234", Name: "al"}
+	res := v.Validate(&contact)
+	assertFalse(t, res.IsValid())
+	assert.Equal(t, []FieldError{
+		{Field: "Mobile", Message: "invalid phone number", Cause: errNotANumber},
+		{Field: "Fax number", Message: "Fax number: field filtering failed", Cause: errNotANumber},
+		{Field: "Name", Message: "length (2) must be at least 3", Validator: "length"},
+	}, res.FieldErrors)
+	assertTrue(t, errors.Is(res.FieldErrors[0], errNotANumber))
+
+	// successful filters apply, failing ones leave the value unmodified, undoing the preceding filters
+	assertEqual(t, "5551234567", contact.Phone)
+	assertEqual(t, "call me", *contact.Mobile)
+	assertEqual(t, "555 1234", contact.Fax)
+
+	// validators of fields with failing filters do not run
+	contact = Contact{Phone: "call me", Fax: "(555) 123-4567", Name: "alice"}
+	res = v.Validate(&contact)
+	assertEqual(t, []FieldError{{Field: "Phone", Message: "invalid phone number", Cause: errNotANumber}}, res.FieldErrors)
+	assertEqual(t, "call me", contact.Phone)
+	assertEqual(t, "5551234567", contact.Fax)
+
+	// filter errors stop validation on first error
+	stopping := setup(New(func(opts *ValidationOptions) {
+		opts.StopOnFirstError = true
+		opts.ExposeUnderlyingErrors = true
+	}))
+	contact = Contact{Phone: "5551234567", Mobile: &mobile, Fax: "x", Name: "al"}
+	res = stopping.Validate(&contact)
+	assertEqual(t, []FieldError{{Field: "Mobile", Message: "invalid phone number: not a phone number", Cause: errNotANumber}}, res.FieldErrors)
+}
+
+func TestFilterErrorsMidChain(t *testing.T) {
+	type Upload struct {
+		Payload string   `filter:"trim|b64decode|upper" validator:"length(1,_)"`
+		Parts   []string `filter:"trim|b64decode"`
+	}
+
+	v := New()
+	v.AddFilter("b64decode", func(ctx *ValidationContext) reflect.Value {
+		decoded, err := base64.StdEncoding.DecodeString(ctx.GetValue().String())
+		if err != nil {
+			ctx.AdditionalError = err
+			return ctx.GetValue()
+		}
+		return reflect.ValueOf(string(decoded))
+	})
+	upperCalls := 0
+	v.AddFilter("upper", func(ctx *ValidationContext) reflect.Value {
+		upperCalls++
+		return reflect.ValueOf(strings.ToUpper(ctx.GetValue().String()))
+	})
+
+	upload := Upload{Payload: " aGVsbG8= ", Parts: []string{" YQ== ", " not base64 ", "Yg=="}}
+	res := v.Validate(&upload)
+	assertFalse(t, res.IsValid())
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "Parts[1]", res.FieldErrors[0].Field)
+	assertEqual(t, "Parts: field filtering failed", res.FieldErrors[0].Message)
+	var corrupt base64.CorruptInputError
+	assertTrue(t, errors.As(res.FieldErrors[0], &corrupt), "expected the decoding error as cause")
+	assertEqual(t, "HELLO", upload.Payload)
+	assertEqual(t, []string{"a", " not base64 ", "b"}, upload.Parts)
+
+	// the failing filter skips the remaining filters and validators and leaves the original value, untrimmed
+	upperCalls = 0
+	upload = Upload{Payload: " not base64 "}
+	res = v.Validate(&upload)
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "Payload", res.FieldErrors[0].Field)
+	assertEqual(t, " not base64 ", upload.Payload)
+	assertEqual(t, 0, upperCalls)
+}
+
+func TestSliceElementFilters(t *testing.T) {
+	type Post struct {
+		Tags     []string  `filter:"trim|lower" validator:"length(1,3)"`
+		Aliases  []*string `filter:"trim|lower"`
+		Keywords [2]string `filter:"trim"`
+		Labels   *[]string `filter:"trim"`
+		Empty    []string  `filter:"trim"`
+	}
+
+	v := New()
+	v.AddFilter("lower", func(ctx *ValidationContext) reflect.Value {
+		ctx.ValueMustBeOfKind(reflect.String)
+		if ctx.IsNull {
+			return ctx.value
+		}
+		lowered := strings.ToLower(ctx.GetValue().String())
+		if ctx.IsPointer {
+			return reflect.ValueOf(&lowered)
+		}
+		return reflect.ValueOf(lowered)
+	})
+
+	alias := "  GoLang "
+	labels := []string{" a ", "b "}
+	post := Post{
+		Tags:     []string{"  Go ", "API  ", "\tWeb\n"},
+		Aliases:  []*string{&alias, nil},
+		Keywords: [2]string{" x", "y "},
+		Labels:   &labels,
+	}
+	tags := post.Tags
+
+	res := v.Validate(&post)
+	assertTrue(t, res.IsValid(), "Validation failed")
+	assertEqual(t, []string{"go", "api", "web"}, post.Tags)
+	// elements are written back in place
+	assertEqual(t, "go", tags[0])
+	assertEqual(t, "golang", *post.Aliases[0])
+	assertNull(t, post.Aliases[1])
+	assertEqual(t, [2]string{"x", "y"}, post.Keywords)
+	assertEqual(t, []string{"a", "b"}, labels)
+	assertNull(t, post.Empty)
+
+	// filters of elements fail for the element
+	v.AddFilter("reject_web", func(ctx *ValidationContext) reflect.Value {
+		if ctx.GetValue().String() == "web" {
+			ctx.ErrorMessage = "web is reserved"
+		}
+		return ctx.value
+	})
+	type Reserved struct {
+		Tags []string `filter:"trim|reject_web" validator:"length(5,_)"`
+	}
+	reserved := Reserved{Tags: []string{" go", " web "}}
+	res = v.Validate(&reserved)
+	assertEqual(t, []FieldError{{Field: "Tags[1]", Message: "web is reserved"}}, res.FieldErrors)
+	assertEqual(t, []string{"go", " web "}, reserved.Tags)
+
+	// filters of other kinds are still rejected
+	type Invalid struct {
+		Counts []int `filter:"trim"`
+	}
+	assert.ErrorContains(t, v.Register(Invalid{}), "filter `trim` does not support slice values")
+}
+
+func TestSkipFiltersOnError(t *testing.T) {
+	type Item struct {
+		Sku string `filter:"trim|upper"`
+	}
+	type Request struct {
+		Name  string   `filter:"trim|upper" validator:"length(3,_)"`
+		Email *string  `filter:"trim"`
+		Tags  []string `filter:"trim"`
+		Age   int      `filter:"add_one" validator:"max(10)"`
+		Items map[string]Item
+	}
+
+	setup := func(configure ...func(opts *ValidationOptions)) *Validator {
+		v := New(configure...)
+		v.AddFilter("upper", func(ctx *ValidationContext) reflect.Value {
+			return reflect.ValueOf(strings.ToUpper(ctx.GetValue().String()))
+		})
+		v.AddFilter("add_one", func(ctx *ValidationContext) reflect.Value {
+			return reflect.ValueOf(int(ctx.GetValue().Int()) + 1)
+		})
+		return v
+	}
+	newRequest := func(name string, age int) Request {
+		email := " jane@example.com "
+		return Request{Name: name, Email: &email, Tags: []string{" a "}, Age: age, Items: map[string]Item{"x": {Sku: " ab "}}}
+	}
+
+	// by default, filters apply whatever the outcome
+	request := newRequest(" ab ", 10)
+	assertFalse(t, setup().Validate(&request).IsValid())
+	assertEqual(t, "AB", request.Name)
+	assertEqual(t, 11, request.Age)
+
+	// failing fields are restored, valid ones keep their filtered value
+	perField := setup(func(opts *ValidationOptions) {
+		opts.SkipFiltersOnError = true
+	})
+	request = newRequest(" ab ", 10)
+	res := perField.Validate(&request)
+	assertEqual(t, 2, len(res.FieldErrors))
+	assertEqual(t, " ab ", request.Name)
+	assertEqual(t, 10, request.Age)
+	assertEqual(t, "jane@example.com", *request.Email)
+	assertEqual(t, []string{"a"}, request.Tags)
+	assertEqual(t, "AB", request.Items["x"].Sku)
+
+	request = newRequest(" abc ", 1)
+	assertTrue(t, perField.Validate(&request).IsValid(), "Validation failed")
+	assertEqual(t, "ABC", request.Name)
+	assertEqual(t, 2, request.Age)
+
+	// with the legacy order, filters of failing fields do not run
+	legacy := setup(func(opts *ValidationOptions) {
+		opts.SkipFiltersOnError = true
+		opts.LegacyFilterOrder = true
+		opts.StringAutoTrim = true
+	})
+	request = newRequest(" ab ", 11)
+	res = legacy.Validate(&request)
+	assertEqual(t, 2, len(res.FieldErrors))
+	assertEqual(t, " ab ", request.Name)
+	assertEqual(t, 11, request.Age)
+	assertEqual(t, "jane@example.com", *request.Email)
+
+	// the struct is left untouched when any field fails
+	all := setup(func(opts *ValidationOptions) {
+		opts.SkipAllFiltersOnError = true
+		opts.StringAutoTrim = true
+	})
+	request = newRequest(" abc ", 10)
+	email, tags := request.Email, request.Tags
+	res = all.Validate(&request)
+	assertEqual(t, []FieldError{{Field: "Age", Message: "value (11) must not exceed 10", Validator: "max"}}, res.FieldErrors)
+	assertEqual(t, newRequest(" abc ", 10), request)
+	assertTrue(t, email == request.Email, "expected the original pointer to be restored")
+	assertEqual(t, " a ", tags[0])
+
+	request = newRequest(" abc ", 1)
+	assertTrue(t, all.Validate(&request).IsValid(), "Validation failed")
+	assertEqual(t, "ABC", request.Name)
+	assertEqual(t, "AB", request.Items["x"].Sku)
+}
+
 func TestEmptyAsNull(t *testing.T) {
 	type Form struct {
 		FirstName *string `validator:"min(10)" flags:"allow_zero"`
@@ -307,3 +1348,511 @@ func TestEmptyAsNull(t *testing.T) {
 	assertNull(t, form.FirstName, "Expected null")
 	assertEqual(t, *form.LastName, "", "Expected null")
 }
+
+func TestAnonymousStructCache(t *testing.T) {
+	first := struct {
+		Name string `validator:"min(5)"`
+	}{Name: "abc"}
+
+	second := struct {
+		Name string `validator:"max(2)"`
+	}{Name: "abc"}
+
+	res := Validate(&first)
+	assertFalse(t, res.IsValid(), "expected min(5) to fail")
+	assertTrue(t, strings.Contains(res.FieldErrors[0].Message, "at least"), res.FieldErrors[0].Message)
+
+	res = Validate(&second)
+	assertFalse(t, res.IsValid(), "expected max(2) to fail")
+	assertTrue(t, strings.Contains(res.FieldErrors[0].Message, "must not exceed"), res.FieldErrors[0].Message)
+}
+
+func TestLength(t *testing.T) {
+	type Form struct {
+		Name  string         `validator:"length(2,5)"`
+		Bio   *string        `validator:"length(_,10)"`
+		Tags  []string       `validator:"length(1,_)"`
+		Attrs map[string]int `validator:"length(_,1)"`
+	}
+
+	form := Form{Name: "héllo", Tags: []string{"a"}, Attrs: map[string]int{"a": 1}}
+	res := Validate(&form)
+	assertTrue(t, res.IsValid(), "validation failed")
+
+	bio := "this bio is far too long"
+	form = Form{Name: "h", Bio: &bio, Attrs: map[string]int{"a": 1, "b": 2}}
+	res = Validate(&form)
+	assertFalse(t, res.IsValid(), "expected validation to fail")
+	assertEqual(t, 4, len(res.FieldErrors))
+	assertEqual(t, "length (1) must be at least 2", res.FieldErrors[0].Message)
+	assertEqual(t, "length (24) must not exceed 10", res.FieldErrors[1].Message)
+	assertEqual(t, "length (0) must be at least 1", res.FieldErrors[2].Message)
+	assertEqual(t, "length (2) must not exceed 1", res.FieldErrors[3].Message)
+}
+
+func TestLegacyMinMaxStringLength(t *testing.T) {
+	type Form struct {
+		Name string `validator:"min(3)|max(5)"`
+	}
+
+	res := Validate(&Form{Name: "ab"})
+	assertFalse(t, res.IsValid(), "expected legacy string length check to fail")
+
+	SetupOptions(func(opts *ValidationOptions) {
+		opts.LegacyMinMaxStringLength = false
+	})
+	defer SetupOptions(func(opts *ValidationOptions) {
+		opts.LegacyMinMaxStringLength = true
+	})
+
+	assert.Panics(t, func() {
+		Validate(&Form{Name: "ab"})
+	})
+}
+
+func TestCacheInvalidatedOnSetupOptions(t *testing.T) {
+	type Form struct {
+		Name *string `filter:"trim" sanitize:"null_if_empty"`
+	}
+
+	name := ""
+	form := Form{Name: &name}
+	Validate(&form)
+	assertEqual(t, "", *form.Name)
+
+	SetupOptions(func(opts *ValidationOptions) {
+		opts.FilterTagName = "sanitize"
+	})
+	defer SetupOptions(func(opts *ValidationOptions) {
+		opts.FilterTagName = "filter"
+	})
+
+	Validate(&form)
+	assertNull(t, form.Name, "expected the sanitize tag to be honored")
+}
+
+func TestStringLengthInRunes(t *testing.T) {
+	type Form struct {
+		Latin string `validator:"min(5)|max(5)"`
+		CJK   string `validator:"length(3,3)"`
+		Emoji string `validator:"length(_,1)"`
+		// a family emoji is a grapheme cluster made of 5 runes (3 people joined by 2 zero width joiners)
+		Family string `validator:"length(5,5)"`
+		Column string `validator:"length(_,5,bytes)"`
+	}
+
+	form := Form{Latin: "héllo", CJK: "山田太", Emoji: "😀", Family: "👨‍👩‍👧", Column: "héllo"}
+	res := Validate(&form)
+	assertFalse(t, res.IsValid(), "expected the byte based length check to fail")
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "Column", res.FieldErrors[0].Field)
+
+	SetupOptions(func(opts *ValidationOptions) {
+		opts.LengthInBytes = true
+	})
+	defer SetupOptions(func(opts *ValidationOptions) {
+		opts.LengthInBytes = false
+	})
+
+	res = Validate(&form)
+	assertEqual(t, 5, len(res.FieldErrors))
+}
+
+func TestMinMaxIntegerKinds(t *testing.T) {
+	type Levels struct {
+		Int    int    `validator:"min(2)|max(5)"`
+		Int8   int8   `validator:"min(2)|max(5)"`
+		Int16  int16  `validator:"min(2)|max(5)"`
+		Int32  int32  `validator:"min(2)|max(5)"`
+		Int64  int64  `validator:"min(2)|max(5)"`
+		Uint   uint   `validator:"min(2)|max(5)"`
+		Uint8  uint8  `validator:"min(2)|max(5)"`
+		Uint16 uint16 `validator:"min(2)|max(5)"`
+		Uint32 uint32 `validator:"min(2)|max(5)"`
+		Uint64 uint64 `validator:"min(2)|max(5)"`
+	}
+
+	valid := Levels{5, 5, 5, 5, 5, 2, 2, 2, 2, 2}
+	res := Validate(&valid)
+	assertTrue(t, res.IsValid(), "validation failed")
+
+	tooSmall := Levels{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+	res = Validate(&tooSmall)
+	assertEqual(t, 10, len(res.FieldErrors))
+	for _, fe := range res.FieldErrors {
+		assertEqual(t, "value (1) must be at least 2", fe.Message, fe.Field)
+	}
+
+	tooLarge := Levels{6, 6, 6, 6, 6, 6, 6, 6, 6, 6}
+	res = Validate(&tooLarge)
+	assertEqual(t, 10, len(res.FieldErrors))
+	for _, fe := range res.FieldErrors {
+		assertEqual(t, "value (6) must not exceed 5", fe.Message, fe.Field)
+	}
+}
+
+func TestMinMaxItems(t *testing.T) {
+	type Order struct {
+		Items    []string       `validator:"required|min(1)|max(3)"`
+		Labels   map[string]int `validator:"min(1)|max(2)"`
+		Codes    [3]byte        `validator:"min(3)|max(3)"`
+		Coupons  *[]string      `validator:"min(1)"`
+		Notes    []string       `validator:"max(2)"`
+		Quantity int8           `validator:"min(-5)|max(100)"`
+		Rating   uint8          `validator:"min(1)|max(200)"`
+	}
+
+	order := Order{Items: []string{"a", "b", "c"}, Labels: map[string]int{"gift": 1}, Quantity: -5, Rating: 200}
+	assertTrue(t, Validate(&order).IsValid(), "expected item counts within bounds to pass")
+
+	coupons := []string{}
+	order = Order{
+		Items:    []string{"a", "b", "c", "d"},
+		Labels:   map[string]int{},
+		Coupons:  &coupons,
+		Quantity: -6,
+		Rating:   201,
+	}
+	res := Validate(&order)
+	assertEqual(t, []FieldError{
+		{Field: "Items", Message: "must contain at most 3 items", Validator: "max"},
+		{Field: "Labels", Message: "must contain at least 1 items", Validator: "min"},
+		{Field: "Coupons", Message: "must contain at least 1 items", Validator: "min"},
+		{Field: "Quantity", Message: "value (-6) must be at least -5", Validator: "min"},
+		{Field: "Rating", Message: "value (201) must not exceed 200", Validator: "max"},
+	}, res.FieldErrors)
+
+	// nil slices and maps contain no items, nil pointers pass
+	res = Validate(&Order{Rating: 1})
+	assertEqual(t, []FieldError{
+		{Field: "Items", Message: "must contain at least 1 items", Validator: "min"},
+		{Field: "Labels", Message: "must contain at least 1 items", Validator: "min"},
+	}, res.FieldErrors)
+}
+
+func TestNestedStructField(t *testing.T) {
+	type Address struct {
+		City string `validator:"min(3)" filter:"trim"`
+	}
+	type Person struct {
+		Address Address
+		Name    string `validator:"min(3)"`
+	}
+
+	person := Person{Address: Address{City: " NYC "}, Name: "Bames"}
+	res := Validate(&person)
+	assertTrue(t, res.IsValid(), "validation failed")
+	assertEqual(t, "NYC", person.Address.City)
+}
+
+func BenchmarkValidate(b *testing.B) {
+	type Request struct {
+		F1  string  `validator:"min(1)|max(50)"`
+		F2  string  `validator:"min(1)|max(50)"`
+		F3  string  `validator:"min(1)|max(50)"`
+		F4  string  `validator:"min(1)|max(50)"`
+		F5  string  `validator:"min(1)|max(50)"`
+		F6  int     `validator:"min(1)|max(50)"`
+		F7  int     `validator:"min(1)|max(50)"`
+		F8  int     `validator:"min(1)|max(50)"`
+		F9  int     `validator:"min(1)|max(50)"`
+		F10 int     `validator:"min(1)|max(50)"`
+		F11 *string `validator:"required|min(1)"`
+		F12 *string `validator:"required|min(1)"`
+		F13 *string `validator:"required|min(1)"`
+		F14 *string `validator:"required|min(1)"`
+		F15 *string `validator:"required|min(1)"`
+		F16 uint    `validator:"enum(1,2,3)"`
+		F17 uint    `validator:"enum(1,2,3)"`
+		F18 uint    `validator:"enum(1,2,3)"`
+		F19 uint    `validator:"enum(1,2,3)"`
+		F20 uint    `validator:"enum(1,2,3)"`
+	}
+
+	s := "value"
+	request := Request{
+		F1: s, F2: s, F3: s, F4: s, F5: s,
+		F6: 1, F7: 2, F8: 3, F9: 4, F10: 5,
+		F11: &s, F12: &s, F13: &s, F14: &s, F15: &s,
+		F16: 1, F17: 2, F18: 3, F19: 1, F20: 2,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Validate(&request)
+	}
+}
+
+func TestValidateWithOptions(t *testing.T) {
+	type Form struct {
+		Status string `validator:"enum(on,off)"`
+		Level  int    `validator:"max(5)"`
+		Code   string `check:"enum(a,b)"`
+	}
+
+	form := Form{Status: "unknown", Level: 6, Code: "c"}
+
+	var opts ValidationOptions
+	CopyOptions(&opts)
+	opts.ExposeEnumValues = false
+	opts.StopOnFirstError = true
+
+	res := ValidateWithOptions(&form, opts)
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "invalid value specified", res.FieldErrors[0].Message)
+
+	res = Validate(&form)
+	assertEqual(t, 2, len(res.FieldErrors))
+	assertEqual(t, "invalid value specified. expected any of on,off", res.FieldErrors[0].Message)
+
+	opts.ValidatorTagName = "check"
+	opts.StopOnFirstError = false
+	res = ValidateWithOptions(&form, opts)
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "Code", res.FieldErrors[0].Field)
+}
+
+func TestEmptyDates(t *testing.T) {
+	type Form struct {
+		Date string `validator:"before_today"`
+	}
+	type PointerForm struct {
+		Date *string `validator:"before_today"`
+	}
+
+	assertTrue(t, Validate(&Form{Date: ""}).IsValid(), "expected empty date to pass")
+	assertTrue(t, Validate(&Form{Date: "  "}).IsValid(), "expected blank date to pass")
+	assertTrue(t, Validate(&PointerForm{}).IsValid(), "expected nil date to pass")
+
+	res := Validate(&Form{Date: "yesterday"})
+	assertFalse(t, res.IsValid(), "expected invalid date to fail")
+	assertEqual(t, "invalid date format. expected format is 2006-01-02", res.FieldErrors[0].Message)
+
+	var opts ValidationOptions
+	CopyOptions(&opts)
+	opts.RejectEmptyDates = true
+
+	res = ValidateWithOptions(&Form{Date: "  "}, opts)
+	assertFalse(t, res.IsValid(), "expected blank date to fail in strict mode")
+	assertEqual(t, "invalid date format. expected format is 2006-01-02", res.FieldErrors[0].Message)
+	assertTrue(t, ValidateWithOptions(&PointerForm{}, opts).IsValid(), "expected nil date to pass in strict mode")
+}
+
+func TestTagErrorInResult(t *testing.T) {
+	type Form struct {
+		Name string `validator:"required|requird"`
+	}
+
+	assert.Panics(t, func() {
+		Validate(&Form{})
+	})
+
+	var opts ValidationOptions
+	CopyOptions(&opts)
+	opts.PanicOnTagError = false
+
+	res := ValidateWithOptions(&Form{}, opts)
+	assertFalse(t, res.IsValid(), "expected tag error")
+	assertEqual(t, 0, len(res.FieldErrors))
+	assertEqual(t, "struct validator.Form, field Name, rule `requird`: validator `requird` not found", res.Error.Error())
+
+	type Order struct {
+		Items []struct {
+			Sku string `filter:"trimm"`
+		}
+		Name string `validator:"length(1,_)"`
+	}
+
+	res = ValidateWithOptions(&Order{Items: make([]struct {
+		Sku string `filter:"trimm"`
+	}, 1)}, opts)
+	assertFalse(t, res.IsValid(), "expected tag error")
+	assertEqual(t, 0, len(res.FieldErrors))
+	assertTrue(t, strings.Contains(res.Error.Error(), "filter `trimm` not found"), res.Error.Error())
+}
+
+func TestReadOnlyStructValue(t *testing.T) {
+	type Plain struct {
+		Name string `validator:"length(3,_)"`
+	}
+
+	assertTrue(t, Validate(Plain{Name: "John"}).IsValid(), "validation failed")
+
+	res := Validate(Plain{Name: "Jo"})
+	assertFalse(t, res.IsValid(), "expected validation to fail")
+	assertNull(t, res.Error)
+	assertEqual(t, 1, len(res.FieldErrors))
+
+	type Filtered struct {
+		Age  int    `validator:"min(18)"`
+		Name string `validator:"length(3,_)" filter:"trim"`
+	}
+
+	form := Filtered{Age: 10, Name: "  John  "}
+	res = Validate(form)
+	assertFalse(t, res.IsValid(), "expected validation to fail")
+	assertEqual(t, "field Name has filters which require a struct pointer", res.Error.Error())
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "  John  ", form.Name)
+
+	// fields needing no trimming are validated as usual
+	trimming := New(func(opts *ValidationOptions) {
+		opts.StringAutoTrim = true
+	})
+	type Trimmed struct {
+		Age      int     `validator:"min(18)"`
+		Name     string  `validator:"length(3,_)"`
+		Nickname *string `validator:"length(3,_)"`
+		Note     string
+	}
+	nickname := " Jo"
+	assertTrue(t, trimming.Validate(Trimmed{Age: 20, Name: "John", Note: " as is "}).IsValid(), "validation failed")
+
+	res = trimming.Validate(Trimmed{Age: 10, Name: "John", Nickname: &nickname})
+	assertFalse(t, res.IsValid(), "expected validation to fail")
+	assertEqual(t, "field Nickname must be trimmed, which requires a struct pointer", res.Error.Error())
+	assertEqual(t, []FieldError{{Field: "Age", Message: "value (10) must be at least 18", Validator: "min"}}, res.FieldErrors)
+	assertEqual(t, " Jo", nickname)
+
+	res = Validate(10)
+	assertEqual(t, "Invalid input type. Expected struct pointer but found int", res.Error.Error())
+}
+
+func TestFixedSizeArrays(t *testing.T) {
+	type Record struct {
+		Id       [16]byte  `validator:"required|uuid_bytes"`
+		ParentId uuid.UUID `validator:"uuid_bytes(nonzero)"`
+		Owner    *[16]byte `validator:"uuid_bytes(nonzero)"`
+		Hash     [32]byte  `validator:"nonzero|length(32,32)"`
+	}
+
+	var record Record
+	res := Validate(&record)
+	assertFalse(t, res.IsValid(), "expected zero arrays to fail")
+	assertEqual(t, []FieldError{
+		{Field: "Id", Message: MsgEmpty, Validator: "required", Code: CodeEmpty},
+		{Field: "ParentId", Message: "uuid must not be the nil uuid", Validator: "uuid_bytes"},
+		{Field: "Hash", Message: "value must not be zero", Validator: "nonzero", Code: CodeEmpty},
+	}, res.FieldErrors)
+
+	id := uuid.New()
+	record = Record{Id: id, ParentId: uuid.New(), Owner: (*[16]byte)(&id), Hash: sha256.Sum256([]byte("content"))}
+	res = Validate(&record)
+	assertTrue(t, res.IsValid(), "expected populated arrays to pass")
+
+	type Checksum struct {
+		Value [32]byte `validator:"uuid_bytes"`
+	}
+	assert.Panics(t, func() {
+		Validate(&Checksum{})
+	})
+
+	// min and max count the items of arrays
+	type Counter struct {
+		Value [4]byte `validator:"min(1)|max(3)"`
+	}
+	assertEqual(t, 0, len(CheckStruct(Counter{})))
+	res = Validate(&Counter{})
+	assertEqual(t, []FieldError{{Field: "Value", Message: "must contain at most 3 items", Validator: "max"}}, res.FieldErrors)
+}
+
+type emailAddress string
+type quantity int
+
+func TestConvertibleValues(t *testing.T) {
+	var converted []interface{}
+
+	v := New()
+	v.AddValidator("convertible", func(ctx *ValidationContext) bool {
+		for _, target := range []interface{}{"", int64(0), time.Time{}} {
+			if value, ok := ctx.ConvertValueTo(target); ok {
+				converted = append(converted, value.Interface())
+				return true
+			}
+		}
+		return ctx.IsNull
+	})
+
+	type Form struct {
+		Email    emailAddress  `validator:"convertible"`
+		Quantity quantity      `validator:"convertible"`
+		Created  *birthDate    `validator:"convertible"`
+		Missing  *emailAddress `validator:"convertible"`
+		Tags     []string      `validator:"convertible"`
+	}
+
+	created := birthDate(time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC))
+	res := v.Validate(&Form{Email: "john@example.com", Quantity: 3, Created: &created})
+
+	assertEqual(t, []interface{}{"john@example.com", int64(3), time.Time(created)}, converted)
+	assertEqual(t, []FieldError{{Field: "Tags", Message: "Tags: field validation failed", Validator: "convertible"}}, res.FieldErrors)
+}
+
+func TestRequirementCodes(t *testing.T) {
+	blank, zero := "", 0
+	tests := []struct {
+		kind  string
+		value interface{}
+		// codes reported by required, required_if and nonzero, empty if the value passes
+		required, requiredIf, nonZero string
+	}{
+		{kind: "nil pointer", value: (*string)(nil), required: CodeMissing, requiredIf: CodeMissing, nonZero: CodeMissing},
+		{kind: "pointer to blank string", value: &blank, requiredIf: CodeEmpty, nonZero: CodeEmpty},
+		{kind: "pointer to zero", value: &zero, requiredIf: CodeEmpty, nonZero: CodeEmpty},
+		{kind: "blank string", value: "", requiredIf: CodeEmpty, nonZero: CodeEmpty},
+		{kind: "whitespace string", value: " "},
+		{kind: "string", value: "x"},
+		{kind: "zero", value: 0, requiredIf: CodeEmpty, nonZero: CodeEmpty},
+		{kind: "number", value: 1},
+		{kind: "false", value: false, requiredIf: CodeEmpty, nonZero: CodeEmpty},
+		{kind: "nil slice", value: []string(nil), requiredIf: CodeMissing, nonZero: CodeMissing},
+		{kind: "empty slice", value: []string{}},
+		{kind: "nil map", value: map[string]int(nil), requiredIf: CodeMissing, nonZero: CodeMissing},
+		{kind: "zero array", value: [2]byte{}, required: CodeEmpty, requiredIf: CodeEmpty, nonZero: CodeEmpty},
+		{kind: "array", value: [2]byte{1}},
+	}
+
+	v := New()
+	for _, test := range tests {
+		valueType := reflect.TypeOf(test.value)
+		for validator, code := range map[string]string{"required": test.required, "required_if(When,x)": test.requiredIf, "nonzero": test.nonZero} {
+			structType := reflect.StructOf([]reflect.StructField{
+				{Name: "When", Type: reflect.TypeOf("")},
+				{Name: "Value", Type: valueType, Tag: reflect.StructTag(`validator:"` + validator + `"`)},
+			})
+			s := reflect.New(structType)
+			s.Elem().Field(0).SetString("x")
+			s.Elem().Field(1).Set(reflect.ValueOf(test.value))
+
+			res := v.Validate(s.Interface())
+			msg := test.kind + " checked by " + validator
+			assertNull(t, res.Error, msg)
+			if code == "" {
+				assertEqual(t, 0, len(res.FieldErrors), msg)
+				continue
+			}
+			assertEqual(t, 1, len(res.FieldErrors), msg)
+			assertEqual(t, code, res.FieldErrors[0].Code, msg)
+
+			expected := MsgEmpty
+			if code == CodeMissing {
+				expected = MsgRequired
+			}
+			if validator == "nonzero" {
+				expected = MsgNonZero
+			}
+			assertEqual(t, expected, res.FieldErrors[0].Message, msg)
+		}
+	}
+
+	// absent and null keys of maps are missing
+	res := v.ValidateMap(map[string]interface{}{"null": nil, "blank": ""}, map[string]string{
+		"absent": "required", "null": "required", "blank": "required|nonzero",
+	})
+	assertEqual(t, []FieldError{
+		{Field: "absent", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+		{Field: "blank", Message: MsgNonZero, Validator: "nonzero", Code: CodeEmpty},
+		{Field: "null", Message: MsgRequired, Validator: "required", Code: CodeMissing},
+	}, res.FieldErrors)
+}
@@ -0,0 +1,159 @@
+// Package validators exposes the packaged validation functions under their tag names, allowing them to be composed
+// programmatically, e.g. calling validators.Email(ctx) from a custom validator, or registered under other names.
+//
+// Each function behaves exactly like the validator registered under the same tag name and reads its arguments from
+// ValidationContext.Args.
+package validators
+
+import validator "github.com/SharkFourSix/go-struct-validator"
+
+// Required Required tests that pointers are not nil and fixed-size arrays are not all zero (required)
+func Required(ctx *validator.ValidationContext) bool { return validator.IsRequired(ctx) }
+
+// AlphaNumeric AlphaNumeric tests that strings only contain letters and digits (alphanum)
+func AlphaNumeric(ctx *validator.ValidationContext) bool { return validator.IsAlphaNumeric(ctx) }
+
+// Uuid1 Uuid1 tests that strings are version 1 UUIDs (uuid1)
+func Uuid1(ctx *validator.ValidationContext) bool { return validator.IsUuid1(ctx) }
+
+// Uuid2 Uuid2 tests that strings are version 2 UUIDs (uuid2)
+func Uuid2(ctx *validator.ValidationContext) bool { return validator.IsUuid2(ctx) }
+
+// Uuid3 Uuid3 tests that strings are version 3 UUIDs (uuid3)
+func Uuid3(ctx *validator.ValidationContext) bool { return validator.IsUuid3(ctx) }
+
+// Uuid4 Uuid4 tests that strings are version 4 UUIDs (uuid4)
+func Uuid4(ctx *validator.ValidationContext) bool { return validator.IsUuid4(ctx) }
+
+// UuidBytes UuidBytes tests [16]byte UUIDs, rejecting the nil UUID with the 'nonzero' argument (uuid_bytes)
+func UuidBytes(ctx *validator.ValidationContext) bool { return validator.IsUuidBytes(ctx) }
+
+// UuidCanonical UuidCanonical tests that strings are UUIDs in lowercase canonical form (uuid_canonical)
+func UuidCanonical(ctx *validator.ValidationContext) bool { return validator.IsUuidCanonical(ctx) }
+
+// NonZero NonZero tests that values are not the zero value of their type (nonzero)
+func NonZero(ctx *validator.ValidationContext) bool { return validator.IsNonZero(ctx) }
+
+// Min Min tests the minimum value of numbers or length of strings (min)
+func Min(ctx *validator.ValidationContext) bool { return validator.IsMin(ctx) }
+
+// Max Max tests the maximum value of numbers or length of strings (max)
+func Max(ctx *validator.ValidationContext) bool { return validator.IsMax(ctx) }
+
+// Length Length tests the length of strings against a range (length)
+func Length(ctx *validator.ValidationContext) bool { return validator.IsLength(ctx) }
+
+// Enum Enum tests that values are any of the arguments (enum)
+func Enum(ctx *validator.ValidationContext) bool { return validator.IsEnum(ctx) }
+
+// EnumField EnumField tests that values are among the elements of the named sibling slice field (enum_field)
+func EnumField(ctx *validator.ValidationContext) bool { return validator.IsEnumField(ctx) }
+
+// Pattern Pattern tests that strings match the named pattern registered with Validator.RegisterPattern (pattern)
+func Pattern(ctx *validator.ValidationContext) bool { return validator.IsPattern(ctx) }
+
+// Email Email tests that strings are email addresses (email)
+func Email(ctx *validator.ValidationContext) bool { return validator.IsEmail(ctx) }
+
+// Resolvable Resolvable tests that the host of URLs or host names can be resolved, performing I/O (resolvable)
+func Resolvable(ctx *validator.ValidationContext) bool { return validator.IsResolvable(ctx) }
+
+// WebhookURL WebhookURL tests that URLs are https URLs not targeting private addresses (webhook_url)
+func WebhookURL(ctx *validator.ValidationContext) bool { return validator.IsWebhookURL(ctx) }
+
+// AtLeastToday AtLeastToday tests that dates are today or before today (at_least_today)
+func AtLeastToday(ctx *validator.ValidationContext) bool { return validator.IsOrBeforeToday(ctx) }
+
+// AtMostToday AtMostToday tests that dates are today or after today (at_most_today)
+func AtMostToday(ctx *validator.ValidationContext) bool { return validator.IsOrAfterToday(ctx) }
+
+// Today Today tests that dates are today (today)
+func Today(ctx *validator.ValidationContext) bool { return validator.IsToday(ctx) }
+
+// BeforeToday BeforeToday tests that dates are before today (before_today)
+func BeforeToday(ctx *validator.ValidationContext) bool { return validator.IsBeforeToday(ctx) }
+
+// AfterToday AfterToday tests that dates are after today (after_today)
+func AfterToday(ctx *validator.ValidationContext) bool { return validator.IsAfterToday(ctx) }
+
+// BetweenDates BetweenDates tests that dates fall within a range (between_dates)
+func BetweenDates(ctx *validator.ValidationContext) bool { return validator.IsBetweenDates(ctx) }
+
+// AgeBetween AgeBetween tests that the age implied by a date of birth is within bounds (age_between)
+func AgeBetween(ctx *validator.ValidationContext) bool { return validator.IsAgeBetween(ctx) }
+
+// DateOfBirth DateOfBirth tests that dates are plausible dates of birth (dob)
+func DateOfBirth(ctx *validator.ValidationContext) bool { return validator.IsDateOfBirth(ctx) }
+
+// EqualToField EqualToField tests that values equal the named sibling field (eqfield)
+func EqualToField(ctx *validator.ValidationContext) bool { return validator.IsEqualToField(ctx) }
+
+// NotEqualToField NotEqualToField tests that values differ from the named sibling field (nefield)
+func NotEqualToField(ctx *validator.ValidationContext) bool { return validator.IsNotEqualToField(ctx) }
+
+// GreaterThanField GreaterThanField tests that values are greater than the named sibling field (gtfield)
+func GreaterThanField(ctx *validator.ValidationContext) bool {
+	return validator.IsGreaterThanField(ctx)
+}
+
+// GreaterThanOrEqualToField GreaterThanOrEqualToField tests that values are greater than or equal to the named
+// sibling field (gtefield)
+func GreaterThanOrEqualToField(ctx *validator.ValidationContext) bool {
+	return validator.IsGreaterThanOrEqualToField(ctx)
+}
+
+// LessThanField LessThanField tests that values are less than the named sibling field (ltfield)
+func LessThanField(ctx *validator.ValidationContext) bool { return validator.IsLessThanField(ctx) }
+
+// LessThanOrEqualToField LessThanOrEqualToField tests that values are less than or equal to the named sibling
+// field (ltefield)
+func LessThanOrEqualToField(ctx *validator.ValidationContext) bool {
+	return validator.IsLessThanOrEqualToField(ctx)
+}
+
+// RequiredIf RequiredIf requires values when the named sibling field has any of the given values (required_if)
+func RequiredIf(ctx *validator.ValidationContext) bool { return validator.IsRequiredIf(ctx) }
+
+// RequiredUnless RequiredUnless requires values unless the named sibling field has any of the given values
+// (required_unless)
+func RequiredUnless(ctx *validator.ValidationContext) bool { return validator.IsRequiredUnless(ctx) }
+
+// All All returns the functions of this package keyed by their tag names. The map is a new copy on every call.
+func All() map[string]validator.ValidationFunction {
+	return map[string]validator.ValidationFunction{
+		"required":        Required,
+		"alphanum":        AlphaNumeric,
+		"uuid1":           Uuid1,
+		"uuid2":           Uuid2,
+		"uuid3":           Uuid3,
+		"uuid4":           Uuid4,
+		"uuid_bytes":      UuidBytes,
+		"uuid_canonical":  UuidCanonical,
+		"nonzero":         NonZero,
+		"min":             Min,
+		"max":             Max,
+		"length":          Length,
+		"enum":            Enum,
+		"enum_field":      EnumField,
+		"pattern":         Pattern,
+		"email":           Email,
+		"resolvable":      Resolvable,
+		"webhook_url":     WebhookURL,
+		"at_least_today":  AtLeastToday,
+		"at_most_today":   AtMostToday,
+		"today":           Today,
+		"before_today":    BeforeToday,
+		"after_today":     AfterToday,
+		"between_dates":   BetweenDates,
+		"age_between":     AgeBetween,
+		"dob":             DateOfBirth,
+		"eqfield":         EqualToField,
+		"nefield":         NotEqualToField,
+		"gtfield":         GreaterThanField,
+		"gtefield":        GreaterThanOrEqualToField,
+		"ltfield":         LessThanField,
+		"ltefield":        LessThanOrEqualToField,
+		"required_if":     RequiredIf,
+		"required_unless": RequiredUnless,
+	}
+}
@@ -0,0 +1,142 @@
+package validators
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	validator "github.com/SharkFourSix/go-struct-validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type sample struct {
+	Name      *string  `validator:"required|alphanum|length(3,8)"`
+	Id        string   `validator:"uuid4|uuid_canonical"`
+	Key       [16]byte `validator:"uuid_bytes(nonzero)"`
+	Age       int      `validator:"nonzero|min(18)|max(65)"`
+	Role      string   `validator:"enum(admin,user)"`
+	Roles     []string
+	Selected  string  `validator:"enum_field(Roles)"`
+	Sku       string  `validator:"pattern(sku)"`
+	Email     *string `validator:"email"`
+	Webhook   string  `validator:"resolvable|webhook_url"`
+	Past      string  `validator:"at_least_today"`
+	Future    string  `validator:"after_today"`
+	Period    string  `validator:"between_dates(2024-01-01,2024-12-31)"`
+	Born      string  `validator:"dob|age_between(18,65)"`
+	Password  string  `validator:"eqfield(Confirm)|nefield(Role)"`
+	Confirm   string
+	Low       int     `validator:"ltfield(High)|ltefield(High)"`
+	High      int     `validator:"gtfield(Low)|gtefield(Low)"`
+	Company   *string `validator:"required_if(Role,admin)"`
+	Reference *string `validator:"required_unless(Role,user)"`
+}
+
+// staticResolver resolves example.com only
+type staticResolver struct{}
+
+func (r *staticResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if host == "example.com" {
+		return []string{"192.0.2.1"}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestAll(t *testing.T) {
+	wrapped := validator.New(func(opts *validator.ValidationOptions) {
+		opts.NoPanicOnFunctionConflict = true
+		opts.Resolver = &staticResolver{}
+	})
+	for name, fn := range All() {
+		wrapped.AddValidator(name, fn)
+	}
+	packaged := validator.New(func(opts *validator.ValidationOptions) {
+		opts.Resolver = &staticResolver{}
+	})
+	for _, v := range []*validator.Validator{wrapped, packaged} {
+		assert.NoError(t, v.RegisterPattern("sku", `^[A-Z]{3}-\d{6}$`))
+	}
+
+	name, email, company := "jane", "jane@example.com", "acme"
+	today := time.Now()
+
+	samples := []sample{
+		{},
+		{
+			Name:      &name,
+			Id:        "9b5d3d2e-54c4-4b8a-9fb4-6a1b2c3d4e5f",
+			Key:       [16]byte{1},
+			Age:       30,
+			Role:      "admin",
+			Roles:     []string{"admin", "user"},
+			Selected:  "user",
+			Sku:       "ABC-123456",
+			Email:     &email,
+			Webhook:   "https://example.com/hook",
+			Past:      today.AddDate(0, 0, -1).Format("2006-01-02"),
+			Future:    today.AddDate(0, 0, 1).Format("2006-01-02"),
+			Period:    "2024-06-01",
+			Born:      today.AddDate(-30, 0, 0).Format("2006-01-02"),
+			Password:  "secret",
+			Confirm:   "secret",
+			Low:       1,
+			High:      2,
+			Company:   &company,
+			Reference: &company,
+		},
+		{
+			Name:     &email,
+			Id:       "not a uuid",
+			Age:      99,
+			Role:     "guest",
+			Selected: "guest",
+			Sku:      "abc",
+			Email:    &name,
+			Webhook:  "http://unknown.example.com:8080/hook",
+			Past:     today.AddDate(0, 0, 1).Format("2006-01-02"),
+			Future:   today.AddDate(0, 0, -1).Format("2006-01-02"),
+			Period:   "2025-06-01",
+			Born:     today.AddDate(1, 0, 0).Format("2006-01-02"),
+			Password: "secret",
+			Confirm:  "terces",
+			Low:      2,
+			High:     1,
+		},
+	}
+
+	for i := range samples {
+		a, b := samples[i], samples[i]
+		expected := packaged.Validate(&a)
+		actual := wrapped.Validate(&b)
+		assert.Equal(t, expected.FieldErrors, actual.FieldErrors, "sample %d", i)
+		assert.Equal(t, expected.Error, actual.Error, "sample %d", i)
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	// composing a packaged validator from a custom validator
+	v := validator.New()
+	v.AddValidator("work_email", func(ctx *validator.ValidationContext) bool {
+		if !Email(ctx) {
+			return false
+		}
+		if ctx.GetValue().String() == "jane@example.com" {
+			ctx.ErrorMessage = "private address"
+			return false
+		}
+		return true
+	})
+
+	type form struct {
+		Email string `validator:"work_email"`
+	}
+
+	assert.True(t, v.Validate(&form{Email: "jane@acme.com"}).IsValid())
+	res := v.Validate(&form{Email: "jane@example.com"})
+	assert.False(t, res.IsValid())
+	assert.Equal(t, "private address", res.FieldErrors[0].Message)
+	res = v.Validate(&form{Email: "jane"})
+	assert.False(t, res.IsValid())
+	assert.Equal(t, "Email: field validation failed", res.FieldErrors[0].Message)
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DefaultVarName the name of the value reported in field errors by ValidateVar
+const DefaultVarName = "value"
+
+// ValidateVar ValidateVar validates a single value, such as a query parameter or a command line flag, against the
+// given validators using this instance, e.g.
+//
+//	res := v.ValidateVar(email, "required|email|max(254)")
+//
+// Rules use the syntax of validator tags, including rule sets. Pointers are resolved and nil values, including a nil
+// interface, are treated like nil pointer fields: IsNull is set for validators. Field errors name the value "value",
+// see ValidateVarNamed.
+//
+// Filters are not supported, as the value cannot be modified. Invalid rules, such as unknown validators or filters,
+// set ValidationResult.Error rather than causing a panic. Parsed rules are cached per rules, name and type of value,
+// like struct tags, so rules should not be built from input.
+func (v *Validator) ValidateVar(value interface{}, rules string) *ValidationResult {
+	return v.ValidateVarNamed(value, rules, DefaultVarName)
+}
+
+// ValidateVarNamed ValidateVarNamed validates a single value like ValidateVar, naming the value in field errors
+// with the given name, e.g. "page" for a query parameter.
+//
+// Each combination of rules, name and type of value is validated as a struct type built once with reflect.StructOf
+// and never released, so names, like rules, should not be built from input.
+func (v *Validator) ValidateVarNamed(value interface{}, rules string, name string) *ValidationResult {
+	valueType := reflect.TypeOf(value)
+	if valueType == nil {
+		valueType = emptyInterfaceType
+	}
+	return v.validateVar(value, valueType, rules, name, nil)
+}
+
+// emptyInterfaceType the type of interface{} values
+var emptyInterfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// validateVar validates the given value, stored in a field of the given type, see ValidateVarNamed
+func (v *Validator) validateVar(value interface{}, valueType reflect.Type, rules string, name string, trigger []string) *ValidationResult {
+	opts := v.currentOptions()
+	if err := opts.Check(); err != nil {
+		return &ValidationResult{Error: err.(*ValidationError)}
+	}
+
+	structType := v.varStructType(valueType, rules, name, &opts)
+
+	// rules are parsed up front unless already cached, so that problems are reported rather than raised
+	if _, cached := v.cache.Get(structType, &opts, v.generation.Load()); !cached {
+		if err := v.checkVarRules(structType, rules, &opts); err != nil {
+			return &ValidationResult{Error: err}
+		}
+	}
+
+	structPtr := reflect.New(structType)
+	if value != nil {
+		structPtr.Elem().Field(0).Set(reflect.ValueOf(value))
+	}
+	// the value is a copy, so automatic trimming could not take effect
+	opts.StringAutoTrim = false
+	return v.validate(context.Background(), structPtr.Interface(), &opts, trigger, nil)
+}
+
+// checkVarRules parses the rules of the given struct type built by varStructType, returning the error describing
+// invalid rules, if any
+func (v *Validator) checkVarRules(structType reflect.Type, rules string, opts *ValidationOptions) *ValidationError {
+	_, problem := v.parseField(structType, structType.Field(0), opts)
+	if problem == nil {
+		return nil
+	}
+	msg := problem.Message
+	if function, _, err := extractFunctionInformation(strings.TrimSpace(problem.Rule)); err == nil {
+		_, isValidator := v.lookupValidator(function)
+		if _, isFilter := v.lookupFilter(function); isFilter && !isValidator {
+			msg = "`" + function + "` is a filter, ValidateVar and ValidateMap only apply validators"
+		}
+	}
+	return newValidationError("invalid rules `"+rules+"`: "+msg, problem.Cause)
+}
+
+// varKey identifies the struct types holding the values validated by ValidateVarNamed, see varStructType
+type varKey struct {
+	valueType        reflect.Type
+	rules            string
+	name             string
+	validatorTagName string
+	labelTagName     string
+}
+
+// varStructType returns the struct type holding values of the given type validated against the given rules, whose
+// single field is labeled with the given name, building it on first use
+func (v *Validator) varStructType(valueType reflect.Type, rules string, name string, opts *ValidationOptions) reflect.Type {
+	key := varKey{valueType: valueType, rules: rules, name: name, validatorTagName: opts.ValidatorTagName, labelTagName: opts.LabelTagName}
+	if structType, ok := v.varTypes.Load(key); ok {
+		return structType.(reflect.Type)
+	}
+
+	tag := tagNames(opts.ValidatorTagName)[0] + ":" + strconv.Quote(rules) + " " + tagNames(opts.LabelTagName)[0] + ":" + strconv.Quote(name)
+	structType := reflect.StructOf([]reflect.StructField{{Name: "Value", Type: valueType, Tag: reflect.StructTag(tag)}})
+	v.varTypes.Store(key, structType)
+	return structType
+}
+
+// ValidateVar ValidateVar validates a single value against the given validators using the default instance.
+//
+// See Validator.ValidateVar for details.
+func ValidateVar(value interface{}, rules string) *ValidationResult {
+	return defaultValidator.ValidateVar(value, rules)
+}
+
+// ValidateVarNamed ValidateVarNamed validates a single value using the default instance, naming the value in field
+// errors with the given name.
+//
+// See Validator.ValidateVarNamed for details.
+func ValidateVarNamed(value interface{}, rules string, name string) *ValidationResult {
+	return defaultValidator.ValidateVarNamed(value, rules, name)
+}
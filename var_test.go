@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestValidateVar(t *testing.T) {
+	v := New()
+
+	// strings
+	assertTrue(t, v.ValidateVar("jo@example.com", "required|email|max(254)").IsValid(), "expected a valid email")
+	res := v.ValidateVar("jo", "length(3,_)")
+	assertEqual(t, []FieldError{{Field: "value", Message: "length (2) must be at least 3", Validator: "length"}}, res.FieldErrors)
+
+	// string pointers, nil or not
+	email := "not an email"
+	res = v.ValidateVar(&email, "required|email")
+	assertEqual(t, 1, len(res.FieldErrors))
+	assertEqual(t, "email", res.FieldErrors[0].Validator)
+
+	var missing *string
+	res = v.ValidateVar(missing, "required|email")
+	assertEqual(t, FieldError{Field: "value", Message: MsgRequired, Validator: "required", Code: CodeMissing}, res.FieldErrors[0])
+	assertTrue(t, v.ValidateVar(missing, "length(3,_)").IsValid(), "expected nil pointers to pass")
+
+	// untyped nil values
+	res = v.ValidateVar(nil, "required")
+	assertEqual(t, []FieldError{{Field: "value", Message: MsgRequired, Validator: "required", Code: CodeMissing}}, res.FieldErrors)
+
+	// integers, named in field errors
+	res = v.ValidateVarNamed(0, "min(1)|max(100)", "page")
+	assertEqual(t, []FieldError{{Field: "page", Message: "value (0) must be at least 1", Validator: "min"}}, res.FieldErrors)
+	assertTrue(t, ValidateVar(uint8(42), "enum(1,42)").IsValid(), "expected a valid enum value")
+	assertTrue(t, v.ValidateVar(int64(50), "min(1)|max(100)").IsValid(), "expected a valid page")
+
+	// invalid rules are reported rather than causing a panic
+	res = v.ValidateVar("x", "required|lenght(3,_)")
+	assertEqual(t, "invalid rules `required|lenght(3,_)`: validator `lenght` not found", res.Error.Error())
+	res = v.ValidateVar(&email, "trim|required")
+	assertEqual(t, "invalid rules `trim|required`: `trim` is a filter, ValidateVar and ValidateMap only apply validators", res.Error.Error())
+	res = v.ValidateVar(10, "min(a)")
+	assertFalse(t, res.IsValid(), "expected invalid arguments to fail")
+	assertNull(t, res.FieldErrors)
+
+	// parsed rules are reused per rules, name and type of value
+	count := func(m *sync.Map) (n int) {
+		m.Range(func(any, any) bool {
+			n++
+			return true
+		})
+		return
+	}
+	cached := New()
+	for i := 0; i < 3; i++ {
+		assertTrue(t, cached.ValidateVarNamed(i+1, "min(1)", "page").IsValid())
+		assertFalse(t, cached.ValidateVarNamed("x", "length(3,_)", "name").IsValid())
+	}
+	assertEqual(t, 2, count(&cached.varTypes))
+	assertEqual(t, 2, count(&cached.cache.backend))
+
+	// rules are parsed again once validators change
+	cached.AddValidator("page", func(*ValidationContext) bool { return false })
+	assertFalse(t, cached.ValidateVarNamed(1, "min(1)|page", "page").IsValid())
+	cached.RemoveValidator("page")
+	assertEqual(t, "invalid rules `min(1)|page`: validator `page` not found", cached.ValidateVarNamed(1, "min(1)|page", "page").Error.Error())
+}